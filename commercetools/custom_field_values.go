@@ -0,0 +1,556 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+// CustomFieldValue is the typed value of a custom field. Only the value
+// matching the type of the field definition is used.
+type CustomFieldValue struct {
+	String          *string
+	Number          *float64
+	Boolean         *bool
+	LocalizedString map[string]string
+	Money           *CustomFieldMoney
+	Reference       *CustomFieldReference
+	Set             []string
+	SetOfMoney      []CustomFieldMoney
+	SetOfReferences []CustomFieldReference
+}
+
+type CustomFieldMoney struct {
+	CurrencyCode string
+	CentAmount   int64
+}
+
+type CustomFieldReference struct {
+	TypeID string
+	ID     string
+}
+
+func customFieldValueSchema() *schema.Schema {
+	money := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"currency_code": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cent_amount": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}
+	reference := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type_id": {
+				Description: "The type of the referenced resource, e.g. `product-type`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+
+	return &schema.Schema{
+		Description: "Custom field with a typed value. Set the value matching the type of the field. " +
+			"Can be combined with `fields`, but every field can only be set once",
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"string": {
+					Description: "Value of String, Enum, LocalizedEnum, Date (YYYY-MM-DD), " +
+						"Time (hh:mm:ss.sss) and DateTime fields",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"number": {
+					Description: "Value of Number fields",
+					Type:        schema.TypeFloat,
+					Optional:    true,
+				},
+				"boolean": {
+					Description: "Value of Boolean fields",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"localized_string": {
+					Description: "Value of LocalizedString fields",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"money": {
+					Description: "Value of Money fields",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem:        money,
+				},
+				"reference": {
+					Description: "Value of Reference fields",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Optional:    true,
+					Elem:        reference,
+				},
+				"set": {
+					Description: "Value of Set fields of strings, enums, numbers, booleans, dates and " +
+						"times. Elements of other types are JSON encoded",
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"set_of_money": {
+					Description: "Value of Set fields of Money",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        money,
+				},
+				"set_of_references": {
+					Description: "Value of Set fields of References",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        reference,
+				},
+			},
+		},
+	}
+}
+
+// encode returns the value of the custom field for the given field type
+func (v CustomFieldValue) encode(t platform.FieldType, name string) (any, error) {
+	switch ft := t.(type) {
+	case platform.CustomFieldBooleanType:
+		if v.Boolean == nil {
+			return nil, fmt.Errorf("value for field '%s' needs to be set as boolean", name)
+		}
+		return *v.Boolean, nil
+
+	case platform.CustomFieldNumberType:
+		if v.Number == nil {
+			return nil, fmt.Errorf("value for field '%s' needs to be set as number", name)
+		}
+		if *v.Number == math.Trunc(*v.Number) {
+			return int64(*v.Number), nil
+		}
+		return *v.Number, nil
+
+	case platform.CustomFieldLocalizedStringType:
+		if v.LocalizedString == nil {
+			return nil, fmt.Errorf("value for field '%s' needs to be set as localized_string", name)
+		}
+		return platform.LocalizedString(v.LocalizedString), nil
+
+	case platform.CustomFieldMoneyType:
+		if v.Money == nil {
+			return nil, fmt.Errorf("value for field '%s' needs to be set as money", name)
+		}
+		return v.Money.encode(name)
+
+	case platform.CustomFieldReferenceType:
+		if v.Reference == nil {
+			return nil, fmt.Errorf("value for field '%s' needs to be set as reference", name)
+		}
+		return v.Reference.encode(), nil
+
+	case platform.CustomFieldSetType:
+		result := []any{}
+		switch ft.ElementType.(type) {
+		case platform.CustomFieldMoneyType:
+			for _, money := range v.SetOfMoney {
+				value, err := money.encode(name)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, value)
+			}
+		case platform.CustomFieldReferenceType:
+			for _, reference := range v.SetOfReferences {
+				result = append(result, reference.encode())
+			}
+		default:
+			for _, element := range v.Set {
+				value, err := CustomFieldEncodeValue(ft.ElementType, name, element)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, value)
+			}
+		}
+		return result, nil
+
+	default:
+		if v.String == nil {
+			return nil, fmt.Errorf("value for field '%s' needs to be set as string", name)
+		}
+		return CustomFieldEncodeValue(t, name, *v.String)
+	}
+}
+
+func (m CustomFieldMoney) encode(name string) (any, error) {
+	if len(m.CurrencyCode) != 3 {
+		return nil, fmt.Errorf("value for field '%s' needs a valid currency_code: '%s'", name, m.CurrencyCode)
+	}
+	return platform.Money{CentAmount: int(m.CentAmount), CurrencyCode: m.CurrencyCode}, nil
+}
+
+func (r CustomFieldReference) encode() any {
+	return map[string]any{"typeId": r.TypeID, "id": r.ID}
+}
+
+// NewCustomFieldValue returns the typed value for a custom field value as
+// returned by commercetools. The kind of value is derived from the value
+// itself, since the values don't include the type of the field.
+func NewCustomFieldValue(value any) (CustomFieldValue, error) {
+	switch v := value.(type) {
+	case string:
+		return CustomFieldValue{String: &v}, nil
+	case bool:
+		return CustomFieldValue{Boolean: &v}, nil
+	case float64:
+		return CustomFieldValue{Number: &v}, nil
+	case int:
+		n := float64(v)
+		return CustomFieldValue{Number: &n}, nil
+	case int64:
+		n := float64(v)
+		return CustomFieldValue{Number: &n}, nil
+	case map[string]any:
+		if money, ok := newCustomFieldMoney(v); ok {
+			return CustomFieldValue{Money: &money}, nil
+		}
+		if reference, ok := newCustomFieldReference(v); ok {
+			return CustomFieldValue{Reference: &reference}, nil
+		}
+		result := map[string]string{}
+		for key, value := range v {
+			s, ok := value.(string)
+			if !ok {
+				return CustomFieldValue{}, fmt.Errorf("unsupported custom field value: %v", v)
+			}
+			result[key] = s
+		}
+		return CustomFieldValue{LocalizedString: result}, nil
+	case []any:
+		return newCustomFieldSetValue(v)
+	default:
+		return CustomFieldValue{}, fmt.Errorf("unsupported custom field value: %v", v)
+	}
+}
+
+func newCustomFieldSetValue(values []any) (CustomFieldValue, error) {
+	var setOfMoney []CustomFieldMoney
+	var setOfReferences []CustomFieldReference
+	for _, value := range values {
+		object, _ := value.(map[string]any)
+		if money, ok := newCustomFieldMoney(object); ok {
+			setOfMoney = append(setOfMoney, money)
+		}
+		if reference, ok := newCustomFieldReference(object); ok {
+			setOfReferences = append(setOfReferences, reference)
+		}
+	}
+	if len(values) > 0 && len(setOfMoney) == len(values) {
+		return CustomFieldValue{SetOfMoney: setOfMoney}, nil
+	}
+	if len(values) > 0 && len(setOfReferences) == len(values) {
+		return CustomFieldValue{SetOfReferences: setOfReferences}, nil
+	}
+
+	result := make([]string, len(values))
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			result[i] = s
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return CustomFieldValue{}, err
+		}
+		result[i] = string(data)
+	}
+	return CustomFieldValue{Set: result}, nil
+}
+
+func newCustomFieldMoney(value map[string]any) (CustomFieldMoney, bool) {
+	currencyCode, ok := value["currencyCode"].(string)
+	if !ok {
+		return CustomFieldMoney{}, false
+	}
+	centAmount, _ := value["centAmount"].(float64)
+	return CustomFieldMoney{CurrencyCode: currencyCode, CentAmount: int64(centAmount)}, true
+}
+
+func newCustomFieldReference(value map[string]any) (CustomFieldReference, bool) {
+	typeID, ok := value["typeId"].(string)
+	if !ok {
+		return CustomFieldReference{}, false
+	}
+	id, ok := value["id"].(string)
+	return CustomFieldReference{TypeID: typeID, ID: id}, ok
+}
+
+// expandCustomFieldValue returns the name and typed value of a field block.
+// Since the SDK doesn't distinguish unset numbers and booleans from zero
+// values these are always set.
+func expandCustomFieldValue(raw map[string]any) (string, CustomFieldValue) {
+	value := CustomFieldValue{}
+	if s, _ := raw["string"].(string); s != "" {
+		value.String = &s
+	}
+	if n, ok := raw["number"].(float64); ok {
+		value.Number = &n
+	}
+	if b, ok := raw["boolean"].(bool); ok {
+		value.Boolean = &b
+	}
+	if values, _ := raw["localized_string"].(map[string]any); len(values) > 0 {
+		value.LocalizedString = make(map[string]string, len(values))
+		for key, s := range values {
+			value.LocalizedString[key] = s.(string)
+		}
+	}
+	if money := expandCustomFieldMoney(raw["money"]); len(money) > 0 {
+		value.Money = &money[0]
+	}
+	if reference := expandCustomFieldReferences(raw["reference"]); len(reference) > 0 {
+		value.Reference = &reference[0]
+	}
+	if values, _ := raw["set"].([]any); len(values) > 0 {
+		value.Set = expandStringArray(values)
+	}
+	value.SetOfMoney = expandCustomFieldMoney(raw["set_of_money"])
+	value.SetOfReferences = expandCustomFieldReferences(raw["set_of_references"])
+	return raw["name"].(string), value
+}
+
+func expandCustomFieldMoney(raw any) []CustomFieldMoney {
+	values, _ := raw.([]any)
+	var result []CustomFieldMoney
+	for _, value := range values {
+		data, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		result = append(result, CustomFieldMoney{
+			CurrencyCode: data["currency_code"].(string),
+			CentAmount:   int64(data["cent_amount"].(int)),
+		})
+	}
+	return result
+}
+
+func expandCustomFieldReferences(raw any) []CustomFieldReference {
+	values, _ := raw.([]any)
+	var result []CustomFieldReference
+	for _, value := range values {
+		data, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		result = append(result, CustomFieldReference{
+			TypeID: data["type_id"].(string),
+			ID:     data["id"].(string),
+		})
+	}
+	return result
+}
+
+func flattenCustomFieldValue(name string, value CustomFieldValue) map[string]any {
+	result := map[string]any{
+		"name": name,
+	}
+	if value.String != nil {
+		result["string"] = *value.String
+	}
+	if value.Number != nil {
+		result["number"] = *value.Number
+	}
+	if value.Boolean != nil {
+		result["boolean"] = *value.Boolean
+	}
+	if value.LocalizedString != nil {
+		result["localized_string"] = value.LocalizedString
+	}
+	if value.Money != nil {
+		result["money"] = flattenCustomFieldMoney([]CustomFieldMoney{*value.Money})
+	}
+	if value.Reference != nil {
+		result["reference"] = flattenCustomFieldReferences([]CustomFieldReference{*value.Reference})
+	}
+	if value.Set != nil {
+		result["set"] = value.Set
+	}
+	if value.SetOfMoney != nil {
+		result["set_of_money"] = flattenCustomFieldMoney(value.SetOfMoney)
+	}
+	if value.SetOfReferences != nil {
+		result["set_of_references"] = flattenCustomFieldReferences(value.SetOfReferences)
+	}
+	return result
+}
+
+func flattenCustomFieldMoney(values []CustomFieldMoney) []map[string]any {
+	result := make([]map[string]any, len(values))
+	for i, value := range values {
+		result[i] = map[string]any{
+			"currency_code": value.CurrencyCode,
+			"cent_amount":   int(value.CentAmount),
+		}
+	}
+	return result
+}
+
+func flattenCustomFieldReferences(values []CustomFieldReference) []map[string]any {
+	result := make([]map[string]any, len(values))
+	for i, value := range values {
+		result[i] = map[string]any{
+			"type_id": value.TypeID,
+			"id":      value.ID,
+		}
+	}
+	return result
+}
+
+// customFieldValueFromCty returns the name and typed value of a planned field
+// block. The value is nil when it is not known yet.
+func customFieldValueFromCty(v cty.Value) (string, any, bool) {
+	name := v.GetAttr("name")
+	if name.IsNull() || !name.IsKnown() {
+		return "", nil, false
+	}
+	if !v.IsWhollyKnown() {
+		return name.AsString(), nil, true
+	}
+
+	value := CustomFieldValue{}
+	if s := v.GetAttr("string"); !s.IsNull() {
+		value.String = ref(s.AsString())
+	}
+	if n := v.GetAttr("number"); !n.IsNull() {
+		f, _ := n.AsBigFloat().Float64()
+		value.Number = &f
+	}
+	if b := v.GetAttr("boolean"); !b.IsNull() {
+		value.Boolean = ref(b.True())
+	}
+	if m := v.GetAttr("localized_string"); !m.IsNull() {
+		value.LocalizedString = map[string]string{}
+		for key, s := range m.AsValueMap() {
+			value.LocalizedString[key] = s.AsString()
+		}
+	}
+	if money := customFieldMoneyFromCty(v.GetAttr("money")); len(money) > 0 {
+		value.Money = &money[0]
+	}
+	if reference := customFieldReferencesFromCty(v.GetAttr("reference")); len(reference) > 0 {
+		value.Reference = &reference[0]
+	}
+	if s := v.GetAttr("set"); !s.IsNull() {
+		value.Set = []string{}
+		for _, element := range s.AsValueSlice() {
+			value.Set = append(value.Set, element.AsString())
+		}
+	}
+	value.SetOfMoney = customFieldMoneyFromCty(v.GetAttr("set_of_money"))
+	value.SetOfReferences = customFieldReferencesFromCty(v.GetAttr("set_of_references"))
+	return name.AsString(), value, true
+}
+
+func customFieldMoneyFromCty(v cty.Value) []CustomFieldMoney {
+	if v.IsNull() {
+		return nil
+	}
+	var result []CustomFieldMoney
+	for _, element := range v.AsValueSlice() {
+		amount, _ := element.GetAttr("cent_amount").AsBigFloat().Int64()
+		result = append(result, CustomFieldMoney{
+			CurrencyCode: element.GetAttr("currency_code").AsString(),
+			CentAmount:   amount,
+		})
+	}
+	return result
+}
+
+func customFieldReferencesFromCty(v cty.Value) []CustomFieldReference {
+	if v.IsNull() {
+		return nil
+	}
+	var result []CustomFieldReference
+	for _, element := range v.AsValueSlice() {
+		result = append(result, CustomFieldReference{
+			TypeID: element.GetAttr("type_id").AsString(),
+			ID:     element.GetAttr("id").AsString(),
+		})
+	}
+	return result
+}
+
+// MergeCustomFieldValues merges the JSON encoded values and the typed values
+// of the custom fields. Every field can only be set once.
+func MergeCustomFieldValues(fields map[string]any, typed map[string]any) (map[string]any, error) {
+	result := make(map[string]any, len(fields)+len(typed))
+	for name, value := range fields {
+		result[name] = value
+	}
+	for name, value := range typed {
+		if _, ok := result[name]; ok {
+			return nil, fmt.Errorf("custom field '%s' is set in both fields and a field block", name)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// CustomFieldChanges returns the encoded values of the custom fields which are
+// changed or added, and nil for the fields which are removed. The values are
+// compared after encoding them, so changing the representation of a value
+// (e.g. the order of keys in a JSON object) doesn't result in an update.
+func CustomFieldChanges(t *platform.Type, encode func(t *platform.Type, name string, value any) (any, error), old, new map[string]any) (map[string]any, error) {
+	result := map[string]any{}
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			result[name] = nil
+		}
+	}
+
+	for name, value := range new {
+		encoded, err := encode(t, name, value)
+		if err != nil {
+			return nil, err
+		}
+		if previous, ok := old[name]; ok {
+			previousEncoded, err := encode(t, name, previous)
+			if err == nil && customFieldValuesEqual(previousEncoded, encoded) {
+				continue
+			}
+		}
+		result[name] = encoded
+	}
+	return result, nil
+}
+
+func customFieldValuesEqual(a, b any) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+	if aErr != nil || bErr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(aData) == string(bData)
+}
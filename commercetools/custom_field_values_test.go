@@ -0,0 +1,284 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+)
+
+var customFieldValueTests = []struct {
+	name  string
+	typ   platform.FieldType
+	value CustomFieldValue
+	// The value as sent to and returned by commercetools
+	native any
+}{
+	{
+		name:   "string",
+		typ:    platform.CustomFieldStringType{},
+		value:  CustomFieldValue{String: ref("foobar")},
+		native: "foobar",
+	},
+	{
+		name:   "enum",
+		typ:    testCustomFieldEnumType,
+		value:  CustomFieldValue{String: ref("value1")},
+		native: "value1",
+	},
+	{
+		name:   "localized enum",
+		typ:    platform.CustomFieldLocalizedEnumType{Values: []platform.CustomFieldLocalizedEnumValue{{Key: "value1"}}},
+		value:  CustomFieldValue{String: ref("value1")},
+		native: "value1",
+	},
+	{
+		name:   "date",
+		typ:    platform.CustomFieldDateType{},
+		value:  CustomFieldValue{String: ref("2023-08-29")},
+		native: "2023-08-29",
+	},
+	{
+		name:   "time",
+		typ:    platform.CustomFieldTimeType{},
+		value:  CustomFieldValue{String: ref("20:22:11.123")},
+		native: "20:22:11.123",
+	},
+	{
+		name:   "datetime",
+		typ:    platform.CustomFieldDateTimeType{},
+		value:  CustomFieldValue{String: ref("2023-08-29T20:22:11.123Z")},
+		native: "2023-08-29T20:22:11.123Z",
+	},
+	{
+		name:   "boolean",
+		typ:    platform.CustomFieldBooleanType{},
+		value:  CustomFieldValue{Boolean: ref(true)},
+		native: true,
+	},
+	{
+		name:   "integer number",
+		typ:    platform.CustomFieldNumberType{},
+		value:  CustomFieldValue{Number: ref(10.0)},
+		native: float64(10),
+	},
+	{
+		name:   "decimal number",
+		typ:    platform.CustomFieldNumberType{},
+		value:  CustomFieldValue{Number: ref(1.5)},
+		native: 1.5,
+	},
+	{
+		name:   "localized string",
+		typ:    platform.CustomFieldLocalizedStringType{},
+		value:  CustomFieldValue{LocalizedString: map[string]string{"en": "foo", "nl": "bar"}},
+		native: map[string]any{"en": "foo", "nl": "bar"},
+	},
+	{
+		name:   "money",
+		typ:    platform.CustomFieldMoneyType{},
+		value:  CustomFieldValue{Money: &CustomFieldMoney{CurrencyCode: "EUR", CentAmount: 1500}},
+		native: map[string]any{"currencyCode": "EUR", "centAmount": float64(1500)},
+	},
+	{
+		name:   "reference",
+		typ:    platform.CustomFieldReferenceType{ReferenceTypeId: platform.CustomFieldReferenceValueProductType},
+		value:  CustomFieldValue{Reference: &CustomFieldReference{TypeID: "product-type", ID: "1234"}},
+		native: map[string]any{"typeId": "product-type", "id": "1234"},
+	},
+	{
+		name:   "set of strings",
+		typ:    platform.CustomFieldSetType{ElementType: platform.CustomFieldStringType{}},
+		value:  CustomFieldValue{Set: []string{"foo", "bar"}},
+		native: []any{"foo", "bar"},
+	},
+	{
+		name:   "set of enums",
+		typ:    platform.CustomFieldSetType{ElementType: testCustomFieldEnumType},
+		value:  CustomFieldValue{Set: []string{"value1", "value2"}},
+		native: []any{"value1", "value2"},
+	},
+	{
+		name:   "set of numbers",
+		typ:    platform.CustomFieldSetType{ElementType: platform.CustomFieldNumberType{}},
+		value:  CustomFieldValue{Set: []string{"1", "2"}},
+		native: []any{float64(1), float64(2)},
+	},
+	{
+		name:   "set of booleans",
+		typ:    platform.CustomFieldSetType{ElementType: platform.CustomFieldBooleanType{}},
+		value:  CustomFieldValue{Set: []string{"true", "false"}},
+		native: []any{true, false},
+	},
+	{
+		name:  "set of money",
+		typ:   platform.CustomFieldSetType{ElementType: platform.CustomFieldMoneyType{}},
+		value: CustomFieldValue{SetOfMoney: []CustomFieldMoney{{CurrencyCode: "EUR", CentAmount: 100}, {CurrencyCode: "USD", CentAmount: 200}}},
+		native: []any{
+			map[string]any{"currencyCode": "EUR", "centAmount": float64(100)},
+			map[string]any{"currencyCode": "USD", "centAmount": float64(200)},
+		},
+	},
+	{
+		name:  "set of references",
+		typ:   platform.CustomFieldSetType{ElementType: platform.CustomFieldReferenceType{}},
+		value: CustomFieldValue{SetOfReferences: []CustomFieldReference{{TypeID: "zone", ID: "1"}, {TypeID: "zone", ID: "2"}}},
+		native: []any{
+			map[string]any{"typeId": "zone", "id": "1"},
+			map[string]any{"typeId": "zone", "id": "2"},
+		},
+	},
+}
+
+// normalizeJSON returns the value as it is decoded from JSON
+func normalizeJSON(t *testing.T, value any) any {
+	data, err := json.Marshal(value)
+	assert.NoError(t, err)
+	var result any
+	assert.NoError(t, json.Unmarshal(data, &result))
+	return result
+}
+
+func TestCustomFieldValueEncode(t *testing.T) {
+	for _, tt := range customFieldValueTests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := CustomFieldEncodeValue(tt.typ, "some_field", tt.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.native, normalizeJSON(t, encoded))
+		})
+	}
+}
+
+func TestNewCustomFieldValue(t *testing.T) {
+	for _, tt := range customFieldValueTests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := NewCustomFieldValue(tt.native)
+			assert.NoError(t, err)
+
+			// Set elements are returned as strings, so compare the encoded values
+			encoded, err := CustomFieldEncodeValue(tt.typ, "some_field", value)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.native, normalizeJSON(t, encoded))
+		})
+	}
+}
+
+func TestExpandFlattenCustomFieldValue(t *testing.T) {
+	for _, tt := range customFieldValueTests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"custom": CustomFieldSchema()}, map[string]any{})
+			err := s.Set("custom", []map[string]any{{
+				"type_id": "type-id",
+				"field":   []any{flattenCustomFieldValue("some_field", tt.value)},
+			}})
+			assert.NoError(t, err)
+
+			fields, err := customFieldsFromData(firstElementFromSlice(s.Get("custom").([]any)))
+			assert.NoError(t, err)
+
+			encoded, err := CustomFieldEncodeValue(tt.typ, "some_field", fields["some_field"])
+			assert.NoError(t, err)
+			assert.Equal(t, tt.native, normalizeJSON(t, encoded))
+		})
+	}
+}
+
+func TestCustomFieldValueEncodeWrongKind(t *testing.T) {
+	var cases = []struct {
+		typ      platform.FieldType
+		value    CustomFieldValue
+		expected string
+	}{
+		{platform.CustomFieldStringType{}, CustomFieldValue{Number: ref(1.0)}, "value for field 'some_field' needs to be set as string"},
+		{platform.CustomFieldBooleanType{}, CustomFieldValue{String: ref("true")}, "value for field 'some_field' needs to be set as boolean"},
+		{platform.CustomFieldNumberType{}, CustomFieldValue{String: ref("1")}, "value for field 'some_field' needs to be set as number"},
+		{platform.CustomFieldMoneyType{}, CustomFieldValue{}, "value for field 'some_field' needs to be set as money"},
+		{platform.CustomFieldMoneyType{}, CustomFieldValue{Money: &CustomFieldMoney{CurrencyCode: "EURO"}}, "value for field 'some_field' needs a valid currency_code: 'EURO'"},
+		{platform.CustomFieldReferenceType{}, CustomFieldValue{}, "value for field 'some_field' needs to be set as reference"},
+		{platform.CustomFieldLocalizedStringType{}, CustomFieldValue{String: ref("foo")}, "value for field 'some_field' needs to be set as localized_string"},
+		{testCustomFieldEnumType, CustomFieldValue{String: ref("value3")}, "value for field 'some_field' needs to be one of the enum keys value1, value2: 'value3'"},
+	}
+
+	for _, tc := range cases {
+		_, err := CustomFieldEncodeValue(tc.typ, "some_field", tc.value)
+		assert.EqualError(t, err, tc.expected)
+	}
+}
+
+func TestCustomFieldChanges(t *testing.T) {
+	ct := &platform.Type{
+		FieldDefinitions: []platform.FieldDefinition{
+			{Name: "name", Type: platform.CustomFieldLocalizedStringType{}},
+			{Name: "count", Type: platform.CustomFieldNumberType{}},
+			{Name: "status", Type: platform.CustomFieldStringType{}},
+		},
+	}
+
+	changes, err := CustomFieldChanges(ct, CustomFieldEncodeType,
+		map[string]any{
+			"name":   `{"en": "foo", "nl": "bar"}`,
+			"count":  "10",
+			"status": "active",
+		},
+		map[string]any{
+			"name":  CustomFieldValue{LocalizedString: map[string]string{"nl": "bar", "en": "foo"}},
+			"count": "11",
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"count": int64(11), "status": nil}, changes)
+
+	changes, err = CustomFieldChanges(ct, CustomFieldEncodeType,
+		map[string]any{"name": `{"en": "foo", "nl": "bar"}`},
+		map[string]any{"name": `{"nl":"bar","en":"foo"}`},
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestMergeCustomFieldValues(t *testing.T) {
+	result, err := MergeCustomFieldValues(
+		map[string]any{"a": "1"},
+		map[string]any{"b": CustomFieldValue{String: ref("2")}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1", "b": CustomFieldValue{String: ref("2")}}, result)
+
+	_, err = MergeCustomFieldValues(map[string]any{"a": "1"}, map[string]any{"a": CustomFieldValue{}})
+	assert.EqualError(t, err, "custom field 'a' is set in both fields and a field block")
+}
+
+func TestFlattenCustomFields(t *testing.T) {
+	s := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"custom": CustomFieldSchema()}, map[string]any{})
+	err := s.Set("custom", []map[string]any{{
+		"type_id": "type-id",
+		"fields": map[string]any{
+			"money": `{"centAmount": 100, "currencyCode": "EUR"}`,
+		},
+		"field": []any{
+			map[string]any{"name": "count", "number": 10.0},
+		},
+	}})
+	assert.NoError(t, err)
+
+	result := flattenCustomFields(&platform.CustomFields{
+		Type: platform.TypeReference{ID: "type-id"},
+		Fields: platform.FieldContainer{
+			"money": map[string]any{"currencyCode": "EUR", "centAmount": float64(100)},
+			"count": float64(11),
+			"name":  "foo",
+		},
+	}, s.Get("custom").([]any))
+
+	assert.Equal(t, "type-id", result[0]["type_id"])
+	assert.Equal(t, map[string]any{
+		// The formatting of the current value is kept
+		"money": `{"centAmount": 100, "currencyCode": "EUR"}`,
+		"name":  "foo",
+	}, result[0]["fields"])
+	assert.Equal(t, []any{
+		map[string]any{"name": "count", "number": float64(11)},
+	}, result[0]["field"])
+}
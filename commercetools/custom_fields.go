@@ -3,18 +3,20 @@ package commercetools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
-)
 
-var cacheTypes map[string]*platform.Type
-var cacheTypesLock sync.Mutex
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
 
 func CustomFieldSchema() *schema.Schema {
 	return &schema.Schema{
@@ -31,21 +33,26 @@ func CustomFieldSchema() *schema.Schema {
 					Type: schema.TypeMap,
 					Description: "Custom fields for this resource. Note that " +
 						"the values need to be provided as JSON encoded " +
-						"strings: `my-value = jsonencode({\"key\": \"value\"})`",
-					Optional: true,
+						"strings: `my-value = jsonencode({\"key\": \"value\"})`. " +
+						"The values are validated against the type during the plan when the id of the " +
+						"type is known. Fields and enum values the type doesn't have yet aren't rejected, " +
+						"since they may be added to the type in the same apply",
+					Optional:         true,
+					DiffSuppressFunc: suppressEquivalentCustomFieldValue,
 				},
+				"field": customFieldValueSchema(),
 			},
 		},
 	}
 }
 
-func CreateCustomFieldDraft(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, d *schema.ResourceData) (*platform.CustomFieldsDraft, error) {
+func CreateCustomFieldDraft(ctx context.Context, m any, d *schema.ResourceData) (*platform.CustomFieldsDraft, error) {
 	customData, err := elementFromList(d, "custom")
 	if err != nil {
 		return nil, err
 	}
 
-	t, err := getTypeResourceFromResourceData(ctx, client, d)
+	t, err := getTypeResourceFromResourceData(ctx, m, d)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +101,10 @@ func CustomFieldEncodeType(t *platform.Type, name string, value any) (any, error
 }
 
 func CustomFieldEncodeValue(t platform.FieldType, name string, value any) (any, error) {
+	if typed, ok := value.(CustomFieldValue); ok {
+		return typed.encode(t, name)
+	}
+
 	switch v := t.(type) {
 	case platform.CustomFieldLocalizedStringType:
 		result := platform.LocalizedString{}
@@ -154,8 +165,8 @@ func CustomFieldEncodeValue(t platform.FieldType, name string, value any) (any,
 
 	case platform.CustomFieldMoneyType:
 		var result *platform.CentPrecisionMoney
-		if err := json.Unmarshal([]byte(value.(string)), &result); err != nil {
-			return nil, fmt.Errorf("value for field '%s' needs to be a CentPrecisionMoney: '%v'", name, value)
+		if err := json.Unmarshal([]byte(value.(string)), &result); err != nil || result == nil || len(result.CurrencyCode) != 3 {
+			return nil, fmt.Errorf("value for field '%s' needs to be a CentPrecisionMoney with a currencyCode: '%v'", name, value)
 		}
 		return result, nil
 
@@ -180,7 +191,21 @@ func CustomFieldEncodeValue(t platform.FieldType, name string, value any) (any,
 		}
 		return result.Format("15:04:05.000"), nil
 
-	case platform.CustomFieldEnumType, platform.CustomFieldLocalizedEnumType, platform.CustomFieldStringType:
+	case platform.CustomFieldEnumType:
+		keys := make([]string, len(v.Values))
+		for i := range v.Values {
+			keys[i] = v.Values[i].Key
+		}
+		return customFieldEnumValue(name, value, keys)
+
+	case platform.CustomFieldLocalizedEnumType:
+		keys := make([]string, len(v.Values))
+		for i := range v.Values {
+			keys[i] = v.Values[i].Key
+		}
+		return customFieldEnumValue(name, value, keys)
+
+	case platform.CustomFieldStringType:
 		return value, nil
 
 	default:
@@ -188,6 +213,179 @@ func CustomFieldEncodeValue(t platform.FieldType, name string, value any) (any,
 	}
 }
 
+func customFieldEnumValue(name string, value any, keys []string) (any, error) {
+	if !slices.Contains(keys, value.(string)) {
+		return nil, &unknownEnumKeyError{fmt.Errorf("value for field '%s' needs to be one of the enum keys %s: '%v'",
+			name, strings.Join(keys, ", "), value)}
+	}
+	return value, nil
+}
+
+// unknownEnumKeyError is returned for enum values which are not one of the
+// keys of the enum type
+type unknownEnumKeyError struct {
+	error
+}
+
+func (e *unknownEnumKeyError) Unwrap() error {
+	return e.error
+}
+
+// CustomFieldError is a validation error for the value of a single custom field
+type CustomFieldError struct {
+	Field string
+	Err   error
+
+	// Undefined is set for fields and enum keys which the type doesn't define.
+	// These may be added to the type in the same apply, so they are not
+	// reported as errors during the plan.
+	Undefined bool
+}
+
+func (e CustomFieldError) Error() string {
+	return e.Err.Error()
+}
+
+// ValidateCustomFields validates the custom field values against the field
+// definitions of the type. It reports unknown fields, missing required fields
+// and values which can not be encoded for the type of the field. A nil value
+// is a value which is not known yet, and is only checked for existence.
+func ValidateCustomFields(t *platform.Type, fields map[string]any) []CustomFieldError {
+	definitions := map[string]platform.FieldDefinition{}
+	for _, field := range t.FieldDefinitions {
+		definitions[field.Name] = field
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var result []CustomFieldError
+	for _, name := range names {
+		definition, ok := definitions[name]
+		if !ok {
+			result = append(result, CustomFieldError{
+				Field:     name,
+				Err:       fmt.Errorf("no field '%s' defined in type %s (%s)", name, t.Key, t.ID),
+				Undefined: true,
+			})
+			continue
+		}
+		if fields[name] == nil {
+			continue
+		}
+		if _, err := CustomFieldEncodeValue(definition.Type, name, fields[name]); err != nil {
+			var unknownKey *unknownEnumKeyError
+			result = append(result, CustomFieldError{Field: name, Err: err, Undefined: errors.As(err, &unknownKey)})
+		}
+	}
+
+	for _, definition := range t.FieldDefinitions {
+		if _, ok := fields[definition.Name]; definition.Required && !ok {
+			result = append(result, CustomFieldError{
+				Field: definition.Name,
+				Err:   fmt.Errorf("field '%s' is required by type %s (%s)", definition.Name, t.Key, t.ID),
+			})
+		}
+	}
+	return result
+}
+
+// validateCustomFields validates the planned custom field values against the
+// type when the custom fields are changed and the type id is known. This
+// reports invalid values during the plan instead of during the apply. Fields
+// and enum keys the type doesn't define are skipped, since a commercetools_type
+// in the same plan may add them; the SDK can't report these as warnings.
+func validateCustomFields(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if !d.HasChange("custom") {
+		return nil
+	}
+	typeID, fields, ok, err := plannedCustomFields(d.GetRawPlan())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	t, err := getTypeCache(m).Get(ctx, getClient(m), typeID)
+	if err != nil {
+		if utils.IsResourceNotFoundError(err) {
+			return fmt.Errorf("custom.0.type_id: type %s does not exist", typeID)
+		}
+		return fmt.Errorf("failed to fetch type %s to validate the custom fields: %w", typeID, err)
+	}
+
+	var errs []error
+	for _, fieldErr := range ValidateCustomFields(t, fields) {
+		if fieldErr.Undefined {
+			log.Printf("[WARN] custom field %s is not validated: %s", fieldErr.Field, fieldErr.Err)
+			continue
+		}
+		if _, ok := fields[fieldErr.Field].(CustomFieldValue); ok {
+			errs = append(errs, fmt.Errorf("custom.0.field (%s): %w", fieldErr.Field, fieldErr.Err))
+			continue
+		}
+		errs = append(errs, fmt.Errorf("custom.0.fields.%s: %w", fieldErr.Field, fieldErr.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// plannedCustomFields returns the type id and the field values of the custom
+// block in the planned state. Values which are not known yet are nil. It
+// returns false when there are no custom fields or when the type id or the
+// names of the fields are not known yet.
+func plannedCustomFields(plan cty.Value) (string, map[string]any, bool, error) {
+	if plan.IsNull() || !plan.IsKnown() {
+		return "", nil, false, nil
+	}
+	custom := plan.GetAttr("custom")
+	if custom.IsNull() || !custom.IsKnown() || custom.LengthInt() == 0 {
+		return "", nil, false, nil
+	}
+
+	data := custom.Index(cty.NumberIntVal(0))
+	typeID := data.GetAttr("type_id")
+	values := data.GetAttr("fields")
+	blocks := data.GetAttr("field")
+	if typeID.IsNull() || !typeID.IsKnown() || !values.IsKnown() || !blocks.IsKnown() {
+		return "", nil, false, nil
+	}
+
+	fields := map[string]any{}
+	if !values.IsNull() {
+		for name, value := range values.AsValueMap() {
+			if value.IsNull() || !value.IsKnown() {
+				fields[name] = nil
+				continue
+			}
+			fields[name] = value.AsString()
+		}
+	}
+
+	typed := map[string]any{}
+	if !blocks.IsNull() {
+		for _, block := range blocks.AsValueSlice() {
+			name, value, ok := customFieldValueFromCty(block)
+			if !ok {
+				return "", nil, false, nil
+			}
+			if _, ok := typed[name]; ok {
+				return "", nil, false, fmt.Errorf("custom field '%s' is set in multiple field blocks", name)
+			}
+			typed[name] = value
+		}
+	}
+
+	result, err := MergeCustomFieldValues(fields, typed)
+	if err != nil {
+		return "", nil, false, err
+	}
+	return typeID.AsString(), result, true, nil
+}
+
 func CreateCustomFieldDraftRaw(data map[string]any, t *platform.Type) (*platform.CustomFieldsDraft, error) {
 	if data["type_id"] == nil {
 		return nil, nil
@@ -198,14 +396,13 @@ func CreateCustomFieldDraftRaw(data map[string]any, t *platform.Type) (*platform
 		draft.Type.ID = stringRef(val)
 	}
 
-	fieldTypes := map[string]platform.FieldType{}
-	for _, field := range t.FieldDefinitions {
-		fieldTypes[field.Name] = field.Type
+	fields, err := customFieldsFromData(data)
+	if err != nil {
+		return nil, err
 	}
-
-	if raw, ok := data["fields"].(map[string]any); ok {
+	if len(fields) > 0 {
 		container := platform.FieldContainer{}
-		for key, value := range raw {
+		for key, value := range fields {
 			enc, err := CustomFieldEncodeType(t, key, value)
 			if err != nil {
 				return nil, err
@@ -218,31 +415,91 @@ func CreateCustomFieldDraftRaw(data map[string]any, t *platform.Type) (*platform
 	return draft, nil
 }
 
-func flattenCustomFields(c *platform.CustomFields) []map[string]any {
+// customFieldsFromData returns the values of the JSON encoded fields and the
+// typed field blocks of the custom block
+func customFieldsFromData(data map[string]any) (map[string]any, error) {
+	fields, _ := data["fields"].(map[string]any)
+
+	var blocks []any
+	switch v := data["field"].(type) {
+	case *schema.Set:
+		blocks = v.List()
+	case []any:
+		blocks = v
+	}
+
+	typed := map[string]any{}
+	for _, raw := range blocks {
+		name, value := expandCustomFieldValue(raw.(map[string]any))
+		if _, ok := typed[name]; ok {
+			return nil, fmt.Errorf("custom field '%s' is set in multiple field blocks", name)
+		}
+		typed[name] = value
+	}
+	return MergeCustomFieldValues(fields, typed)
+}
+
+// suppressEquivalentCustomFieldValue suppresses the diff of JSON encoded
+// custom field values which only differ in whitespace or the order of keys
+func suppressEquivalentCustomFieldValue(k, old, new string, _ *schema.ResourceData) bool {
+	if strings.HasSuffix(k, ".%") {
+		return false
+	}
+	return utils.JSONEqual(old, new)
+}
+
+// flattenCustomFields returns the custom fields for the state. Fields which
+// are set with a typed field block in the current state are returned as field
+// block, others as JSON encoded value. JSON encoded values which are equal to
+// the current value are kept as is, to avoid differences in formatting.
+func flattenCustomFields(c *platform.CustomFields, current []any) []map[string]any {
 	if c == nil {
 		return nil
 	}
 	result := map[string]any{}
 	result["type_id"] = c.Type.ID
 
+	currentData := firstElementFromSlice(current)
+	currentFields, _ := currentData["fields"].(map[string]any)
+	typed := map[string]bool{}
+	if blocks, ok := currentData["field"].(*schema.Set); ok {
+		for _, raw := range blocks.List() {
+			typed[raw.(map[string]any)["name"].(string)] = true
+		}
+	}
+
 	fields := map[string]any{}
+	var blocks []any
 	for key, value := range c.Fields {
+		if typed[key] {
+			if v, err := NewCustomFieldValue(value); err == nil {
+				blocks = append(blocks, flattenCustomFieldValue(key, v))
+				continue
+			}
+		}
+
+		var encoded string
 		switch value.(type) {
 		case string:
-			fields[key] = value
+			encoded = value.(string)
 		default:
 			if v, err := json.Marshal(value); err == nil {
-				fields[key] = string(v)
+				encoded = string(v)
 			} else {
 				panic(err)
 			}
 		}
+		if previous, ok := currentFields[key].(string); ok && utils.JSONEqual(previous, encoded) {
+			encoded = previous
+		}
+		fields[key] = encoded
 	}
 	result["fields"] = fields
+	result["field"] = blocks
 	return []map[string]any{result}
 }
 
-func getTypeResourceFromResourceData(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, d *schema.ResourceData) (*platform.Type, error) {
+func getTypeResourceFromResourceData(ctx context.Context, m any, d *schema.ResourceData) (*platform.Type, error) {
 	custom := d.Get("custom")
 	data := firstElementFromSlice(custom.([]any))
 	if data == nil {
@@ -250,46 +507,13 @@ func getTypeResourceFromResourceData(ctx context.Context, client *platform.ByPro
 	}
 
 	if typeId, ok := data["type_id"].(string); ok {
-		return GetTypeResource(ctx, CreateTypeFetcher(client), typeId)
+		return getTypeCache(m).Get(ctx, getClient(m), typeId)
 	}
 	return nil, fmt.Errorf("missing type_id for custom fields")
 }
 
-func CreateTypeFetcher(client *platform.ByProjectKeyRequestBuilder) func(ctx context.Context, id string) (*platform.Type, error) {
-	return func(ctx context.Context, id string) (*platform.Type, error) {
-		return client.Types().WithId(id).Get().Execute(ctx)
-	}
-}
-
-// GetTypeResource returns the platform.Type for the type_id in the custom
-// field. The type_id is cached to minimize API calls when multiple resource
-// use the same type
-func GetTypeResource(ctx context.Context, getTypeByIdFunc func(ctx context.Context, id string) (*platform.Type, error), typeId string) (*platform.Type, error) {
-	cacheTypesLock.Lock()
-	defer cacheTypesLock.Unlock()
-	if cacheTypes == nil {
-		cacheTypes = make(map[string]*platform.Type)
-	}
-	if t, exists := cacheTypes[typeId]; exists {
-		if t == nil {
-			return nil, fmt.Errorf("type %s not in cache due to previous error", typeId)
-		}
-		return t, nil
-	}
-
-	t, err := getTypeByIdFunc(ctx, typeId)
-	if err != nil {
-		cacheTypes[typeId] = nil
-		return nil, err
-	}
-
-	cacheTypes[typeId] = t
-
-	return t, nil
-}
-
-func CustomFieldUpdateActions[T SetCustomTypeAction, F SetCustomFieldAction](ctx context.Context, client *platform.ByProjectKeyRequestBuilder, d *schema.ResourceData) ([]any, error) {
-	t, err := getTypeResourceFromResourceData(ctx, client, d)
+func CustomFieldUpdateActions[T SetCustomTypeAction, F SetCustomFieldAction](ctx context.Context, m any, d *schema.ResourceData) ([]any, error) {
+	t, err := getTypeResourceFromResourceData(ctx, m, d)
 	if err != nil {
 		return nil, err
 	}
@@ -313,34 +537,32 @@ func CustomFieldUpdateActions[T SetCustomTypeAction, F SetCustomFieldAction](ctx
 		if err != nil {
 			return nil, err
 		}
-		action := platform.StoreSetCustomTypeAction{
+		action := T{
 			Type:   &value.Type,
 			Fields: value.Fields,
 		}
 		return []any{action}, nil
 	}
 
-	changes := DiffSlices(
-		oldData["fields"].(map[string]any),
-		newData["fields"].(map[string]any))
+	oldFields, err := customFieldsFromData(oldData)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := customFieldsFromData(newData)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := CustomFieldChanges(t, CustomFieldEncodeType, oldFields, newFields)
+	if err != nil {
+		return nil, err
+	}
 
 	var result []any
 	for key := range changes {
-		if changes[key] == nil {
-			result = append(result, F{
-				Name:  key,
-				Value: nil,
-			})
-		} else {
-			val, err := CustomFieldEncodeType(t, key, changes[key])
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, F{
-				Name:  key,
-				Value: val,
-			})
-		}
+		result = append(result, F{
+			Name:  key,
+			Value: changes[key],
+		})
 	}
 	return result, nil
 }
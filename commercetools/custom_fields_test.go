@@ -4,14 +4,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"math/rand"
-	"sync"
 	"testing"
 	"text/template"
-	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/stretchr/testify/assert"
@@ -62,6 +61,30 @@ var customFieldEncodeValueTests = []struct {
 		value:       `{"id":"98edd6e4-1702-45d5-8bc0-bbb792a4a839","typeId":"zone"}`,
 		expectedVal: map[string]interface{}{"id": "98edd6e4-1702-45d5-8bc0-bbb792a4a839", "typeId": "zone"},
 	},
+
+	//CustomFieldEnumType
+	{typ: testCustomFieldEnumType, value: "value1", expectedVal: "value1"},
+	{typ: testCustomFieldEnumType, value: "value3", hasError: true},
+	{typ: platform.CustomFieldLocalizedEnumType{Values: []platform.CustomFieldLocalizedEnumValue{{Key: "value1"}}}, value: "value1", expectedVal: "value1"},
+	{typ: platform.CustomFieldLocalizedEnumType{Values: []platform.CustomFieldLocalizedEnumValue{{Key: "value1"}}}, value: "value2", hasError: true},
+	{typ: platform.CustomFieldSetType{ElementType: testCustomFieldEnumType}, value: `["value1", "value3"]`, hasError: true},
+
+	//CustomFieldMoneyType
+	{
+		typ:         platform.CustomFieldMoneyType{},
+		value:       `{"centAmount":100,"currencyCode":"EUR"}`,
+		expectedVal: &platform.CentPrecisionMoney{CentAmount: 100, CurrencyCode: "EUR"},
+	},
+	{typ: platform.CustomFieldMoneyType{}, value: `{"centAmount":100}`, hasError: true},
+	{typ: platform.CustomFieldMoneyType{}, value: `100`, hasError: true},
+
+	//CustomFieldDateType
+	{typ: platform.CustomFieldDateType{}, value: "2023-08-29", expectedVal: "2023-08-29"},
+	{typ: platform.CustomFieldDateType{}, value: "29-08-2023", hasError: true},
+}
+
+var testCustomFieldEnumType = platform.CustomFieldEnumType{
+	Values: []platform.CustomFieldEnumValue{{Key: "value1"}, {Key: "value2"}},
 }
 
 func TestCustomFieldEncodeValue(t *testing.T) {
@@ -78,6 +101,179 @@ func TestCustomFieldEncodeValue(t *testing.T) {
 	}
 }
 
+func TestValidateCustomFields(t *testing.T) {
+	ct := &platform.Type{
+		ID:  "type-id",
+		Key: "my-type",
+		FieldDefinitions: []platform.FieldDefinition{
+			{Name: "name", Type: platform.CustomFieldStringType{}, Required: true},
+			{Name: "count", Type: platform.CustomFieldNumberType{}},
+			{Name: "status", Type: testCustomFieldEnumType},
+			{Name: "statuses", Type: platform.CustomFieldSetType{ElementType: testCustomFieldEnumType}},
+			{Name: "price", Type: platform.CustomFieldMoneyType{}},
+		},
+	}
+
+	var cases = []struct {
+		name      string
+		fields    map[string]any
+		expected  map[string]string
+		undefined []string
+	}{
+		{
+			name: "valid",
+			fields: map[string]any{
+				"name":     "foo",
+				"count":    "10",
+				"status":   "value1",
+				"statuses": `["value1", "value2"]`,
+				"price":    `{"centAmount":100,"currencyCode":"EUR"}`,
+			},
+		},
+		{
+			name:     "unknown values",
+			fields:   map[string]any{"name": nil, "count": nil},
+			expected: nil,
+		},
+		{
+			name:   "unknown field",
+			fields: map[string]any{"name": "foo", "colour": "red"},
+			expected: map[string]string{
+				"colour": "no field 'colour' defined in type my-type (type-id)",
+			},
+			undefined: []string{"colour"},
+		},
+		{
+			name:   "missing required field",
+			fields: map[string]any{"count": "10"},
+			expected: map[string]string{
+				"name": "field 'name' is required by type my-type (type-id)",
+			},
+		},
+		{
+			name: "invalid values",
+			fields: map[string]any{
+				"name":     "foo",
+				"count":    "ten",
+				"status":   "value3",
+				"statuses": `["value1", "value3"]`,
+				"price":    `{"centAmount":100}`,
+			},
+			expected: map[string]string{
+				"count":    "value for field 'count' needs to be a number: 'ten'",
+				"status":   "value for field 'status' needs to be one of the enum keys value1, value2: 'value3'",
+				"statuses": "value for field 'statuses' needs to be one of the enum keys value1, value2: 'value3'",
+				"price":    "value for field 'price' needs to be a CentPrecisionMoney with a currencyCode: '{\"centAmount\":100}'",
+			},
+			undefined: []string{"status", "statuses"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result map[string]string
+			var undefined []string
+			for _, err := range ValidateCustomFields(ct, tc.fields) {
+				if result == nil {
+					result = map[string]string{}
+				}
+				result[err.Field] = err.Error()
+				if err.Undefined {
+					undefined = append(undefined, err.Field)
+				}
+			}
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.undefined, undefined)
+		})
+	}
+}
+
+func TestPlannedCustomFields(t *testing.T) {
+	customType := CustomFieldSchema().Elem.(*schema.Resource).CoreConfigSchema().ImpliedType()
+	fieldType := customFieldValueSchema().Elem.(*schema.Resource).CoreConfigSchema().ImpliedType()
+	object := func(objectType cty.Type, values map[string]cty.Value) cty.Value {
+		attrs := map[string]cty.Value{}
+		for name, attrType := range objectType.AttributeTypes() {
+			attrs[name] = cty.NullVal(attrType)
+		}
+		for name, value := range values {
+			attrs[name] = value
+		}
+		return cty.ObjectVal(attrs)
+	}
+	custom := func(values map[string]cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"custom": cty.ListVal([]cty.Value{object(customType, values)}),
+		})
+	}
+
+	typeID, fields, ok, err := plannedCustomFields(custom(map[string]cty.Value{
+		"type_id": cty.StringVal("type-id"),
+		"fields": cty.MapVal(map[string]cty.Value{
+			"known":   cty.StringVal("value"),
+			"unknown": cty.UnknownVal(cty.String),
+		}),
+		"field": cty.SetVal([]cty.Value{
+			object(fieldType, map[string]cty.Value{
+				"name":   cty.StringVal("number"),
+				"number": cty.NumberFloatVal(1.5),
+			}),
+			object(fieldType, map[string]cty.Value{
+				"name": cty.StringVal("money"),
+				"money": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"currency_code": cty.StringVal("EUR"),
+					"cent_amount":   cty.NumberIntVal(100),
+				})}),
+			}),
+			object(fieldType, map[string]cty.Value{
+				"name":   cty.StringVal("pending"),
+				"string": cty.UnknownVal(cty.String),
+			}),
+		}),
+	}))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "type-id", typeID)
+	assert.Equal(t, map[string]any{
+		"known":   "value",
+		"unknown": nil,
+		"number":  CustomFieldValue{Number: ref(1.5)},
+		"money":   CustomFieldValue{Money: &CustomFieldMoney{CurrencyCode: "EUR", CentAmount: 100}},
+		"pending": nil,
+	}, fields)
+
+	_, fields, ok, err = plannedCustomFields(custom(map[string]cty.Value{"type_id": cty.StringVal("type-id")}))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, fields)
+
+	_, _, ok, _ = plannedCustomFields(custom(map[string]cty.Value{"type_id": cty.UnknownVal(cty.String)}))
+	assert.False(t, ok)
+
+	_, _, ok, _ = plannedCustomFields(custom(map[string]cty.Value{
+		"type_id": cty.StringVal("type-id"),
+		"fields":  cty.UnknownVal(cty.Map(cty.String)),
+	}))
+	assert.False(t, ok)
+
+	_, _, ok, _ = plannedCustomFields(cty.ObjectVal(map[string]cty.Value{
+		"custom": cty.ListValEmpty(customType),
+	}))
+	assert.False(t, ok)
+
+	_, _, _, err = plannedCustomFields(custom(map[string]cty.Value{
+		"type_id": cty.StringVal("type-id"),
+		"fields":  cty.MapVal(map[string]cty.Value{"number": cty.StringVal("1")}),
+		"field": cty.SetVal([]cty.Value{
+			object(fieldType, map[string]cty.Value{
+				"name":   cty.StringVal("number"),
+				"number": cty.NumberIntVal(1),
+			}),
+		}),
+	}))
+	assert.EqualError(t, err, "custom field 'number' is set in both fields and a field block")
+}
+
 // List of the resources with custom fields support
 var customFieldResourceTypes = []string{"commercetools_channel", "commercetools_cart_discount", "commercetools_category",
 	"commercetools_customer_group", "commercetools_discount_code", "commercetools_shipping_method", "commercetools_store"}
@@ -251,21 +447,3 @@ func testGetShippingMethod(s *terraform.State, identifier string) (*platform.Shi
 	}
 	return result, nil
 }
-
-func TestGetTypeResourceConcurrent(t *testing.T) {
-	var ids = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
-
-	wg := &sync.WaitGroup{}
-	for pid := 0; pid < 1000; pid++ {
-		wg.Add(1)
-		go func(pid int) {
-			defer wg.Done()
-			_, err := GetTypeResource(context.Background(), func(_ context.Context, id string) (*platform.Type, error) {
-				time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
-				return &platform.Type{ID: id}, nil
-			}, ids[pid%len(ids)])
-			assert.NoError(t, err)
-		}(pid)
-	}
-	wg.Wait()
-}
@@ -0,0 +1,256 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+func checkDataLossSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Count the resources which still use removed fields, attributes or enum values " +
+			"during the plan and report them in `migration_warnings`",
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+}
+
+func failOnDataLossSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Fail the plan when removed fields, attributes or enum values are still used by " +
+			"resources. Implies `check_data_loss`",
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+}
+
+func migrationWarningsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Warnings about the planned changes which result in data loss on existing " +
+			"resources. Only populated in the plan",
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// dataLossQuery describes a query for the resources which lose data when a
+// change is applied.
+type dataLossQuery struct {
+	// Description of the change, e.g. "removing attribute 'color'"
+	description string
+	// The resource type id of the resources to query, e.g. "product"
+	resource string
+	// The query predicate matching the resources which lose data
+	where string
+}
+
+// usageCounter returns the number of resources matching the query predicate
+type usageCounter func(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, where string) (int, error)
+
+// pagedQuery is the Get request builder of the resources of a resource type
+type pagedQuery[Q any, R any] interface {
+	Where(v []string) Q
+	WithTotal(v bool) Q
+	Limit(v int) Q
+	Execute(ctx context.Context) (R, error)
+}
+
+// countUsage returns a usageCounter which counts the resources of the query
+// returned by get
+func countUsage[Q pagedQuery[Q, R], R any](get func(client *platform.ByProjectKeyRequestBuilder) Q) usageCounter {
+	return func(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, where string) (int, error) {
+		r, err := get(client).Where([]string{where}).WithTotal(true).Limit(1).Execute(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return pagedQueryTotal(r), nil
+	}
+}
+
+// pagedQueryTotal returns the total of a paged query response. The responses
+// of the resource types are separate types, which all have a Total field.
+func pagedQueryTotal(response any) int {
+	total := reflect.Indirect(reflect.ValueOf(response)).FieldByName("Total")
+	if !total.IsValid() || total.Kind() != reflect.Pointer || total.IsNil() {
+		return 0
+	}
+	return int(total.Elem().Int())
+}
+
+var usageCounters = map[string]usageCounter{
+	"product": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyProductsRequestMethodGet {
+		return c.Products().Get()
+	}),
+	"category": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyCategoriesRequestMethodGet {
+		return c.Categories().Get()
+	}),
+	"channel": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyChannelsRequestMethodGet {
+		return c.Channels().Get()
+	}),
+	"customer": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyCustomersRequestMethodGet {
+		return c.Customers().Get()
+	}),
+	"customer-group": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyCustomerGroupsRequestMethodGet {
+		return c.CustomerGroups().Get()
+	}),
+	"order": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyOrdersRequestMethodGet {
+		return c.Orders().Get()
+	}),
+	"cart-discount": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyCartDiscountsRequestMethodGet {
+		return c.CartDiscounts().Get()
+	}),
+	"discount-code": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyDiscountCodesRequestMethodGet {
+		return c.DiscountCodes().Get()
+	}),
+	"shipping-method": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyShippingMethodsRequestMethodGet {
+		return c.ShippingMethods().Get()
+	}),
+	"store": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyStoresRequestMethodGet {
+		return c.Stores().Get()
+	}),
+	"inventory-entry": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyInventoryRequestMethodGet {
+		return c.Inventory().Get()
+	}),
+	"payment": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyPaymentsRequestMethodGet {
+		return c.Payments().Get()
+	}),
+	"shopping-list": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyShoppingListsRequestMethodGet {
+		return c.ShoppingLists().Get()
+	}),
+	"review": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyReviewsRequestMethodGet {
+		return c.Reviews().Get()
+	}),
+	"business-unit": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyBusinessUnitsRequestMethodGet {
+		return c.BusinessUnits().Get()
+	}),
+	"associate-role": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyAssociateRolesRequestMethodGet {
+		return c.AssociateRoles().Get()
+	}),
+	"product-selection": countUsage(func(c *platform.ByProjectKeyRequestBuilder) *platform.ByProjectKeyProductSelectionsRequestMethodGet {
+		return c.ProductSelections().Get()
+	}),
+}
+
+// countDataLoss executes the queries and returns a message for every query
+// matching one or more resources. Queries for resource types which can not be
+// counted (like line items or addresses) are reported as well.
+func countDataLoss(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, counters map[string]usageCounter, queries []dataLossQuery) ([]string, error) {
+	var messages []string
+	for _, q := range queries {
+		counter, ok := counters[q.resource]
+		if !ok {
+			messages = append(messages, fmt.Sprintf(
+				"%s might remove data from resources of type %s, which can not be counted", q.description, q.resource))
+			continue
+		}
+
+		count, err := counter(ctx, client, q.where)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count the %s resources affected by %s: %w", q.resource, q.description, err)
+		}
+		if count > 0 {
+			messages = append(messages, fmt.Sprintf(
+				"%s removes data from %d %s resource(s)", q.description, count, q.resource))
+		}
+	}
+	return messages, nil
+}
+
+// checkDataLoss counts the resources affected by the queries when the
+// check_data_loss or fail_on_data_loss setting is enabled. It returns the
+// messages to add to the warnings, or an error when fail_on_data_loss is set
+// and resources are affected.
+func checkDataLoss(ctx context.Context, d *schema.ResourceDiff, m any, queries []dataLossQuery) ([]string, error) {
+	failOnDataLoss := d.Get("fail_on_data_loss").(bool)
+	if len(queries) == 0 || (!d.Get("check_data_loss").(bool) && !failOnDataLoss) {
+		return nil, nil
+	}
+
+	messages, err := countDataLoss(ctx, getClient(m), usageCounters, queries)
+	if err != nil {
+		return nil, err
+	}
+	if failOnDataLoss && len(messages) > 0 {
+		return nil, fmt.Errorf(
+			"the planned changes result in data loss and fail_on_data_loss is set:\n- %s",
+			strings.Join(messages, "\n- "))
+	}
+	return messages, nil
+}
+
+// setMigrationWarnings updates the computed migration_warnings when they are
+// changed, to avoid showing an empty change in the plan. Warnings of an earlier
+// plan are cleared when there are no warnings anymore, since the planned value
+// is stored in the state when it is applied.
+func setMigrationWarnings(d *schema.ResourceDiff, warnings []string) error {
+	current := expandStringArray(d.Get("migration_warnings").([]any))
+	if slices.Equal(current, warnings) || (len(current) == 0 && len(warnings) == 0) {
+		return nil
+	}
+	if warnings == nil {
+		warnings = []string{}
+	}
+	return d.SetNew("migration_warnings", warnings)
+}
+
+// productDataLossQueries returns the queries for the products which lose
+// data when the attributes and enum values are removed from the product type
+func productDataLossQueries(productTypeID string, removedAttributes []string, removedValues map[string][]string) []dataLossQuery {
+	var queries []dataLossQuery
+	for _, name := range removedAttributes {
+		queries = append(queries, dataLossQuery{
+			description: fmt.Sprintf("removing attribute '%s'", name),
+			resource:    "product",
+			where:       productAttributePredicate(productTypeID, fmt.Sprintf("name = %q", name)),
+		})
+	}
+
+	var names []string
+	for name := range removedValues {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		keys := removedValues[name]
+		quoted := make([]string, len(keys))
+		for i := range keys {
+			quoted[i] = fmt.Sprintf("%q", keys[i])
+		}
+		queries = append(queries, dataLossQuery{
+			description: fmt.Sprintf("removing enum value(s) %s of attribute '%s'", strings.Join(quoted, ", "), name),
+			resource:    "product",
+			where: productAttributePredicate(productTypeID,
+				fmt.Sprintf("name = %q and value(key in (%s))", name, strings.Join(quoted, ", "))),
+		})
+	}
+	return queries
+}
+
+func productAttributePredicate(productTypeID, attribute string) string {
+	variants := fmt.Sprintf("masterVariant(attributes(%s)) or variants(attributes(%s))", attribute, attribute)
+	return fmt.Sprintf("productType(id = %q) and masterData(current(%s) or staged(%s))",
+		productTypeID, variants, variants)
+}
+
+// customFieldDataLossQueries returns the queries for the resources which lose
+// data when the fields are removed from the type
+func customFieldDataLossQueries(typeID string, resourceTypeIDs []string, removedFields []string) []dataLossQuery {
+	var queries []dataLossQuery
+	for _, name := range removedFields {
+		for _, resource := range resourceTypeIDs {
+			queries = append(queries, dataLossQuery{
+				description: fmt.Sprintf("removing field '%s'", name),
+				resource:    resource,
+				where:       fmt.Sprintf("custom(type(id = %q) and fields(%s is defined))", typeID, name),
+			})
+		}
+	}
+	return queries
+}
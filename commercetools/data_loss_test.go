@@ -0,0 +1,185 @@
+package commercetools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-commercetools/internal/predicate"
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func TestProductDataLossQueries(t *testing.T) {
+	queries := productDataLossQueries(
+		"product-type-id",
+		[]string{"color"},
+		map[string][]string{"size": {"s", "m"}},
+	)
+
+	variants := `masterVariant(attributes(name = "color")) or variants(attributes(name = "color"))`
+	sizeVariants := `masterVariant(attributes(name = "size" and value(key in ("s", "m")))) or ` +
+		`variants(attributes(name = "size" and value(key in ("s", "m"))))`
+	assert.Equal(t, []dataLossQuery{
+		{
+			description: "removing attribute 'color'",
+			resource:    "product",
+			where:       `productType(id = "product-type-id") and masterData(current(` + variants + `) or staged(` + variants + `))`,
+		},
+		{
+			description: `removing enum value(s) "s", "m" of attribute 'size'`,
+			resource:    "product",
+			where:       `productType(id = "product-type-id") and masterData(current(` + sizeVariants + `) or staged(` + sizeVariants + `))`,
+		},
+	}, queries)
+
+	for _, q := range queries {
+		assert.NoError(t, predicate.Validate(q.where))
+	}
+}
+
+func TestCustomFieldDataLossQueries(t *testing.T) {
+	queries := customFieldDataLossQueries("type-id", []string{"category", "line-item"}, []string{"brand"})
+	assert.Equal(t, []dataLossQuery{
+		{
+			description: "removing field 'brand'",
+			resource:    "category",
+			where:       `custom(type(id = "type-id") and fields(brand is defined))`,
+		},
+		{
+			description: "removing field 'brand'",
+			resource:    "line-item",
+			where:       `custom(type(id = "type-id") and fields(brand is defined))`,
+		},
+	}, queries)
+
+	for _, q := range queries {
+		assert.NoError(t, predicate.Validate(q.where))
+	}
+}
+
+func TestCountDataLoss(t *testing.T) {
+	counters := map[string]usageCounter{
+		"category": func(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, where string) (int, error) {
+			return 12, nil
+		},
+		"channel": func(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, where string) (int, error) {
+			return 0, nil
+		},
+	}
+
+	messages, err := countDataLoss(context.Background(), nil, counters, []dataLossQuery{
+		{description: "removing field 'brand'", resource: "category"},
+		{description: "removing field 'brand'", resource: "channel"},
+		{description: "removing field 'brand'", resource: "line-item"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"removing field 'brand' removes data from 12 category resource(s)",
+		"removing field 'brand' might remove data from resources of type line-item, which can not be counted",
+	}, messages)
+
+	counters["category"] = func(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, where string) (int, error) {
+		return 0, errors.New("rate limited")
+	}
+	_, err = countDataLoss(context.Background(), nil, counters, []dataLossQuery{
+		{description: "removing field 'brand'", resource: "category"},
+	})
+	assert.EqualError(t, err, "failed to count the category resources affected by removing field 'brand': rate limited")
+}
+
+func TestProductTypeAttributeRemovals(t *testing.T) {
+	enumAttribute := func(name string, values ...map[string]any) map[string]any {
+		attr := testProductTypeAttribute(name, "enum")
+		attr["type"] = testEnumType(values...)
+		return attr
+	}
+
+	old := []any{
+		testProductTypeAttribute("removed", "text"),
+		testProductTypeAttribute("renamed", "text"),
+		testProductTypeAttribute("replaced", "text"),
+		enumAttribute("size",
+			map[string]any{"key": "s", "label": "S"},
+			map[string]any{"key": "m", "label": "M"},
+			map[string]any{"key": "l", "label": "L"},
+		),
+	}
+
+	renamed := testProductTypeAttribute("new-name", "text")
+	renamed["previous_name"] = "renamed"
+	replaced := testProductTypeAttribute("replaced", "number")
+	replaced["replace_on_type_change"] = true
+
+	new := []any{
+		renamed,
+		replaced,
+		enumAttribute("size",
+			map[string]any{"key": "small", "label": "S", "previous_key": "s"},
+			map[string]any{"key": "l", "label": "L"},
+		),
+	}
+
+	removedAttributes, removedValues := productTypeAttributeRemovals(old, new)
+	assert.Equal(t, []string{"removed", "replaced"}, removedAttributes)
+	assert.Equal(t, map[string][]string{"size": {"m"}}, removedValues)
+}
+
+func TestUsageCounters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"custom(fields(brand is defined))"}, r.URL.Query()["where"])
+		assert.Equal(t, "true", r.URL.Query().Get("withTotal"))
+		assert.Equal(t, "1", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"limit": 1, "offset": 0, "count": 0, "total": 7, "results": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := platform.NewClient(&platform.ClientConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	for resource, counter := range usageCounters {
+		count, err := counter(context.Background(), client.WithProjectKey("test"), "custom(fields(brand is defined))")
+		assert.NoError(t, err, resource)
+		assert.Equal(t, 7, count, resource)
+	}
+}
+
+func TestPagedQueryTotal(t *testing.T) {
+	total := 3
+	assert.Equal(t, 3, pagedQueryTotal(&platform.ProductPagedQueryResponse{Total: &total}))
+	assert.Equal(t, 0, pagedQueryTotal(&platform.ProductPagedQueryResponse{}))
+	assert.Equal(t, 0, pagedQueryTotal(struct{}{}))
+}
+
+func TestMigrationWarningsCleared(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "type-id",
+		Attributes: map[string]string{
+			"id":                   "type-id",
+			"key":                  "my-type",
+			"name.%":               "1",
+			"name.en":              "My type",
+			"resource_type_ids.#":  "1",
+			"resource_type_ids.0":  "category",
+			"migration_warnings.#": "1",
+			"migration_warnings.0": "removing field 'brand' removes data from 12 category resource(s)",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"key":               "my-type",
+		"name":              map[string]any{"en": "My type"},
+		"resource_type_ids": []any{"category"},
+	})
+
+	diff, err := resourceType().Diff(context.Background(), state, config, &utils.ProviderData{})
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "0", diff.Attributes["migration_warnings.#"].New)
+}
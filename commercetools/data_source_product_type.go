@@ -0,0 +1,137 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+func dataSourceProductType() *schema.Resource {
+	attributeSchema := resourceProductType().Schema["attribute"]
+
+	// These settings only control how the resource applies changes and are
+	// not stored in commercetools
+	attributeElem := dataSourceComputedResource(attributeSchema.Elem.(*schema.Resource))
+	delete(attributeElem.Schema, "previous_name")
+	delete(attributeElem.Schema, "replace_on_type_change")
+	deleteEnumPreviousKeys(attributeElem.Schema["type"].Elem.(*schema.Resource))
+
+	return &schema.Resource{
+		Description: "Fetches a product type, including the full definition of its attributes, by key or name. " +
+			"This allows product types maintained in another workspace to be used to validate products, " +
+			"attribute groups or import jobs.\n\n" +
+			"See also the [Product Type API Documentation](https://docs.commercetools.com/api/projects/productTypes)",
+		ReadContext: dataSourceProductTypeRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Description:  "User-specific unique identifier of the product type",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key", "name"},
+			},
+			"name": {
+				Description:  "Name of the product type. Lookups by name fail when more than one product type matches",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key", "name"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"attribute": {
+				Description: attributeSchema.Description,
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        attributeElem,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// deleteEnumPreviousKeys removes the previous_key hints from the enum values
+// of the attribute type, including the element type of sets
+func deleteEnumPreviousKeys(attributeType *schema.Resource) {
+	for _, name := range []string{"value", "localized_value"} {
+		delete(attributeType.Schema[name].Elem.(*schema.Resource).Schema, "previous_key")
+	}
+	if elementType, ok := attributeType.Schema["element_type"]; ok {
+		deleteEnumPreviousKeys(elementType.Elem.(*schema.Resource))
+	}
+}
+
+// dataSourceComputedResource returns a copy of the given resource schema in
+// which every attribute is computed, so resource schemas can be reused as the
+// (read-only) result of a data source.
+func dataSourceComputedResource(r *schema.Resource) *schema.Resource {
+	result := make(map[string]*schema.Schema, len(r.Schema))
+	for name, s := range r.Schema {
+		computed := &schema.Schema{
+			Type:        s.Type,
+			Description: s.Description,
+			Computed:    true,
+		}
+		switch elem := s.Elem.(type) {
+		case *schema.Resource:
+			computed.Elem = dataSourceComputedResource(elem)
+		case *schema.Schema:
+			computed.Elem = &schema.Schema{Type: elem.Type}
+		}
+		result[name] = computed
+	}
+	return &schema.Resource{Schema: result}
+}
+
+func dataSourceProductTypeRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := getClient(m)
+
+	var productType *platform.ProductType
+	if key, ok := d.GetOk("key"); ok {
+		result, err := client.ProductTypes().WithKey(key.(string)).Get().Execute(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		productType = result
+	} else {
+		name := d.Get("name").(string)
+		result, err := client.ProductTypes().Get().
+			Where([]string{fmt.Sprintf("name=%s", strconv.Quote(name))}).
+			Limit(2).
+			Execute(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		switch len(result.Results) {
+		case 0:
+			return diag.Errorf("no product type found with name %q", name)
+		case 1:
+			productType = &result.Results[0]
+		default:
+			return diag.Errorf("multiple product types found with name %q, use the key instead", name)
+		}
+	}
+
+	attrs, err := flattenProductTypeAttributes(productType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(productType.ID)
+	_ = d.Set("version", productType.Version)
+	_ = d.Set("key", productType.Key)
+	_ = d.Set("name", productType.Name)
+	_ = d.Set("description", productType.Description)
+	_ = d.Set("attribute", attrs)
+	return nil
+}
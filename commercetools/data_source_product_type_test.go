@@ -0,0 +1,92 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceComputedResource(t *testing.T) {
+	attribute := dataSourceProductType().Schema["attribute"]
+	assert.True(t, attribute.Computed)
+	assert.False(t, attribute.Optional)
+
+	attrSchema := attribute.Elem.(*schema.Resource).Schema
+	for name, s := range attrSchema {
+		assert.True(t, s.Computed, name)
+		assert.False(t, s.Required, name)
+		assert.False(t, s.Optional, name)
+		assert.Nil(t, s.Default, name)
+		assert.Zero(t, s.MaxItems, name)
+	}
+
+	typeSchema := attrSchema["type"].Elem.(*schema.Resource).Schema
+	assert.Contains(t, typeSchema, "element_type")
+	assert.Contains(t, typeSchema, "localized_value")
+	assert.Equal(t, TypeLocalizedString, attrSchema["label"].Type)
+
+	elementSchema := typeSchema["element_type"].Elem.(*schema.Resource).Schema
+	assert.NotContains(t, elementSchema, "element_type")
+	assert.True(t, elementSchema["name"].Computed)
+
+	// Settings which only control how the resource applies changes are
+	// removed
+	assert.NotContains(t, attrSchema, "previous_name")
+	assert.NotContains(t, attrSchema, "replace_on_type_change")
+	for _, s := range []map[string]*schema.Schema{typeSchema, elementSchema} {
+		assert.NotContains(t, s["value"].Elem.(*schema.Resource).Schema, "previous_key")
+		assert.NotContains(t, s["localized_value"].Elem.(*schema.Resource).Schema, "previous_key")
+		assert.Contains(t, s["value"].Elem.(*schema.Resource).Schema, "key")
+	}
+}
+
+func TestAccDataSourceProductType(t *testing.T) {
+	key := "acctest-producttype-ds"
+	identifier := "acctest_producttype_ds"
+	byKey := "data.commercetools_product_type.by_key"
+	byName := "data.commercetools_product_type.by_name"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckProductTypesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProductTypeConfig(identifier, key),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						byKey, "id", "commercetools_product_type."+identifier, "id",
+					),
+					resource.TestCheckResourceAttr(byKey, "name", "Shipping info"),
+					resource.TestCheckResourceAttr(byKey, "attribute.#", "3"),
+					resource.TestCheckResourceAttr(byKey, "attribute.0.name", "location"),
+					resource.TestCheckResourceAttr(byKey, "attribute.0.label.nl", "Locatie"),
+					resource.TestCheckResourceAttr(byKey, "attribute.0.type.0.name", "text"),
+					resource.TestCheckResourceAttr(byKey, "attribute.0.input_hint", "SingleLine"),
+					resource.TestCheckResourceAttr(byKey, "attribute.0.searchable", "false"),
+					resource.TestCheckResourceAttr(
+						byKey, "attribute.1.type.0.localized_value.0.key", "snack",
+					),
+					resource.TestCheckResourceAttr(
+						byKey, "attribute.2.type.0.element_type.0.localized_value.1.label.en", "Lunch",
+					),
+					resource.TestCheckResourceAttr(byName, "key", key),
+					resource.TestCheckResourceAttr(byName, "attribute.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceProductTypeConfig(identifier, key string) string {
+	return testAccProductTypeConfig(identifier, key) + hclTemplate(`
+		data "commercetools_product_type" "by_key" {
+			key = commercetools_product_type.{{ .identifier }}.key
+		}
+
+		data "commercetools_product_type" "by_name" {
+			name = commercetools_product_type.{{ .identifier }}.name
+		}`, map[string]any{"identifier": identifier})
+}
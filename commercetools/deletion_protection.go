@@ -0,0 +1,301 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+// detachLimit is the number of referencing resources fetched per request when
+// detaching references with force_destroy
+const detachLimit = 100
+
+func deletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Prevent the resource from being deleted. Changes which replace the resource are " +
+			"rejected during the plan. Destroying the resource is only rejected when the destroy is " +
+			"applied, since Terraform doesn't consult the provider when planning a destroy: " +
+			"`terraform plan` still shows the destroy, and the apply fails before anything is " +
+			"changed. Set this to false and apply before destroying the resource",
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+}
+
+// forceDestroySchema returns the force_destroy schema. The description names
+// the references which are removed, and the references which are not, since
+// commercetools refuses to delete the resource while these exist.
+func forceDestroySchema(references, remaining string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Remove the references to this resource from %s before deleting it. "+
+			"All changed resources are reported as warnings. %s", references, remaining),
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+}
+
+// checkDeletionProtection returns an error diagnostic when deletion
+// protection is enabled for the resource. It is called from Delete, since the
+// SDK doesn't call CustomizeDiff when a destroy is planned.
+func checkDeletionProtection(d *schema.ResourceData, resourceType string) diag.Diagnostics {
+	if protected, _ := d.Get("deletion_protection").(bool); !protected {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Cannot delete %s %s, deletion_protection is enabled", resourceType, d.Id()),
+			Detail: "Set deletion_protection to false and apply the change before " +
+				"destroying or replacing this resource.",
+		},
+	}
+}
+
+// withDeletionProtection adds a CustomizeDiff to the resource which rejects
+// changes to ForceNew attributes while deletion_protection is enabled, so a
+// replacement fails during the plan instead of halfway through the apply.
+func withDeletionProtection(resourceType string, r *schema.Resource) *schema.Resource {
+	var forceNew []string
+	for key, attribute := range r.Schema {
+		if requiresReplacement(attribute) {
+			forceNew = append(forceNew, key)
+		}
+	}
+	slices.Sort(forceNew)
+
+	validate := func(_ context.Context, d *schema.ResourceDiff, _ any) error {
+		if d.Id() == "" {
+			return nil
+		}
+		// The current state decides, as in Delete: disabling the protection
+		// in the same change doesn't allow the replacement
+		if protected, _ := d.GetChange("deletion_protection"); protected != true {
+			return nil
+		}
+		for _, key := range forceNew {
+			if d.HasChange(key) {
+				return fmt.Errorf("cannot replace %s %s, deletion_protection is enabled and %s "+
+					"can't be changed without replacing it. Set deletion_protection to false and "+
+					"apply the change first", resourceType, d.Id(), key)
+			}
+		}
+		return nil
+	}
+
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = validate
+	} else {
+		r.CustomizeDiff = customdiff.All(r.CustomizeDiff, validate)
+	}
+	return r
+}
+
+// requiresReplacement returns whether changing the attribute, or any of its
+// nested attributes, replaces the resource
+func requiresReplacement(attribute *schema.Schema) bool {
+	if attribute.ForceNew {
+		return true
+	}
+	if elem, ok := attribute.Elem.(*schema.Resource); ok {
+		for _, nested := range elem.Schema {
+			if requiresReplacement(nested) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// detachedDiagnostic returns a warning describing a resource from which a
+// reference was removed by force_destroy
+func detachedDiagnostic(resourceType, reference, id string, key *string) diag.Diagnostic {
+	identifier := id
+	if key != nil && *key != "" {
+		identifier = fmt.Sprintf("%s (%s)", *key, id)
+	}
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Removed %s from %s %s", reference, resourceType, identifier),
+		Detail:   "The reference was removed because force_destroy is enabled.",
+	}
+}
+
+// detachChannelFromStores removes the channel from the distribution and supply
+// channels of all stores using it
+func detachChannelFromStores(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, channelID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	where := fmt.Sprintf("distributionChannels(id = %q) or supplyChannels(id = %q)", channelID, channelID)
+	for {
+		result, err := client.Stores().Get().Where([]string{where}).Limit(detachLimit).Execute(ctx)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		updated := 0
+		for _, store := range result.Results {
+			actions := storeChannelRemoveActions(store, channelID)
+			if len(actions) == 0 {
+				continue
+			}
+			_, err := client.Stores().WithId(store.ID).Post(platform.StoreUpdate{
+				Version: store.Version,
+				Actions: actions,
+			}).Execute(ctx)
+			if err != nil {
+				return append(diags, diag.Errorf("failed to remove channel %s from store %s: %s", channelID, store.Key, err)...)
+			}
+			diags = append(diags, detachedDiagnostic("store", "channel "+channelID, store.ID, &store.Key))
+			updated++
+		}
+
+		// The updated stores no longer match the query, so the next page
+		// starts at offset 0 again. Stop when nothing was updated, since the
+		// same stores would be returned again.
+		if result.Count < detachLimit || updated == 0 {
+			return diags
+		}
+	}
+}
+
+func storeChannelRemoveActions(store platform.Store, channelID string) []platform.StoreUpdateAction {
+	var actions []platform.StoreUpdateAction
+	for _, channel := range store.DistributionChannels {
+		if channel.ID == channelID {
+			actions = append(actions, platform.StoreRemoveDistributionChannelAction{
+				DistributionChannel: platform.ChannelResourceIdentifier{ID: &channelID},
+			})
+		}
+	}
+	for _, channel := range store.SupplyChannels {
+		if channel.ID == channelID {
+			actions = append(actions, platform.StoreRemoveSupplyChannelAction{
+				SupplyChannel: platform.ChannelResourceIdentifier{ID: &channelID},
+			})
+		}
+	}
+	return actions
+}
+
+// detachZoneFromShippingMethods removes the zone, including its shipping
+// rates, from all shipping methods using it
+func detachZoneFromShippingMethods(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, zoneID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	where := fmt.Sprintf("zoneRates(zone(id = %q))", zoneID)
+	for {
+		result, err := client.ShippingMethods().Get().Where([]string{where}).Limit(detachLimit).Execute(ctx)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		updated := 0
+		for _, method := range result.Results {
+			// Lock to prevent concurrent updates with the shipping zone rate resources
+			ctMutexKV.Lock(method.ID)
+			_, err := client.ShippingMethods().WithId(method.ID).Post(platform.ShippingMethodUpdate{
+				Version: method.Version,
+				Actions: []platform.ShippingMethodUpdateAction{
+					platform.ShippingMethodRemoveZoneAction{
+						Zone: platform.ZoneResourceIdentifier{ID: &zoneID},
+					},
+				},
+			}).Execute(ctx)
+			ctMutexKV.Unlock(method.ID)
+			if err != nil {
+				return append(diags, diag.Errorf("failed to remove zone %s from shipping method %s: %s", zoneID, method.ID, err)...)
+			}
+			diags = append(diags, detachedDiagnostic("shipping method", "zone "+zoneID, method.ID, method.Key))
+			updated++
+		}
+
+		if result.Count < detachLimit || updated == 0 {
+			return diags
+		}
+	}
+}
+
+// detachCustomerGroupFromCustomers removes the customer group from all
+// customers in the group
+func detachCustomerGroupFromCustomers(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, customerGroupID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	where := fmt.Sprintf("customerGroup(id = %q)", customerGroupID)
+	for {
+		result, err := client.Customers().Get().Where([]string{where}).Limit(detachLimit).Execute(ctx)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		updated := 0
+		for _, customer := range result.Results {
+			_, err := client.Customers().WithId(customer.ID).Post(platform.CustomerUpdate{
+				Version: customer.Version,
+				Actions: []platform.CustomerUpdateAction{
+					platform.CustomerSetCustomerGroupAction{CustomerGroup: nil},
+				},
+			}).Execute(ctx)
+			if err != nil {
+				return append(diags, diag.Errorf("failed to remove customer group %s from customer %s: %s", customerGroupID, customer.ID, err)...)
+			}
+			diags = append(diags, detachedDiagnostic("customer", "customer group "+customerGroupID, customer.ID, customer.Key))
+			updated++
+		}
+
+		if result.Count < detachLimit || updated == 0 {
+			return diags
+		}
+	}
+}
+
+// detachTaxCategory removes the tax category from all products using it.
+// Shipping methods require a tax category, so these are reported as errors
+// since the tax category can't be deleted while they exist.
+func detachTaxCategory(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, taxCategoryID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	where := fmt.Sprintf("taxCategory(id = %q)", taxCategoryID)
+
+	methods, err := client.ShippingMethods().Get().Where([]string{where}).Limit(detachLimit).Execute(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, method := range methods.Results {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Tax category %s is used by shipping method %s", taxCategoryID, method.Name),
+			Detail: "Shipping methods require a tax category, so the reference can not be removed. " +
+				"Assign another tax category to the shipping method first.",
+		})
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	for {
+		result, err := client.Products().Get().Where([]string{where}).Limit(detachLimit).Execute(ctx)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		updated := 0
+		for _, product := range result.Results {
+			_, err := client.Products().WithId(product.ID).Post(platform.ProductUpdate{
+				Version: product.Version,
+				Actions: []platform.ProductUpdateAction{
+					platform.ProductSetTaxCategoryAction{TaxCategory: nil},
+				},
+			}).Execute(ctx)
+			if err != nil {
+				return append(diags, diag.Errorf("failed to remove tax category %s from product %s: %s", taxCategoryID, product.ID, err)...)
+			}
+			diags = append(diags, detachedDiagnostic("product", "tax category "+taxCategoryID, product.ID, product.Key))
+			updated++
+		}
+
+		if result.Count < detachLimit || updated == 0 {
+			return diags
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package commercetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	for _, resource := range []*schema.Resource{
+		resourceTaxCategory(),
+		resourceShippingZone(),
+		resourceChannel(),
+		resourceCustomerGroup(),
+		resourceProductType(),
+	} {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]any{})
+		d.SetId("some-id")
+		assert.False(t, checkDeletionProtection(d, "resource").HasError())
+
+		assert.NoError(t, d.Set("deletion_protection", true))
+		diags := checkDeletionProtection(d, "channel")
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Cannot delete channel some-id, deletion_protection is enabled", diags[0].Summary)
+	}
+}
+
+func TestWithDeletionProtection(t *testing.T) {
+	resource := withDeletionProtection("thing", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"deletion_protection": deletionProtectionSchema(),
+		},
+	})
+	state := func(protected string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "some-id",
+			Attributes: map[string]string{
+				"id":                  "some-id",
+				"key":                 "my-key",
+				"name":                "my-name",
+				"deletion_protection": protected,
+			},
+		}
+	}
+	diff := func(s *terraform.InstanceState, config map[string]any) error {
+		_, err := resource.Diff(context.Background(), s, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+
+	err := diff(state("true"), map[string]any{"key": "other-key", "name": "my-name", "deletion_protection": true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot replace thing some-id, deletion_protection is enabled and key")
+
+	// Disabling the protection in the same change doesn't allow the replacement
+	assert.Error(t, diff(state("true"), map[string]any{"key": "other-key", "name": "my-name", "deletion_protection": false}))
+
+	assert.NoError(t, diff(state("true"), map[string]any{"key": "my-key", "name": "other-name", "deletion_protection": true}))
+	assert.NoError(t, diff(state("false"), map[string]any{"key": "other-key", "name": "my-name", "deletion_protection": true}))
+	assert.NoError(t, diff(nil, map[string]any{"key": "my-key", "deletion_protection": true}))
+
+	for _, r := range []*schema.Resource{
+		resourceTaxCategory(),
+		resourceShippingZone(),
+		resourceChannel(),
+		resourceCustomerGroup(),
+		resourceProductType(),
+	} {
+		assert.NotNil(t, r.CustomizeDiff)
+	}
+}
+
+func TestStoreChannelRemoveActions(t *testing.T) {
+	channelID := "channel-1"
+	store := platform.Store{
+		DistributionChannels: []platform.ChannelReference{{ID: "channel-1"}, {ID: "channel-2"}},
+		SupplyChannels:       []platform.ChannelReference{{ID: "channel-1"}},
+	}
+
+	assert.Equal(t, []platform.StoreUpdateAction{
+		platform.StoreRemoveDistributionChannelAction{
+			DistributionChannel: platform.ChannelResourceIdentifier{ID: &channelID},
+		},
+		platform.StoreRemoveSupplyChannelAction{
+			SupplyChannel: platform.ChannelResourceIdentifier{ID: &channelID},
+		},
+	}, storeChannelRemoveActions(store, channelID))
+
+	assert.Empty(t, storeChannelRemoveActions(store, "channel-3"))
+}
+
+func TestDetachedDiagnostic(t *testing.T) {
+	d := detachedDiagnostic("store", "channel channel-1", "store-id", stringRef("my-store"))
+	assert.Equal(t, diag.Warning, d.Severity)
+	assert.Equal(t, "Removed channel channel-1 from store my-store (store-id)", d.Summary)
+
+	d = detachedDiagnostic("customer", "customer group group-1", "customer-id", nil)
+	assert.Equal(t, "Removed customer group group-1 from customer customer-id", d.Summary)
+}
+
+func TestDetachChannelFromStoresWithoutProgress(t *testing.T) {
+	// The query keeps returning a full page of stores which don't reference
+	// the channel, detaching must stop instead of querying forever
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, http.MethodGet, r.Method)
+
+		stores := make([]platform.Store, detachLimit)
+		for i := range stores {
+			stores[i] = platform.Store{ID: fmt.Sprintf("store-%d", i), Key: fmt.Sprintf("store-%d", i)}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(platform.StorePagedQueryResponse{
+			Limit:   detachLimit,
+			Count:   detachLimit,
+			Results: stores,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := platform.NewClient(&platform.ClientConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	diags := detachChannelFromStores(context.Background(), client.WithProjectKey("test"), "channel-1")
+	assert.Empty(t, diags)
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,184 @@
+package commercetools
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func enumPreviousKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The key of the value before it was renamed. Product types rename the key " +
+			"using the changeEnumKey update action, keeping the value on existing products. " +
+			"Types don't support changing or removing enum keys, so a new value is added and " +
+			"the value with the previous key is kept in commercetools. Keep this set as long " +
+			"as the value with the previous key exists",
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+}
+
+// enumKeyHints returns the previous_key hints of the enum values of the given
+// raw (set) type as a map from the key to the previous key.
+func enumKeyHints(rawType []any) map[string]string {
+	typeData := firstElementFromSlice(rawType)
+	if typeData == nil {
+		return nil
+	}
+	if element := elementFromSlice(typeData, "element_type"); element != nil {
+		typeData = element
+	}
+
+	hints := map[string]string{}
+	for _, field := range []string{"value", "localized_value"} {
+		values, _ := typeData[field].([]any)
+		for _, raw := range values {
+			value := raw.(map[string]any)
+			if previousKey, _ := value["previous_key"].(string); previousKey != "" {
+				hints[value["key"].(string)] = previousKey
+			}
+		}
+	}
+	return hints
+}
+
+// enumKeys returns the keys of the enum values of the given raw (set) type
+func enumKeys(rawType []any) []string {
+	typeData := firstElementFromSlice(rawType)
+	if typeData == nil {
+		return nil
+	}
+	if element := elementFromSlice(typeData, "element_type"); element != nil {
+		typeData = element
+	}
+
+	var keys []string
+	for _, field := range []string{"value", "localized_value"} {
+		values, _ := typeData[field].([]any)
+		for _, raw := range values {
+			keys = append(keys, raw.(map[string]any)["key"].(string))
+		}
+	}
+	return keys
+}
+
+// validateEnumKeyHints validates that the previous_key hints of the enum
+// values of the given raw (set) type are unambiguous.
+func validateEnumKeyHints(name string, rawType []any) error {
+	hints := enumKeyHints(rawType)
+	if len(hints) == 0 {
+		return nil
+	}
+
+	keys := enumKeys(rawType)
+	seen := map[string]string{}
+	for _, key := range keys {
+		previousKey, ok := hints[key]
+		if !ok {
+			continue
+		}
+		if previousKey == key {
+			return fmt.Errorf("enum value '%s' of '%s' uses its own key as previous_key", key, name)
+		}
+		if slices.Contains(keys, previousKey) {
+			return fmt.Errorf(
+				"previous_key '%s' of enum value '%s' of '%s' is still used as key of another value",
+				previousKey, key, name)
+		}
+		if other, ok := seen[previousKey]; ok {
+			return fmt.Errorf(
+				"previous_key '%s' of '%s' is used by both enum value '%s' and '%s'",
+				previousKey, name, other, key)
+		}
+		seen[previousKey] = key
+	}
+	return nil
+}
+
+// enumKeyRenames returns the enum values which need to be renamed, as a map
+// from the previous key to the new key. A value is only renamed when the
+// previous key exists and is no longer used and the new key doesn't exist
+// yet.
+func enumKeyRenames(oldKeys, newKeys []string, hints map[string]string) map[string]string {
+	renames := map[string]string{}
+	for _, key := range newKeys {
+		previousKey, ok := hints[key]
+		if !ok || slices.Contains(newKeys, previousKey) || slices.Contains(oldKeys, key) {
+			continue
+		}
+		if slices.Contains(oldKeys, previousKey) {
+			renames[previousKey] = key
+		}
+	}
+	return renames
+}
+
+// retiredEnumKeys returns the previous keys of enum values of a type which
+// are renamed, either in this update or in an earlier one. commercetools
+// doesn't support changing or removing the keys of enum values of types, so
+// the values with these keys are kept in commercetools but are no longer
+// managed by terraform.
+func retiredEnumKeys(oldKeys, newKeys []string, oldHints, newHints map[string]string) []string {
+	var retired []string
+	for _, key := range newKeys {
+		previousKey, ok := newHints[key]
+		if !ok || slices.Contains(newKeys, previousKey) {
+			continue
+		}
+
+		renamed := slices.Contains(oldKeys, previousKey) && !slices.Contains(oldKeys, key)
+		appliedBefore := slices.Contains(oldKeys, key) && !slices.Contains(oldKeys, previousKey) &&
+			oldHints[key] == previousKey
+		if renamed || appliedBefore {
+			retired = append(retired, previousKey)
+		}
+	}
+	return retired
+}
+
+// mergeEnumKeyHints copies the previous_key hints of the enum values in the
+// current raw type to the flattened type, since they are not stored in
+// commercetools. When removeRetired is set the values which are replaced by
+// a renamed value are removed from the flattened type.
+func mergeEnumKeyHints(flattened, current []any, removeRetired bool) {
+	hints := enumKeyHints(current)
+	if len(hints) == 0 {
+		return
+	}
+
+	typeData := firstElementFromSlice(flattened)
+	if typeData == nil {
+		return
+	}
+	if element := elementFromSlice(typeData, "element_type"); element != nil {
+		typeData = element
+	}
+
+	for _, field := range []string{"value", "localized_value"} {
+		values, ok := typeData[field].([]any)
+		if !ok {
+			continue
+		}
+
+		retired := map[string]bool{}
+		for _, raw := range values {
+			value := raw.(map[string]any)
+			if previousKey, ok := hints[value["key"].(string)]; ok {
+				value["previous_key"] = previousKey
+				retired[previousKey] = true
+			}
+		}
+		if !removeRetired {
+			continue
+		}
+
+		result := make([]any, 0, len(values))
+		for _, raw := range values {
+			if !retired[raw.(map[string]any)["key"].(string)] {
+				result = append(result, raw)
+			}
+		}
+		typeData[field] = result
+	}
+}
@@ -0,0 +1,105 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEnumType(values ...map[string]any) []any {
+	raw := make([]any, len(values))
+	for i := range values {
+		raw[i] = values[i]
+	}
+	return []any{
+		map[string]any{
+			"name":  "enum",
+			"value": raw,
+		},
+	}
+}
+
+func TestValidateEnumKeyHints(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rawType  []any
+		expected string
+	}{
+		{
+			name: "valid",
+			rawType: testEnumType(
+				map[string]any{"key": "a", "previous_key": "old-a"},
+				map[string]any{"key": "b", "previous_key": "old-b"},
+			),
+		},
+		{
+			name: "own key",
+			rawType: testEnumType(
+				map[string]any{"key": "a", "previous_key": "a"},
+			),
+			expected: "enum value 'a' of 'attr' uses its own key as previous_key",
+		},
+		{
+			name: "key still in use",
+			rawType: testEnumType(
+				map[string]any{"key": "a"},
+				map[string]any{"key": "b", "previous_key": "a"},
+			),
+			expected: "previous_key 'a' of enum value 'b' of 'attr' is still used as key of another value",
+		},
+		{
+			name: "duplicate previous key",
+			rawType: testEnumType(
+				map[string]any{"key": "a", "previous_key": "old"},
+				map[string]any{"key": "b", "previous_key": "old"},
+			),
+			expected: "previous_key 'old' of 'attr' is used by both enum value 'a' and 'b'",
+		},
+		{
+			name: "set of enums",
+			rawType: []any{
+				map[string]any{
+					"name":         "set",
+					"element_type": testEnumType(map[string]any{"key": "a", "previous_key": "a"}),
+				},
+			},
+			expected: "enum value 'a' of 'attr' uses its own key as previous_key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEnumKeyHints("attr", tc.rawType)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMergeEnumKeyHints(t *testing.T) {
+	current := testEnumType(
+		map[string]any{"key": "a", "label": "A"},
+		map[string]any{"key": "renamed", "label": "B", "previous_key": "b"},
+	)
+
+	flattened := testEnumType(
+		map[string]any{"key": "a", "label": "A"},
+		map[string]any{"key": "b", "label": "B"},
+		map[string]any{"key": "renamed", "label": "B"},
+	)
+	mergeEnumKeyHints(flattened, current, false)
+	assert.Equal(t, testEnumType(
+		map[string]any{"key": "a", "label": "A"},
+		map[string]any{"key": "b", "label": "B"},
+		map[string]any{"key": "renamed", "label": "B", "previous_key": "b"},
+	), flattened)
+
+	mergeEnumKeyHints(flattened, current, true)
+	assert.Equal(t, testEnumType(
+		map[string]any{"key": "a", "label": "A"},
+		map[string]any{"key": "renamed", "label": "B", "previous_key": "b"},
+	), flattened)
+}
@@ -0,0 +1,73 @@
+package commercetools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func getLanguages(m any) *utils.LanguageValidator {
+	data := m.(*utils.ProviderData)
+	return data.Languages
+}
+
+// validateLocalizedStrings returns a CustomizeDiffFunc which validates the
+// localized strings at the given paths with the validate_languages and
+// required_languages settings of the provider. Elements of lists are matched
+// with a *, e.g. attribute.*.label
+func validateLocalizedStrings(paths ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		languages := getLanguages(m)
+		if !languages.Enabled() {
+			return nil
+		}
+
+		var errs []error
+		for _, p := range paths {
+			for _, key := range expandListPath(d, p) {
+				if !d.NewValueKnown(key) {
+					continue
+				}
+				value, _ := d.Get(key).(map[string]any)
+				keys := make([]string, 0, len(value))
+				for language := range value {
+					keys = append(keys, language)
+				}
+
+				result, err := languages.Validate(ctx, keys)
+				if err != nil {
+					return err
+				}
+				for _, e := range result {
+					if e.Key != "" {
+						errs = append(errs, fmt.Errorf("%s.%s: %w", key, e.Key, e.Err))
+						continue
+					}
+					errs = append(errs, fmt.Errorf("%s: %w", key, e.Err))
+				}
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// expandListPath returns the keys of all list elements matching the
+// path, e.g. attribute.0.label and attribute.1.label for attribute.*.label
+func expandListPath(d interface{ Get(string) any }, path string) []string {
+	prefix, rest, found := strings.Cut(path, ".*.")
+	if !found {
+		return []string{path}
+	}
+
+	items, _ := d.Get(prefix).([]any)
+	var result []string
+	for i := range items {
+		result = append(result, expandListPath(d, fmt.Sprintf("%s.%d.%s", prefix, i, rest))...)
+	}
+	return result
+}
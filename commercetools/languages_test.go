@@ -0,0 +1,57 @@
+package commercetools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func TestValidateLocalizedStrings(t *testing.T) {
+	meta := &utils.ProviderData{
+		Languages: utils.NewLanguageValidator(nil, &utils.ProjectSettings{}, false, []string{"en"}),
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"key":  "test",
+		"name": map[string]any{"en": "Name", "de": "Name"},
+		"field": []any{
+			map[string]any{
+				"name":  "field",
+				"label": map[string]any{"de": "Feld"},
+				"type":  []any{map[string]any{"name": "String"}},
+			},
+		},
+	})
+	_, err := resourceType().Diff(context.Background(), nil, config, meta)
+	assert.EqualError(t, err, "field.0.label: missing a value for the required languages en")
+
+	meta.Languages = utils.NewLanguageValidator(nil, &utils.ProjectSettings{}, false, nil)
+	_, err = resourceType().Diff(context.Background(), nil, config, meta)
+	assert.NoError(t, err)
+}
+
+func TestExpandLocalizedStringPath(t *testing.T) {
+	values := testGetter{
+		"attribute":             []any{map[string]any{}, map[string]any{}},
+		"attribute.0.type":      []any{map[string]any{}},
+		"attribute.1.type":      []any{},
+		"attribute.0.type.0.lv": []any{map[string]any{}, map[string]any{}},
+	}
+
+	assert.Equal(t, []string{"name"}, expandListPath(values, "name"))
+	assert.Equal(t, []string{"attribute.0.label", "attribute.1.label"}, expandListPath(values, "attribute.*.label"))
+	assert.Equal(t,
+		[]string{"attribute.0.type.0.lv.0.label", "attribute.0.type.0.lv.1.label"},
+		expandListPath(values, "attribute.*.type.*.lv.*.label"),
+	)
+}
+
+type testGetter map[string]any
+
+func (g testGetter) Get(key string) any {
+	return g[key]
+}
@@ -0,0 +1,101 @@
+package commercetools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func getProjectValidator(m any) *utils.ProjectValidator {
+	data := m.(*utils.ProviderData)
+	return data.ProjectValidator
+}
+
+// validateCurrencies returns a CustomizeDiffFunc which validates the currency
+// codes at the given paths against the currencies of the project. Elements of
+// lists are matched with a *, e.g. price.*.currency_code
+func validateCurrencies(paths ...string) schema.CustomizeDiffFunc {
+	return validateProjectSettings(paths, (*utils.ProjectValidator).ValidateCurrency)
+}
+
+// validateCountries returns a CustomizeDiffFunc which validates the country
+// codes at the given paths against the countries of the project
+func validateCountries(paths ...string) schema.CustomizeDiffFunc {
+	return validateProjectSettings(paths, (*utils.ProjectValidator).ValidateCountry)
+}
+
+// validateShippingRateTiers returns a CustomizeDiffFunc which validates the
+// type of the shipping rate price tiers at the given paths against the shipping
+// rate input type of the project, e.g. shipping_rate_price_tier.*.type. The
+// value of CartClassification tiers is validated against the classification
+// values of the project.
+func validateShippingRateTiers(paths ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		validator := getProjectValidator(m)
+		if !validator.Enabled() {
+			return nil
+		}
+
+		var errs []error
+		for _, p := range paths {
+			for _, key := range expandListPath(d, p) {
+				valueKey := strings.TrimSuffix(key, "type") + "value"
+				if !d.NewValueKnown(key) || !d.NewValueKnown(valueKey) {
+					continue
+				}
+
+				tierType := d.Get(key).(string)
+				if tierType == "" {
+					continue
+				}
+				if err := validator.ValidateShippingRateTier(ctx, tierType, d.Get(valueKey).(string)); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				}
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func validateProjectSettings(paths []string, validate func(*utils.ProjectValidator, context.Context, string) error) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		validator := getProjectValidator(m)
+		if !validator.Enabled() {
+			return nil
+		}
+
+		var errs []error
+		for _, p := range paths {
+			for _, key := range expandListPath(d, p) {
+				if !d.NewValueKnown(key) {
+					continue
+				}
+
+				var values []string
+				switch v := d.Get(key).(type) {
+				case string:
+					values = append(values, v)
+				case *schema.Set:
+					for _, item := range v.List() {
+						values = append(values, item.(string))
+					}
+				}
+
+				for _, value := range values {
+					if value == "" {
+						continue
+					}
+					if err := validate(validator, ctx, value); err != nil {
+						errs = append(errs, fmt.Errorf("%s: %w", key, err))
+					}
+				}
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
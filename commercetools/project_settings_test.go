@@ -0,0 +1,95 @@
+package commercetools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func TestValidateProjectSettings(t *testing.T) {
+	settings := &utils.ProjectSettings{}
+	settings.Set(&platform.Project{
+		Currencies:            []string{"EUR"},
+		Countries:             []string{"DE", "NL"},
+		ShippingRateInputType: platform.CartScoreType{},
+	})
+	meta := &utils.ProviderData{
+		ProjectValidator: utils.NewProjectValidator(nil, settings, true),
+	}
+
+	t.Run("currencies", func(t *testing.T) {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"shipping_method_id": "method",
+			"shipping_zone_id":   "zone",
+			"price":              []any{map[string]any{"currency_code": "EUR", "cent_amount": 100}},
+			"shipping_rate_price_tier": []any{
+				map[string]any{
+					"type":  "CartScore",
+					"score": 1,
+					"price": []any{map[string]any{"currency_code": "USD", "cent_amount": 100}},
+				},
+			},
+		})
+		_, err := resourceShippingZoneRate().Diff(context.Background(), nil, config, meta)
+		assert.EqualError(t, err, "shipping_rate_price_tier.0.price.0.currency_code: currency USD is not "+
+			"enabled in the project, the currencies of the project are EUR")
+	})
+
+	t.Run("shipping rate tiers", func(t *testing.T) {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name": "method",
+			"zone_rate": []any{
+				map[string]any{
+					"shipping_zone_id": "zone",
+					"shipping_rate": []any{
+						map[string]any{
+							"price": []any{map[string]any{"currency_code": "EUR", "cent_amount": 100}},
+							"shipping_rate_price_tier": []any{
+								map[string]any{
+									"type":  "CartScore",
+									"score": 1,
+									"price": []any{map[string]any{"currency_code": "EUR", "cent_amount": 100}},
+								},
+								map[string]any{
+									"type":  "CartClassification",
+									"value": "Light",
+									"price": []any{map[string]any{"currency_code": "EUR", "cent_amount": 100}},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+		_, err := resourceShippingMethod().Diff(context.Background(), nil, config, meta)
+		assert.EqualError(t, err, "zone_rate.0.shipping_rate.0.shipping_rate_price_tier.1.type: tier type "+
+			"CartClassification does not match the shipping rate input type of the project, which is CartScore")
+	})
+
+	t.Run("countries", func(t *testing.T) {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"key":       "store",
+			"countries": []any{"DE", "BE"},
+		})
+		_, err := resourceStore().Diff(context.Background(), nil, config, meta)
+		assert.EqualError(t, err, "countries: country BE is not enabled in the project, the countries of "+
+			"the project are DE, NL")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"key":       "store",
+			"countries": []any{"BE"},
+		})
+		disabled := &utils.ProviderData{
+			ProjectValidator: utils.NewProjectValidator(nil, settings, false),
+		}
+		_, err := resourceStore().Diff(context.Background(), nil, config, disabled)
+		assert.NoError(t, err)
+	})
+}
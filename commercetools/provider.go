@@ -61,13 +61,31 @@ func New(version string) func() *schema.Provider {
 					Optional:    true,
 					Description: "The authentication URL of the commercetools platform. https://docs.commercetools.com/api/authorization",
 				},
+				"validate_languages": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Validate the languages of localized strings, like names, descriptions, slugs and enum labels, against the languages of the project during the plan. The project settings are fetched once per run, which requires the `view_project_settings` scope. Languages added to the project in the same apply aren't known during the plan",
+				},
+				"required_languages": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Languages every localized string needs a value for, e.g. `[\"en\", \"de\"]`. Missing languages are reported during the plan. Localized strings which aren't set are not validated",
+				},
+				"validate_project_settings": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Validate currencies, countries and shipping rate tiers, like the currencies of shipping rates and the countries of stores, against the settings of the project during the plan. Defaults to `false`. The project settings are fetched once per run, which requires the `view_project_settings` scope. Leave this disabled when the currencies, countries or shipping rate input type of the project are changed in the same apply, e.g. when bootstrapping a project with `commercetools_project_settings`. The plan fails when the client isn't allowed to view the project settings",
+				},
+			},
+			DataSourcesMap: map[string]*schema.Resource{
+				"commercetools_product_type": dataSourceProductType(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
 				"commercetools_api_client":         resourceAPIClient(),
 				"commercetools_api_extension":      resourceAPIExtension(),
 				"commercetools_cart_discount":      resourceCartDiscount(),
 				"commercetools_channel":            resourceChannel(),
-				"commercetools_custom_object":      resourceCustomObject(),
 				"commercetools_customer_group":     resourceCustomerGroup(),
 				"commercetools_discount_code":      resourceDiscountCode(),
 				"commercetools_product_type":       resourceProductType(),
@@ -136,7 +154,34 @@ func providerConfigure(version string) func(context.Context, *schema.ResourceDat
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
-		return client.WithProjectKey(projectKey), nil
+
+		var requiredLanguages []string
+		for _, language := range d.Get("required_languages").([]any) {
+			requiredLanguages = append(requiredLanguages, language.(string))
+		}
+
+		projectClient := client.WithProjectKey(projectKey)
+		settings := utils.ProjectSettingsFor(apiURL, projectKey)
+		return &utils.ProviderData{
+			Client:           projectClient,
+			Mutex:            ctMutexKV,
+			ProjectKey:       projectKey,
+			Credentials:      oauth2Config,
+			TypeCache:        utils.TypeCacheFor(apiURL, projectKey),
+			APIClientSecrets: utils.APIClientSecretsFor(apiURL, projectKey),
+			ProjectSettings:  settings,
+			Languages: utils.NewLanguageValidator(
+				projectClient,
+				settings,
+				d.Get("validate_languages").(bool),
+				requiredLanguages,
+			),
+			ProjectValidator: utils.NewProjectValidator(
+				projectClient,
+				settings,
+				d.Get("validate_project_settings").(bool),
+			),
+		}, nil
 	}
 }
 
@@ -2,6 +2,7 @@ package commercetools
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/labd/commercetools-go-sdk/platform"
 
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
@@ -19,10 +21,21 @@ func resourceAPIClient() *schema.Resource {
 		Description: "Create a new API client. Note that Commercetools might return slightly different scopes, " +
 			"resulting in a new API client being created everytime Terraform is run. In this case, " +
 			"fix your scopes accordingly to match what is returned by Commercetools.\n\n" +
+			"The secret of an API client is only returned by commercetools when the client is created. " +
+			"Set `store_secret` to `false` to keep it out of the Terraform state, in which case it is only " +
+			"delivered by the `commercetools_api_client_secret` ephemeral resource, during the apply which " +
+			"creates or rotates the client.\n\n" +
+			"Secrets can be rotated without downtime by changing the `trigger` of the `rotation` block. Adding " +
+			"the `rotation` block to an existing client doesn't rotate it. A new " +
+			"client is created first and the previous client is deleted once the configured `overlap` has " +
+			"passed. While the previous client exists every plan shows `previous_id` as changing, and the " +
+			"first apply after the overlap has passed deletes it.\n\n" +
 			"Also see the [API client HTTP API documentation](https://docs.commercetools.com/api/projects/api-clients).",
 		CreateContext: resourceAPIClientCreate,
 		ReadContext:   resourceAPIClientRead,
+		UpdateContext: resourceAPIClientUpdate,
 		DeleteContext: resourceAPIClientDelete,
+		CustomizeDiff: resourceAPIClientCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -52,16 +65,135 @@ func resourceAPIClient() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"delete_days_after_creation": {
+				Description:  "If set, the client will be deleted by commercetools after the specified amount of days.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"store_secret": {
+				Description: "Whether the secret is stored in the Terraform state. When disabled the secret is " +
+					"only available from the `commercetools_api_client_secret` ephemeral resource, during the " +
+					"apply which creates or rotates the client",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"rotation": {
+				Description: "Rotate the API client by creating a new client before the current one is deleted",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"trigger": {
+							Description: "Arbitrary value, for example a date, which rotates the client when changed",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"overlap": {
+							Description: "Duration, for example `24h`, during which the previous client remains " +
+								"valid after a rotation",
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "0s",
+							ValidateFunc: func(val any, key string) (warns []string, errs []error) {
+								if _, err := time.ParseDuration(val.(string)); err != nil {
+									errs = append(errs, fmt.Errorf("%q is not a valid duration: %w", key, err))
+								}
+								return
+							},
+						},
+					},
+				},
+			},
 			"secret": {
 				Type:      schema.TypeString,
 				Computed:  true,
 				Sensitive: true,
 			},
+			"created_at": {
+				Description: "Date and time (UTC) the API client was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"delete_at": {
+				Description: "Date and time (UTC) the API client will be deleted by commercetools",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_id": {
+				Description: "ID of the rotated client which is deleted once the overlap has passed",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_delete_at": {
+				Description: "Date and time (UTC) after which the rotated client is deleted",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
 
-func resourceAPIClientCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+func resourceAPIClientCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if apiClientRotationTriggered(d.GetChange) {
+		for _, key := range []string{"secret", "created_at", "delete_at", "previous_id", "previous_delete_at"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !d.Get("store_secret").(bool) && d.Get("secret").(string) != "" {
+		if err := d.SetNew("secret", ""); err != nil {
+			return err
+		}
+	}
+
+	// Whether the overlap has passed is decided when the change is applied,
+	// so the plan doesn't depend on the time it is created
+	if d.Get("previous_id").(string) != "" {
+		if err := d.SetNewComputed("previous_id"); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed("previous_delete_at"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apiClientRotationTriggered returns true when the trigger of the rotation
+// block changed. Adding a rotation block to an existing client doesn't rotate
+// the client.
+func apiClientRotationTriggered(getChange func(string) (any, any)) bool {
+	o, n := getChange("rotation.0.trigger")
+	oldTrigger, _ := o.(string)
+	newTrigger, _ := n.(string)
+	return oldTrigger != "" && newTrigger != "" && oldTrigger != newTrigger
+}
+
+// apiClientRotationExpired returns true when the overlap period of a rotated
+// client has passed.
+func apiClientRotationExpired(deleteAt string, now time.Time) bool {
+	if deleteAt == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, deleteAt)
+	if err != nil {
+		return true
+	}
+	return !now.Before(t)
+}
+
+func expandAPIClientDraft(d *schema.ResourceData) platform.ApiClientDraft {
 	scopes := d.Get("scope").(*schema.Set).List()
 
 	scopeParts := make([]string, 0)
@@ -79,23 +211,47 @@ func resourceAPIClientCreate(ctx context.Context, d *schema.ResourceData, m any)
 	if val := d.Get("refresh_token_validity_seconds").(int); val != 0 {
 		draft.RefreshTokenValiditySeconds = &val
 	}
+	if val := d.Get("delete_days_after_creation").(int); val != 0 {
+		draft.DeleteDaysAfterCreation = &val
+	}
+	return draft
+}
 
+// createAPIClient creates a new API client and delivers the secret to the
+// state and/or the commercetools_api_client_secret ephemeral resource. This is
+// used both on create and on rotation.
+func createAPIClient(ctx context.Context, d *schema.ResourceData, m any) error {
 	client := getClient(m)
+	draft := expandAPIClientDraft(d)
 
 	var apiClient *platform.ApiClient
-
 	err := retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
 		var err error
 		apiClient, err = client.ApiClients().Post(draft).Execute(ctx)
 		return utils.ProcessRemoteError(err)
 	})
-
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
 	d.SetId(apiClient.ID)
-	_ = d.Set("secret", apiClient.Secret)
+
+	if secrets := m.(*utils.ProviderData).APIClientSecrets; secrets != nil && apiClient.Secret != nil {
+		secrets.Set(apiClient.ID, *apiClient.Secret)
+	}
+
+	if d.Get("store_secret").(bool) {
+		_ = d.Set("secret", apiClient.Secret)
+	} else {
+		_ = d.Set("secret", "")
+	}
+	return nil
+}
+
+func resourceAPIClientCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if err := createAPIClient(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceAPIClientRead(ctx, d, m)
 }
@@ -119,16 +275,98 @@ func resourceAPIClientRead(ctx context.Context, d *schema.ResourceData, m any) d
 	_ = d.Set("scope", scopes)
 	_ = d.Set("access_token_validity_seconds", apiClient.AccessTokenValiditySeconds)
 	_ = d.Set("refresh_token_validity_seconds", apiClient.RefreshTokenValiditySeconds)
+	_ = d.Set("created_at", formatAPIClientTime(apiClient.CreatedAt))
+	_ = d.Set("delete_at", formatAPIClientTime(apiClient.DeleteAt))
 	return nil
 }
 
+func formatAPIClientTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func resourceAPIClientUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	// The previous client is planned as unknown, see
+	// resourceAPIClientCustomizeDiff, so it is taken from the state
+	o, _ := d.GetChange("previous_id")
+	pendingID := o.(string)
+	o, _ = d.GetChange("previous_delete_at")
+	pendingDeleteAt := o.(string)
+
+	if apiClientRotationTriggered(d.GetChange) {
+		oldID := d.Id()
+
+		// A client which was rotated before is still pending deletion; it is
+		// replaced by the client we rotate now.
+		if pendingID != "" {
+			if err := deleteAPIClient(ctx, m, pendingID); err != nil {
+				return diag.FromErr(err)
+			}
+			_ = d.Set("previous_id", "")
+			_ = d.Set("previous_delete_at", "")
+		}
+
+		if err := createAPIClient(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// The previous client is recorded before it is deleted, so it is
+		// deleted by a later apply when the deletion fails
+		overlap, _ := time.ParseDuration(d.Get("rotation.0.overlap").(string))
+		_ = d.Set("previous_id", oldID)
+		_ = d.Set("previous_delete_at", time.Now().Add(max(overlap, 0)).UTC().Format(time.RFC3339))
+
+		if overlap <= 0 {
+			if err := deleteAPIClient(ctx, m, oldID); err != nil {
+				return diag.FromErr(err)
+			}
+			_ = d.Set("previous_id", "")
+			_ = d.Set("previous_delete_at", "")
+		}
+
+		return resourceAPIClientRead(ctx, d, m)
+	}
+
+	_ = d.Set("previous_id", pendingID)
+	_ = d.Set("previous_delete_at", pendingDeleteAt)
+	if pendingID != "" && apiClientRotationExpired(pendingDeleteAt, time.Now()) {
+		if err := deleteAPIClient(ctx, m, pendingID); err != nil {
+			return diag.FromErr(err)
+		}
+		_ = d.Set("previous_id", "")
+		_ = d.Set("previous_delete_at", "")
+	}
+
+	if !d.Get("store_secret").(bool) {
+		_ = d.Set("secret", "")
+	}
+
+	return resourceAPIClientRead(ctx, d, m)
+}
+
 func resourceAPIClientDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if previousID := d.Get("previous_id").(string); previousID != "" {
+		if err := deleteAPIClient(ctx, m, previousID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diag.FromErr(deleteAPIClient(ctx, m, d.Id()))
+}
+
+// deleteAPIClient deletes the client with the given ID. Clients which no
+// longer exist, for example because they were deleted by commercetools after
+// `delete_days_after_creation`, are ignored.
+func deleteAPIClient(ctx context.Context, m any, id string) error {
 	client := getClient(m)
 
-	err := retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
-		_, err := client.ApiClients().WithId(d.Id()).Delete().Execute(ctx)
+	return retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
+		_, err := client.ApiClients().WithId(id).Delete().Execute(ctx)
+		if utils.IsResourceNotFoundError(err) {
+			return nil
+		}
 		return utils.ProcessRemoteError(err)
 	})
-
-	return diag.FromErr(err)
 }
@@ -0,0 +1,199 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIClientRotationExpired(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		deleteAt string
+		expected bool
+	}{
+		{"no rotation pending", "", false},
+		{"overlap not passed", "2024-01-02T13:00:00Z", false},
+		{"overlap passed", "2024-01-02T11:00:00Z", true},
+		{"overlap ends now", "2024-01-02T12:00:00Z", true},
+		{"invalid timestamp", "tomorrow", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, apiClientRotationExpired(c.deleteAt, now))
+		})
+	}
+}
+
+func TestAPIClientRotationTriggered(t *testing.T) {
+	cases := []struct {
+		name     string
+		old      string
+		new      string
+		expected bool
+	}{
+		{"trigger changed", "2024-01", "2024-02", true},
+		{"trigger unchanged", "2024-01", "2024-01", false},
+		{"rotation block added", "", "2024-01", false},
+		{"rotation block removed", "2024-01", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			getChange := func(string) (any, any) { return c.old, c.new }
+			assert.Equal(t, c.expected, apiClientRotationTriggered(getChange))
+		})
+	}
+}
+
+func TestAPIClientPendingPreviousClient(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"name":         "my-client",
+		"scope":        []any{"view_orders:my-project"},
+		"store_secret": false,
+		"rotation":     []any{map[string]any{"trigger": "2024-02", "overlap": "24h"}},
+	})
+	state := func(previousID, previousDeleteAt string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "client-2",
+			Attributes: map[string]string{
+				"id":                         "client-2",
+				"name":                       "my-client",
+				"scope.#":                    "1",
+				"scope.0":                    "view_orders:my-project",
+				"store_secret":               "false",
+				"rotation.#":                 "1",
+				"rotation.0.trigger":         "2024-02",
+				"rotation.0.overlap":         "24h",
+				"previous_id":                previousID,
+				"previous_delete_at":         previousDeleteAt,
+				"secret":                     "",
+				"created_at":                 "2024-02-01T00:00:00Z",
+				"delete_at":                  "",
+				"delete_days_after_creation": "0",
+			},
+		}
+	}
+
+	// The plan doesn't depend on whether the overlap has passed, this is
+	// decided when the change is applied
+	for _, deleteAt := range []string{"2000-01-01T00:00:00Z", "2999-01-01T00:00:00Z"} {
+		diff, err := resourceAPIClient().Diff(context.Background(), state("client-1", deleteAt), config, nil)
+		require.NoError(t, err)
+		require.NotNil(t, diff)
+		assert.True(t, diff.Attributes["previous_id"].NewComputed)
+		assert.True(t, diff.Attributes["previous_delete_at"].NewComputed)
+	}
+
+	diff, err := resourceAPIClient().Diff(context.Background(), state("", ""), config, nil)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+}
+
+func TestExpandAPIClientDraft(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAPIClient().Schema, map[string]any{
+		"name":                       "my-client",
+		"scope":                      []any{"view_orders:my-project"},
+		"delete_days_after_creation": 30,
+	})
+
+	assert.Equal(t, platform.ApiClientDraft{
+		Name:                    "my-client",
+		Scope:                   "view_orders:my-project",
+		DeleteDaysAfterCreation: ref(30),
+	}, expandAPIClientDraft(d))
+}
+
+func TestAccAPIClient_rotation(t *testing.T) {
+	resourceName := "commercetools_api_client.rotated"
+	var firstID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckAPIClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIClientRotationConfig("2024-01", "0s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "secret", ""),
+					resource.TestCheckResourceAttr(resourceName, "previous_id", ""),
+					func(s *terraform.State) error {
+						firstID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccAPIClientRotationConfig("2024-02", "24h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "secret", ""),
+					resource.TestCheckResourceAttrSet(resourceName, "previous_delete_at"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources[resourceName]
+						assert.NotEqual(t, firstID, rs.Primary.ID)
+						assert.Equal(t, firstID, rs.Primary.Attributes["previous_id"])
+
+						client := getClient(testAccProvider.Meta())
+						_, err := client.ApiClients().WithId(firstID).Get().Execute(context.Background())
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAPIClientDestroy(s *terraform.State) error {
+	client := getClient(testAccProvider.Meta())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "commercetools_api_client" {
+			continue
+		}
+		for _, id := range []string{rs.Primary.ID, rs.Primary.Attributes["previous_id"]} {
+			if id == "" {
+				continue
+			}
+			response, err := client.ApiClients().WithId(id).Get().Execute(context.Background())
+			if err == nil {
+				if response != nil && response.ID == id {
+					return fmt.Errorf("api client (%s) still exists", id)
+				}
+				continue
+			}
+			if newErr := checkApiResult(err); newErr != nil {
+				return newErr
+			}
+		}
+	}
+	return nil
+}
+
+func testAccAPIClientRotationConfig(trigger, overlap string) string {
+	return hclTemplate(`
+		resource "commercetools_api_client" "rotated" {
+			name         = "rotated-client"
+			scope        = ["view_orders:{{ .projectKey }}"]
+			store_secret = false
+
+			rotation {
+				trigger = "{{ .trigger }}"
+				overlap = "{{ .overlap }}"
+			}
+		}`, map[string]any{
+		"projectKey": os.Getenv("CTP_PROJECT_KEY"),
+		"trigger":    trigger,
+		"overlap":    overlap,
+	})
+}
@@ -2,14 +2,22 @@ package commercetools
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"log"
+	"maps"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/labd/commercetools-go-sdk/platform"
 
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
@@ -20,11 +28,19 @@ func resourceAPIExtension() *schema.Resource {
 		Description: "Create a new API extension to extend the behaviour of an API with business logic. " +
 			"Note that API extensions affect the performance of the API it is extending. If it fails, the whole API " +
 			"call fails \n\n" +
+			"When a new extension is planned, the existing extensions of the project are counted to check " +
+			"the [maximum number of extensions](https://docs.commercetools.com/api/limits#api-extensions). " +
+			"This is an extra request during the plan, which is skipped when the API client isn't allowed " +
+			"to list the extensions\n\n" +
 			"Also see the [API Extension API Documentation](https://docs.commercetools.com/api/projects/api-extensions)",
 		CreateContext: resourceAPIExtensionCreate,
 		ReadContext:   resourceAPIExtensionRead,
 		UpdateContext: resourceAPIExtensionUpdate,
 		DeleteContext: resourceAPIExtensionDelete,
+		CustomizeDiff: customdiff.All(
+			resourceAPIExtensionCustomizeDiff,
+			validateExtensionLimit,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -55,6 +71,15 @@ func resourceAPIExtension() *schema.Resource {
 							Required:     true,
 							ValidateFunc: validateDestinationType,
 						},
+						"authentication_mode": {
+							Description: "How commercetools authenticates to the destination. For AWSLambda " +
+								"destinations either `Credentials` or `IAM`, for HTTP destinations either `None`, " +
+								"`AuthorizationHeader` or `AzureFunctions`. When not set, the mode is derived from " +
+								"the authentication values which are set",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(extensionAuthenticationModes, false),
+						},
 
 						// HTTP specific fields
 						"url": {
@@ -62,12 +87,40 @@ func resourceAPIExtension() *schema.Resource {
 							Optional: true,
 						},
 						"azure_authentication": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"azure_authentication_wo": {
+							Description: "Write-only variant of `azure_authentication`, which is never stored " +
+								"in the state. Requires Terraform 1.11 or later",
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+						},
+						"azure_authentication_wo_version": {
+							Description: "Version of `azure_authentication_wo`, change it to update the key",
+							Type:        schema.TypeInt,
+							Optional:    true,
 						},
 						"authorization_header": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"authorization_header_wo": {
+							Description: "Write-only variant of `authorization_header`, which is never stored " +
+								"in the state. Requires Terraform 1.11 or later",
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+						},
+						"authorization_header_wo_version": {
+							Description: "Version of `authorization_header_wo`, change it to update the header",
+							Type:        schema.TypeInt,
+							Optional:    true,
 						},
 
 						// AWSLambda specific fields
@@ -84,6 +137,19 @@ func resourceAPIExtension() *schema.Resource {
 							Optional:  true,
 							Sensitive: true,
 						},
+						"access_secret_wo": {
+							Description: "Write-only variant of `access_secret`, which is never stored in the " +
+								"state. Requires Terraform 1.11 or later",
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+						},
+						"access_secret_wo_version": {
+							Description: "Version of `access_secret_wo`, change it to update the secret",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
 					},
 				},
 			},
@@ -95,20 +161,27 @@ func resourceAPIExtension() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"resource_type_id": {
-							Description: "Currently, cart, order, payment, and customer are supported",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description: "Currently, cart, order, payment, payment-method, customer, customer-group, " +
+								"quote-request, staged-quote, quote, business-unit and shopping-list are supported",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(extensionResourceTypeIds, false),
 						},
 						"actions": {
 							Description: "Currently, Create and Update are supported",
 							Type:        schema.TypeList,
 							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(extensionActions, false),
+							},
 						},
 						"condition": {
-							Description: "Valid predicate that controls the conditions under which the API Extension is called.",
-							Type:        schema.TypeString,
-							Optional:    true,
+							Description:  "Valid predicate that controls the conditions under which the API Extension is called.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validatePredicate,
 						},
 					},
 				},
@@ -122,6 +195,12 @@ func resourceAPIExtension() *schema.Resource {
 				Default:  2000,
 				Optional: true,
 			},
+			"verify_destination": {
+				Description: "Check during the plan whether the url of the destination can be reached. The " +
+					"check sends a HEAD request without credentials, every response counts as reachable",
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -152,10 +231,259 @@ func validateExtensionDestination(draft platform.ExtensionDraft) error {
 		if t.Arn == "" {
 			return fmt.Errorf("arn is required when using AWSLambda as destination")
 		}
+	case awsLambdaIAMDestination:
+		if t.Arn == "" {
+			return fmt.Errorf("arn is required when using AWSLambda as destination")
+		}
 	}
 	return nil
 }
 
+// extensionResourceTypeIds are the resource types which can trigger an
+// extension, see
+// https://docs.commercetools.com/api/projects/api-extensions#extensionresourcetypeid
+var extensionResourceTypeIds = []string{
+	string(platform.ExtensionResourceTypeIdCart),
+	string(platform.ExtensionResourceTypeIdOrder),
+	string(platform.ExtensionResourceTypeIdPayment),
+	string(platform.ExtensionResourceTypeIdPaymentMethod),
+	string(platform.ExtensionResourceTypeIdCustomer),
+	string(platform.ExtensionResourceTypeIdCustomerGroup),
+	string(platform.ExtensionResourceTypeIdQuoteRequest),
+	string(platform.ExtensionResourceTypeIdStagedQuote),
+	string(platform.ExtensionResourceTypeIdQuote),
+	string(platform.ExtensionResourceTypeIdBusinessUnit),
+	string(platform.ExtensionResourceTypeIdShoppingList),
+}
+
+// extensionActions are the actions which trigger an extension, all resource
+// types support both of them
+var extensionActions = []string{
+	string(platform.ExtensionActionCreate),
+	string(platform.ExtensionActionUpdate),
+}
+
+// maxExtensions is the maximum number of API extensions of a project, see
+// https://docs.commercetools.com/api/limits#api-extensions
+const maxExtensions = 25
+
+const (
+	extensionAuthenticationCredentials         = "Credentials"
+	extensionAuthenticationIAM                 = "IAM"
+	extensionAuthenticationNone                = "None"
+	extensionAuthenticationAuthorizationHeader = "AuthorizationHeader"
+	extensionAuthenticationAzureFunctions      = "AzureFunctions"
+)
+
+var extensionAuthenticationModes = []string{
+	extensionAuthenticationCredentials,
+	extensionAuthenticationIAM,
+	extensionAuthenticationNone,
+	extensionAuthenticationAuthorizationHeader,
+	extensionAuthenticationAzureFunctions,
+}
+
+// extensionSecretFields are the secrets of the destination, each of them has a
+// write-only variant with the _wo suffix
+var extensionSecretFields = []string{"access_secret", "authorization_header", "azure_authentication"}
+
+// extensionAuthenticationFields are the fields of the destination which are
+// needed to validate the authentication mode
+var extensionAuthenticationFields = []string{
+	"type", "authentication_mode", "access_key",
+	"access_secret", "access_secret_wo",
+	"authorization_header", "authorization_header_wo",
+	"azure_authentication", "azure_authentication_wo",
+}
+
+// awsLambdaIAMDestination is an AWSLambda destination with the IAM
+// authentication mode, which isn't supported by the commercetools SDK yet.
+// commercetools assumes a role in the AWS account to invoke the function, so
+// there are no access keys.
+type awsLambdaIAMDestination struct {
+	Arn string
+}
+
+func (obj awsLambdaIAMDestination) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type               string `json:"type"`
+		AuthenticationMode string `json:"authenticationMode"`
+		Arn                string `json:"arn"`
+	}{
+		Type:               "AWSLambda",
+		AuthenticationMode: extensionAuthenticationIAM,
+		Arn:                obj.Arn,
+	})
+}
+
+// verifyExtensionDestination checks whether the url of a destination can be
+// reached. It is a variable so the tests don't need network access.
+var verifyExtensionDestination = func(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Only a HEAD request without credentials is sent, so the extension isn't
+	// executed. Every response counts, since most extensions only accept POST
+	// requests
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func resourceAPIExtensionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, _ any) error {
+	input := extensionDestinationInput(d)
+	if input == nil {
+		return nil
+	}
+
+	known := true
+	for _, field := range extensionAuthenticationFields {
+		known = known && d.NewValueKnown(fmt.Sprintf("destination.0.%s", field))
+	}
+	if known {
+		if err := validateExtensionAuthentication(input); err != nil {
+			return err
+		}
+	}
+
+	if !d.Get("verify_destination").(bool) || !d.NewValueKnown("destination.0.url") {
+		return nil
+	}
+	if !d.HasChange("destination.0.url") && !d.HasChange("verify_destination") {
+		return nil
+	}
+
+	url, _ := input["url"].(string)
+	destinationType, _ := input["type"].(string)
+	switch strings.ToLower(destinationType) {
+	case "http", "googlecloudfunction":
+		if url == "" {
+			return nil
+		}
+		if err := verifyExtensionDestination(ctx, url); err != nil {
+			return fmt.Errorf("destination.0.url: %s can't be reached: %w", url, err)
+		}
+	}
+	return nil
+}
+
+// validateExtensionLimit returns an error when a new extension exceeds the
+// maximum number of extensions of the project. Only the existing extensions
+// are counted, not the other extensions which are created in the same plan.
+// This is an extra request for every planned extension, when it fails the
+// check is skipped and creating the extension reports the limit instead.
+func validateExtensionLimit(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	result, err := getClient(m).Extensions().Get().Limit(1).WithTotal(true).Execute(ctx)
+	if err != nil {
+		log.Printf("[WARN] skipping the check of the maximum number of API extensions, failed to count "+
+			"the API extensions of the project: %s", err)
+		return nil
+	}
+
+	total := result.Count
+	if result.Total != nil {
+		total = *result.Total
+	}
+	if total >= maxExtensions {
+		return fmt.Errorf("the project already has %d API extensions, which is the maximum number of extensions "+
+			"per project. Remove an extension before adding a new one", total)
+	}
+	return nil
+}
+
+// validateExtensionAuthentication validates the authentication mode of the
+// destination against the authentication values which are set. Without an
+// authentication mode, the mode is derived from the values.
+func validateExtensionAuthentication(input map[string]any) error {
+	for _, field := range extensionSecretFields {
+		_, isSet := isNotEmpty(input, field)
+		_, isWriteOnlySet := isNotEmpty(input, field+"_wo")
+		if isSet && isWriteOnlySet {
+			return fmt.Errorf("destination.0.%s: only one of %s and %s_wo can be set", field, field, field)
+		}
+	}
+
+	mode, _ := input["authentication_mode"].(string)
+	if mode == "" {
+		return nil
+	}
+
+	destinationType, _ := input["type"].(string)
+	var modes, required, forbidden []string
+	switch strings.ToLower(destinationType) {
+	case "awslambda":
+		modes = []string{extensionAuthenticationCredentials, extensionAuthenticationIAM}
+		switch mode {
+		case extensionAuthenticationCredentials:
+			required = []string{"access_key", "access_secret"}
+		case extensionAuthenticationIAM:
+			forbidden = []string{"access_key", "access_secret"}
+		}
+	case "http":
+		modes = []string{
+			extensionAuthenticationNone,
+			extensionAuthenticationAuthorizationHeader,
+			extensionAuthenticationAzureFunctions,
+		}
+		switch mode {
+		case extensionAuthenticationNone:
+			forbidden = []string{"authorization_header", "azure_authentication"}
+		case extensionAuthenticationAuthorizationHeader:
+			required = []string{"authorization_header"}
+			forbidden = []string{"azure_authentication"}
+		case extensionAuthenticationAzureFunctions:
+			required = []string{"azure_authentication"}
+			forbidden = []string{"authorization_header"}
+		}
+	default:
+		return fmt.Errorf("destination.0.authentication_mode: not supported for %s destinations", destinationType)
+	}
+
+	if !slices.Contains(modes, mode) {
+		return fmt.Errorf("destination.0.authentication_mode: %s is not valid for %s destinations, valid options are: %s",
+			mode, destinationType, strings.Join(modes, ", "))
+	}
+
+	var errs []error
+	for _, field := range required {
+		if !isExtensionFieldSet(input, field) {
+			name := field
+			if slices.Contains(extensionSecretFields, field) {
+				name = fmt.Sprintf("%s or %s_wo", field, field)
+			}
+			errs = append(errs, fmt.Errorf("destination.0.%s: %s is required when the authentication mode is %s",
+				field, name, mode))
+		}
+	}
+	for _, field := range forbidden {
+		if isExtensionFieldSet(input, field) {
+			errs = append(errs, fmt.Errorf("destination.0.%s: can't be set when the authentication mode is %s",
+				field, mode))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isExtensionFieldSet returns whether the field or its write-only variant is
+// set
+func isExtensionFieldSet(input map[string]any, field string) bool {
+	if _, ok := isNotEmpty(input, field); ok {
+		return true
+	}
+	_, ok := isNotEmpty(input, field+"_wo")
+	return ok
+}
+
 func resourceAPIExtensionCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
 
@@ -299,12 +627,51 @@ func resourceAPIExtensionDelete(ctx context.Context, d *schema.ResourceData, m a
 // Helper methods
 //
 
-func expandExtensionDestination(d *schema.ResourceData) (platform.Destination, error) {
-	input, err := elementFromList(d, "destination")
-	if err != nil {
-		return nil, err
+// destinationGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff
+type destinationGetter interface {
+	Get(key string) any
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// extensionDestinationInput returns the destination, including the write-only
+// secrets. These are only available in the configuration.
+func extensionDestinationInput(d destinationGetter) map[string]any {
+	input := maps.Clone(firstElementFromSlice(d.Get("destination").([]any)))
+	if input == nil {
+		return nil
+	}
+
+	for _, field := range extensionSecretFields {
+		key := field + "_wo"
+		if _, ok := isNotEmpty(input, key); ok {
+			continue
+		}
+		value, diags := d.GetRawConfigAt(cty.GetAttrPath("destination").IndexInt(0).GetAttr(key))
+		if diags.HasError() || !value.Type().Equals(cty.String) || value.IsNull() || !value.IsKnown() {
+			continue
+		}
+		input[key] = value.AsString()
+	}
+	return input
+}
+
+func expandExtensionDestination(d destinationGetter) (platform.Destination, error) {
+	input := extensionDestinationInput(d)
+	if input == nil {
+		return nil, fmt.Errorf("destination is required")
 	}
 
+	// The write-only secrets take the place of the secrets they replace
+	for _, field := range extensionSecretFields {
+		if value, ok := isNotEmpty(input, field+"_wo"); ok {
+			input[field] = value
+		}
+	}
+	return expandExtensionDestinationInput(input)
+}
+
+func expandExtensionDestinationInput(input map[string]any) (platform.Destination, error) {
 	switch strings.ToLower(input["type"].(string)) {
 	case "googlecloudfunction":
 		return platform.GoogleCloudFunctionDestination{
@@ -321,6 +688,11 @@ func expandExtensionDestination(d *schema.ResourceData) (platform.Destination, e
 			Authentication: auth,
 		}, nil
 	case "awslambda":
+		if input["authentication_mode"] == extensionAuthenticationIAM {
+			return awsLambdaIAMDestination{
+				Arn: input["arn"].(string),
+			}, nil
+		}
 		return platform.AWSLambdaDestination{
 			Arn:          input["arn"].(string),
 			AccessKey:    input["access_key"].(string),
@@ -362,7 +734,7 @@ func expandExtensionDestinationAuthentication(destInput map[string]any) (platfor
 
 // flattenExtensionDestination flattens the destination returned by
 // commercetools to write it in the state file.
-func flattenExtensionDestination(dst platform.Destination, d *schema.ResourceData) []map[string]string {
+func flattenExtensionDestination(dst platform.Destination, d *schema.ResourceData) []map[string]any {
 	// Special handling is required here since the destination contains a secret
 	// value which is returned as a masked value by the commercetools API. This means
 	// we need to extract the value from the current raw state file. However, when
@@ -374,19 +746,42 @@ func flattenExtensionDestination(dst platform.Destination, d *schema.ResourceDat
 		isExisting = !rawState.AsValueMap()["version"].IsNull()
 	}
 
+	// The write-only secrets are left out, so they never end up in the state
+	input := firstElementFromSlice(d.Get("destination").([]any))
 	var current platform.Destination
-	if isExisting {
-		current, _ = expandExtensionDestination(d)
+	if isExisting && input != nil {
+		current, _ = expandExtensionDestinationInput(input)
 	}
 
+	result, mode := flattenExtensionDestinationValue(dst, current)
+	if result == nil {
+		return []map[string]any{}
+	}
+
+	// The authentication mode is derived from the authentication values when
+	// it isn't set, so it is only written when it was set before
+	if _, ok := isNotEmpty(input, "authentication_mode"); ok {
+		result["authentication_mode"] = mode
+	}
+	for _, field := range extensionSecretFields {
+		if version, ok := input[field+"_wo_version"]; ok {
+			result[field+"_wo_version"] = version
+		}
+	}
+	return []map[string]any{result}
+}
+
+// flattenExtensionDestinationValue returns the destination and its
+// authentication mode
+func flattenExtensionDestinationValue(dst platform.Destination, current platform.Destination) (map[string]any, string) {
 	// A destination is either GoogleCloudFunction, HTTP or AWSLambda
 	switch d := dst.(type) {
 
 	case platform.GoogleCloudFunctionDestination:
-		return []map[string]string{{
+		return map[string]any{
 			"type": "GoogleCloudFunction",
 			"url":  d.Url,
-		}}
+		}, ""
 
 	// For the HTTP Destination there are two specific authentication types:
 	// AuthorizationHeader and AzureFunctions.
@@ -406,11 +801,11 @@ func flattenExtensionDestination(dst platform.Destination, d *schema.ResourceDat
 				}
 			}
 
-			return []map[string]string{{
+			return map[string]any{
 				"type":                 "HTTP",
 				"url":                  d.Url,
 				"authorization_header": secretValue,
-			}}
+			}, extensionAuthenticationAuthorizationHeader
 
 		case platform.AzureFunctionsAuthentication:
 			// The headerValue value is masked when retrieved from commercetools,
@@ -423,21 +818,35 @@ func flattenExtensionDestination(dst platform.Destination, d *schema.ResourceDat
 					}
 				}
 			}
-			return []map[string]string{{
+			return map[string]any{
 				"type":                 "HTTP",
 				"url":                  d.Url,
 				"azure_authentication": secretValue,
-			}}
+			}, extensionAuthenticationAzureFunctions
+
+		case nil:
+			return map[string]any{
+				"type": "HTTP",
+				"url":  d.Url,
+			}, extensionAuthenticationNone
 
 		default:
 			log.Println("Unexpected authentication type")
-			return []map[string]string{{
+			return map[string]any{
 				"type": "HTTP",
 				"url":  d.Url,
-			}}
+			}, ""
 		}
 
 	case platform.AWSLambdaDestination:
+		// commercetools doesn't return access keys for destinations with the
+		// IAM authentication mode
+		if d.AccessKey == "" {
+			return map[string]any{
+				"type": "awslambda",
+				"arn":  d.Arn,
+			}, extensionAuthenticationIAM
+		}
 
 		// The accessSecret value is masked when retrieved from commercetools,
 		// so use the value from the state file instead (if it exists)
@@ -448,15 +857,15 @@ func flattenExtensionDestination(dst platform.Destination, d *schema.ResourceDat
 			}
 		}
 
-		return []map[string]string{{
+		return map[string]any{
 			"type":          "awslambda",
 			"access_key":    d.AccessKey,
 			"access_secret": secretValue,
 			"arn":           d.Arn,
-		}}
+		}, extensionAuthenticationCredentials
 
 	default:
-		return []map[string]string{}
+		return nil, ""
 	}
 }
 
@@ -480,28 +889,7 @@ func expandExtensionTriggers(d *schema.ResourceData) []platform.ExtensionTrigger
 
 	for _, raw := range input {
 		i := raw.(map[string]any)
-		var typeId platform.ExtensionResourceTypeId
-
-		switch i["resource_type_id"].(string) {
-		case "cart":
-			typeId = platform.ExtensionResourceTypeIdCart
-		case "order":
-			typeId = platform.ExtensionResourceTypeIdOrder
-		case "payment":
-			typeId = platform.ExtensionResourceTypeIdPayment
-		case "customer":
-			typeId = platform.ExtensionResourceTypeIdCustomer
-		case "quote-request":
-			typeId = platform.ExtensionResourceTypeIdQuoteRequest
-		case "staged-quote":
-			typeId = platform.ExtensionResourceTypeIdStagedQuote
-		case "quote":
-			typeId = platform.ExtensionResourceTypeIdQuote
-		case "business-unit":
-			typeId = platform.ExtensionResourceTypeIdBusinessUnit
-		case "shopping-list":
-			typeId = platform.ExtensionResourceTypeIdShoppingList
-		}
+		typeId := platform.ExtensionResourceTypeId(i["resource_type_id"].(string))
 
 		rawActions := i["actions"].([]any)
 		actions := make([]platform.ExtensionAction, 0, len(rawActions))
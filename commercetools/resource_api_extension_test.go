@@ -2,7 +2,10 @@ package commercetools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 
@@ -12,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 func TestAPIExtensionExpandExtensionDestination(t *testing.T) {
@@ -69,6 +75,263 @@ func TestAPIExtensionExpandExtensionDestinationAuthentication(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestAPIExtensionExpandExtensionDestinationIAM(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAPIExtension().Schema, map[string]any{
+		"destination": []any{
+			map[string]any{
+				"type":                "AWSLambda",
+				"authentication_mode": "IAM",
+				"arn":                 "arn:aws:lambda:eu-west-1:111111111:function:api_extensions",
+			},
+		},
+	})
+	destination, err := expandExtensionDestination(d)
+	assert.NoError(t, err)
+	assert.Equal(t, awsLambdaIAMDestination{Arn: "arn:aws:lambda:eu-west-1:111111111:function:api_extensions"}, destination)
+
+	data, err := json.Marshal(platform.ExtensionDraft{Destination: destination})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"destination": {
+			"type": "AWSLambda",
+			"authenticationMode": "IAM",
+			"arn": "arn:aws:lambda:eu-west-1:111111111:function:api_extensions"
+		},
+		"triggers": null
+	}`, string(data))
+}
+
+func TestFlattenExtensionDestination(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAPIExtension().Schema, map[string]any{
+		"destination": []any{
+			map[string]any{
+				"type":                     "awslambda",
+				"authentication_mode":      "IAM",
+				"arn":                      "arn",
+				"access_secret_wo_version": 2,
+			},
+		},
+	})
+	result := flattenExtensionDestination(platform.AWSLambdaDestination{Arn: "arn"}, d)
+	assert.Equal(t, []map[string]any{{
+		"type":                            "awslambda",
+		"arn":                             "arn",
+		"authentication_mode":             "IAM",
+		"access_secret_wo_version":        2,
+		"authorization_header_wo_version": 0,
+		"azure_authentication_wo_version": 0,
+	}}, result)
+
+	d = schema.TestResourceDataRaw(t, resourceAPIExtension().Schema, map[string]any{
+		"destination": []any{
+			map[string]any{
+				"type":                 "HTTP",
+				"url":                  "https://example.com",
+				"authorization_header": "Basic 12345",
+			},
+		},
+	})
+	result = flattenExtensionDestination(platform.HttpDestination{
+		Url:            "https://example.com",
+		Authentication: platform.AuthorizationHeaderAuthentication{HeaderValue: "****"},
+	}, d)
+	assert.Equal(t, "Basic 12345", result[0]["authorization_header"])
+	assert.NotContains(t, result[0], "authentication_mode")
+}
+
+func TestValidateExtensionAuthentication(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input map[string]any
+		err   string
+	}{
+		{
+			name:  "derived mode",
+			input: map[string]any{"type": "awslambda", "access_key": "key", "access_secret": "secret"},
+		},
+		{
+			name:  "iam",
+			input: map[string]any{"type": "AWSLambda", "authentication_mode": "IAM", "arn": "arn"},
+		},
+		{
+			name:  "iam with access keys",
+			input: map[string]any{"type": "awslambda", "authentication_mode": "IAM", "access_key": "key", "access_secret_wo": "secret"},
+			err: "destination.0.access_key: can't be set when the authentication mode is IAM\n" +
+				"destination.0.access_secret: can't be set when the authentication mode is IAM",
+		},
+		{
+			name:  "credentials with write-only secret",
+			input: map[string]any{"type": "awslambda", "authentication_mode": "Credentials", "access_key": "key", "access_secret_wo": "secret"},
+		},
+		{
+			name:  "credentials without secret",
+			input: map[string]any{"type": "awslambda", "authentication_mode": "Credentials", "access_key": "key"},
+			err:   "destination.0.access_secret: access_secret or access_secret_wo is required when the authentication mode is Credentials",
+		},
+		{
+			name:  "secret and write-only secret",
+			input: map[string]any{"type": "HTTP", "authorization_header": "a", "authorization_header_wo": "b"},
+			err:   "destination.0.authorization_header: only one of authorization_header and authorization_header_wo can be set",
+		},
+		{
+			name:  "mode of other destination type",
+			input: map[string]any{"type": "HTTP", "authentication_mode": "IAM"},
+			err:   "destination.0.authentication_mode: IAM is not valid for HTTP destinations, valid options are: None, AuthorizationHeader, AzureFunctions",
+		},
+		{
+			name:  "none with header",
+			input: map[string]any{"type": "HTTP", "authentication_mode": "None", "authorization_header": "a"},
+			err:   "destination.0.authorization_header: can't be set when the authentication mode is None",
+		},
+		{
+			name:  "google cloud function",
+			input: map[string]any{"type": "GoogleCloudFunction", "authentication_mode": "None"},
+			err:   "destination.0.authentication_mode: not supported for GoogleCloudFunction destinations",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateExtensionAuthentication(tc.input)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestAPIExtensionVerifyDestination(t *testing.T) {
+	var verified []string
+	verify := verifyExtensionDestination
+	defer func() { verifyExtensionDestination = verify }()
+	verifyExtensionDestination = func(_ context.Context, url string) error {
+		verified = append(verified, url)
+		if url == "https://unreachable.example.com" {
+			return fmt.Errorf("no such host")
+		}
+		return nil
+	}
+
+	config := func(url string, verify bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]any{
+			"destination": []any{
+				map[string]any{"type": "HTTP", "url": url},
+			},
+			"trigger": []any{
+				map[string]any{"resource_type_id": "cart", "actions": []any{"Create"}},
+			},
+			"verify_destination": verify,
+		})
+	}
+
+	meta := newTestExtensionMeta(t, 0)
+	_, err := resourceAPIExtension().Diff(context.Background(), nil, config("https://example.com", false), meta)
+	assert.NoError(t, err)
+	assert.Empty(t, verified)
+
+	_, err = resourceAPIExtension().Diff(context.Background(), nil, config("https://example.com", true), meta)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com"}, verified)
+
+	_, err = resourceAPIExtension().Diff(context.Background(), nil, config("https://unreachable.example.com", true), meta)
+	assert.EqualError(t, err, "destination.0.url: https://unreachable.example.com can't be reached: no such host")
+}
+
+func TestAPIExtensionValidateTriggers(t *testing.T) {
+	config := func(trigger map[string]any) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]any{
+			"destination": []any{
+				map[string]any{"type": "HTTP", "url": "https://example.com"},
+			},
+			"trigger": []any{trigger},
+		})
+	}
+
+	diags := resourceAPIExtension().Validate(config(map[string]any{
+		"resource_type_id": "payment-method",
+		"actions":          []any{"Create", "Update"},
+		"condition":        `name = "Michael"`,
+	}))
+	assert.False(t, diags.HasError(), diags)
+
+	diags = resourceAPIExtension().Validate(config(map[string]any{
+		"resource_type_id": "orders",
+		"actions":          []any{"Create"},
+	}))
+	assert.True(t, diags.HasError())
+
+	diags = resourceAPIExtension().Validate(config(map[string]any{
+		"resource_type_id": "order",
+		"actions":          []any{"Delete"},
+	}))
+	assert.True(t, diags.HasError())
+
+	diags = resourceAPIExtension().Validate(config(map[string]any{
+		"resource_type_id": "order",
+		"actions":          []any{"Create"},
+		"condition":        `name = "Michael`,
+	}))
+	assert.True(t, diags.HasError())
+}
+
+func TestAPIExtensionValidateLimit(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"destination": []any{
+			map[string]any{"type": "HTTP", "url": "https://example.com"},
+		},
+		"trigger": []any{
+			map[string]any{"resource_type_id": "cart", "actions": []any{"Create"}},
+		},
+	})
+
+	_, err := resourceAPIExtension().Diff(context.Background(), nil, config, newTestExtensionMeta(t, 24))
+	assert.NoError(t, err)
+
+	_, err = resourceAPIExtension().Diff(context.Background(), nil, config, newTestExtensionMeta(t, 25))
+	assert.EqualError(t, err, "the project already has 25 API extensions, which is the maximum number of "+
+		"extensions per project. Remove an extension before adding a new one")
+
+	// Existing extensions are not counted again
+	state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{"version": "1"}}
+	_, err = resourceAPIExtension().Diff(context.Background(), state, config, newTestExtensionMeta(t, 25))
+	assert.NoError(t, err)
+
+	// The check is skipped when the extensions can't be listed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"statusCode": 403, "message": "Insufficient scope"}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := platform.NewClient(&platform.ClientConfig{URL: server.URL})
+	require.NoError(t, err)
+	meta := &utils.ProviderData{Client: client.WithProjectKey("test")}
+	_, err = resourceAPIExtension().Diff(context.Background(), nil, config, meta)
+	assert.NoError(t, err)
+}
+
+// newTestExtensionMeta returns the provider data with a client for a project
+// with the given number of extensions
+func newTestExtensionMeta(t *testing.T, total int) *utils.ProviderData {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test/extensions", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(platform.ExtensionPagedQueryResponse{
+			Limit:   1,
+			Count:   min(total, 1),
+			Total:   &total,
+			Results: []platform.Extension{},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := platform.NewClient(&platform.ClientConfig{URL: server.URL})
+	require.NoError(t, err)
+	return &utils.ProviderData{Client: client.WithProjectKey("test")}
+}
+
 func TestExpandExtensionTriggers(t *testing.T) {
 	resourceDataMap := map[string]any{
 		"id":             "2845b936-e407-4f29-957b-f8deb0fcba97",
@@ -80,6 +343,10 @@ func TestExpandExtensionTriggers(t *testing.T) {
 				"resource_type_id": "cart",
 				"actions":          []any{"Create", "Update"},
 			},
+			map[string]any{
+				"resource_type_id": "payment-method",
+				"actions":          []any{"Create"},
+			},
 		},
 		"timeout_in_ms": 1,
 		"key":           "create-order",
@@ -88,9 +355,10 @@ func TestExpandExtensionTriggers(t *testing.T) {
 	d := schema.TestResourceDataRaw(t, resourceAPIExtension().Schema, resourceDataMap)
 	triggers := expandExtensionTriggers(d)
 
-	assert.Len(t, triggers, 1)
+	assert.Len(t, triggers, 2)
 	assert.Equal(t, triggers[0].ResourceTypeId, platform.ExtensionResourceTypeIdCart)
 	assert.Len(t, triggers[0].Actions, 2)
+	assert.Equal(t, triggers[1].ResourceTypeId, platform.ExtensionResourceTypeIdPaymentMethod)
 }
 
 func TestAccAPIExtension_basic(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/ctutils"
 	"github.com/labd/commercetools-go-sdk/platform"
@@ -32,6 +33,11 @@ func resourceCartDiscount() *schema.Resource {
 				Version: 0,
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			validateCustomFields,
+			validateLocalizedStrings("name", "description"),
+			validateCurrencies("value.*.money.*.currency_code"),
+		),
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Description: "User-specific unique identifier for a cart discount. Must be unique across a project",
@@ -295,7 +301,7 @@ func resourceCartDiscountCreate(ctx context.Context, d *schema.ResourceData, m a
 		return diag.FromErr(err)
 	}
 
-	custom, err := CreateCustomFieldDraft(ctx, client, d)
+	custom, err := CreateCustomFieldDraft(ctx, m, d)
 	if err != nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -387,7 +393,7 @@ func resourceCartDiscountRead(ctx context.Context, d *schema.ResourceData, m any
 	_ = d.Set("valid_until", flattenTime(cartDiscount.ValidUntil))
 	_ = d.Set("requires_discount_code", cartDiscount.RequiresDiscountCode)
 	_ = d.Set("stacking_mode", cartDiscount.StackingMode)
-	_ = d.Set("custom", flattenCustomFields(cartDiscount.Custom))
+	_ = d.Set("custom", flattenCustomFields(cartDiscount.Custom, d.Get("custom").([]any)))
 	_ = d.Set("stores", flattenStores(cartDiscount.Stores))
 	return nil
 }
@@ -517,7 +523,7 @@ func resourceCartDiscountUpdate(ctx context.Context, d *schema.ResourceData, m a
 	}
 
 	if d.HasChange("custom") {
-		actions, err := CustomFieldUpdateActions[platform.CartDiscountSetCustomTypeAction, platform.CartDiscountSetCustomFieldAction](ctx, client, d)
+		actions, err := CustomFieldUpdateActions[platform.CartDiscountSetCustomTypeAction, platform.CartDiscountSetCustomFieldAction](ctx, m, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
@@ -30,6 +31,19 @@ func resourceCategory() *schema.Resource {
 				Version: 0,
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			validateCustomFields,
+			validateLocalizedStrings(
+				"name",
+				"description",
+				"slug",
+				"meta_title",
+				"meta_description",
+				"meta_keywords",
+				"assets.*.name",
+				"assets.*.description",
+			),
+		),
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Type:        schema.TypeString,
@@ -173,7 +187,7 @@ func resourceCategoryCreate(ctx context.Context, d *schema.ResourceData, m any)
 	slug := expandLocalizedString(d.Get("slug"))
 	key := stringRef(d.Get("key"))
 
-	custom, err := CreateCustomFieldDraft(ctx, client, d)
+	custom, err := CreateCustomFieldDraft(ctx, m, d)
 	if err != nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -281,7 +295,7 @@ func resourceCategoryRead(ctx context.Context, d *schema.ResourceData, m any) di
 	if category.Assets != nil {
 		_ = d.Set("assets", flattenCategoryAssets(category.Assets))
 	}
-	_ = d.Set("custom", flattenCustomFields(category.Custom))
+	_ = d.Set("custom", flattenCustomFields(category.Custom, d.Get("custom").([]any)))
 	return nil
 }
 
@@ -399,7 +413,7 @@ func resourceCategoryUpdate(ctx context.Context, d *schema.ResourceData, m any)
 	}
 
 	if d.HasChange("custom") {
-		actions, err := CustomFieldUpdateActions[platform.CategorySetCustomTypeAction, platform.CategorySetCustomFieldAction](ctx, client, d)
+		actions, err := CustomFieldUpdateActions[platform.CategorySetCustomTypeAction, platform.CategorySetCustomFieldAction](ctx, m, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -6,13 +6,14 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 func resourceChannel() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection("channel", &schema.Resource{
 		Description: "Channels represent a source or destination of different entities. They can be used to model " +
 			"warehouses or stores.\n\n" +
 			"See also the [Channels API Documentation](https://docs.commercetools.com/api/projects/channels)",
@@ -23,6 +24,10 @@ func resourceChannel() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateCustomFields,
+			validateLocalizedStrings("name", "description"),
+		),
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Description: "Any arbitrary string key that uniquely identifies this channel within the project",
@@ -66,12 +71,18 @@ func resourceChannel() *schema.Resource {
 					},
 				},
 			},
+			"deletion_protection": deletionProtectionSchema(),
+			"force_destroy": forceDestroySchema(
+				"the distribution and supply channels of stores",
+				"Other references, like the channel of inventory entries, prices "+
+					"and carts, are not removed and make the delete fail",
+			),
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -85,7 +96,7 @@ func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m any) d
 
 	client := getClient(m)
 
-	custom, err := CreateCustomFieldDraft(ctx, client, d)
+	custom, err := CreateCustomFieldDraft(ctx, m, d)
 	if err != nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -144,7 +155,7 @@ func resourceChannelRead(ctx context.Context, d *schema.ResourceData, m any) dia
 	_ = d.Set("roles", channel.Roles)
 	_ = d.Set("address", flattenAddress(channel.Address))
 	_ = d.Set("geolocation", flattenGeoLocation(channel.GeoLocation))
-	_ = d.Set("custom", flattenCustomFields(channel.Custom))
+	_ = d.Set("custom", flattenCustomFields(channel.Custom, d.Get("custom").([]any)))
 	return nil
 }
 
@@ -202,7 +213,7 @@ func resourceChannelUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 	}
 
 	if d.HasChange("custom") {
-		actions, err := CustomFieldUpdateActions[platform.ChannelSetCustomTypeAction, platform.ChannelSetCustomFieldAction](ctx, client, d)
+		actions, err := CustomFieldUpdateActions[platform.ChannelSetCustomTypeAction, platform.ChannelSetCustomFieldAction](ctx, m, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -227,16 +238,29 @@ func resourceChannelUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 
 func resourceChannelDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
+
+	if diags := checkDeletionProtection(d, "channel"); diags.HasError() {
+		return diags
+	}
+
+	var diags diag.Diagnostics
+	if d.Get("force_destroy").(bool) {
+		diags = detachChannelFromStores(ctx, client, d.Id())
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	version := d.Get("version").(int)
 	err := retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
 		_, err := client.Channels().WithId(d.Id()).Delete().Version(version).Execute(ctx)
 		return utils.ProcessRemoteError(err)
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return nil
+	return diags
 }
 
 func flattenGeoLocation(loc platform.GeoJson) []map[string]any {
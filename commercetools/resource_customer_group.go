@@ -12,7 +12,7 @@ import (
 )
 
 func resourceCustomerGroup() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection("customer group", &schema.Resource{
 		Description: "A Customer can be a member of a customer group (for example reseller, gold member). " +
 			"Special prices can be assigned to specific products based on a customer group.\n\n" +
 			"See also the [Customer Group API Documentation](https://docs.commercetools.com/api/projects/customerGroups)",
@@ -23,12 +23,19 @@ func resourceCustomerGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: validateCustomFields,
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Description: "User-specific unique identifier for the customer group",
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
+			"force_destroy": forceDestroySchema(
+				"the customer group of customers",
+				"Other references, like the customer group assignments of customers, prices and "+
+					"cart discount predicates, are not removed and make the delete fail",
+			),
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -40,13 +47,13 @@ func resourceCustomerGroup() *schema.Resource {
 			},
 			"custom": CustomFieldSchema(),
 		},
-	}
+	})
 }
 
 func resourceCustomerGroupCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
 
-	custom, err := CreateCustomFieldDraft(ctx, client, d)
+	custom, err := CreateCustomFieldDraft(ctx, m, d)
 	if err != nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -102,7 +109,7 @@ func resourceCustomerGroupRead(ctx context.Context, d *schema.ResourceData, m an
 		_ = d.Set("version", customerGroup.Version)
 		_ = d.Set("name", customerGroup.Name)
 		_ = d.Set("key", customerGroup.Key)
-		_ = d.Set("custom", flattenCustomFields(customerGroup.Custom))
+		_ = d.Set("custom", flattenCustomFields(customerGroup.Custom, d.Get("custom").([]any)))
 	}
 
 	return nil
@@ -131,7 +138,7 @@ func resourceCustomerGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("custom") {
-		actions, err := CustomFieldUpdateActions[platform.CustomerGroupSetCustomTypeAction, platform.CustomerGroupSetCustomFieldAction](ctx, client, d)
+		actions, err := CustomFieldUpdateActions[platform.CustomerGroupSetCustomTypeAction, platform.CustomerGroupSetCustomFieldAction](ctx, m, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -156,10 +163,23 @@ func resourceCustomerGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceCustomerGroupDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
+
+	if diags := checkDeletionProtection(d, "customer group"); diags.HasError() {
+		return diags
+	}
+
+	var diags diag.Diagnostics
+	if d.Get("force_destroy").(bool) {
+		diags = detachCustomerGroupFromCustomers(ctx, client, d.Id())
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	version := d.Get("version").(int)
 	err := retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
 		_, err := client.CustomerGroups().WithId(d.Id()).Delete().Version(version).Execute(ctx)
 		return utils.ProcessRemoteError(err)
 	})
-	return diag.FromErr(err)
+	return append(diags, diag.FromErr(err)...)
 }
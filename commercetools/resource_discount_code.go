@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
 
@@ -26,6 +27,10 @@ func resourceDiscountCode() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateCustomFields,
+			validateLocalizedStrings("name", "description"),
+		),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description:      "[LocalizedString](https://docs.commercetools.com/api/types#localizedstring)",
@@ -110,7 +115,7 @@ func resourceDiscountCodeCreate(ctx context.Context, d *schema.ResourceData, m a
 	name := expandLocalizedString(d.Get("name"))
 	description := expandLocalizedString(d.Get("description"))
 
-	custom, err := CreateCustomFieldDraft(ctx, client, d)
+	custom, err := CreateCustomFieldDraft(ctx, m, d)
 	if err != nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -190,7 +195,7 @@ func resourceDiscountCodeRead(ctx context.Context, d *schema.ResourceData, m any
 	_ = d.Set("valid_until", flattenTime(discountCode.ValidUntil))
 	_ = d.Set("max_applications_per_customer", discountCode.MaxApplicationsPerCustomer)
 	_ = d.Set("max_applications", discountCode.MaxApplications)
-	_ = d.Set("custom", flattenCustomFields(discountCode.Custom))
+	_ = d.Set("custom", flattenCustomFields(discountCode.Custom, d.Get("custom").([]any)))
 	return nil
 }
 
@@ -299,7 +304,7 @@ func resourceDiscountCodeUpdate(ctx context.Context, d *schema.ResourceData, m a
 	}
 
 	if d.HasChange("custom") {
-		actions, err := CustomFieldUpdateActions[platform.DiscountCodeSetCustomTypeAction, platform.DiscountCodeSetCustomFieldAction](ctx, client, d)
+		actions, err := CustomFieldUpdateActions[platform.DiscountCodeSetCustomTypeAction, platform.DiscountCodeSetCustomFieldAction](ctx, m, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
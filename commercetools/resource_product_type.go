@@ -27,7 +27,7 @@ var constraintMap = map[string]platform.AttributeConstraintEnum{
 }
 
 func resourceProductType() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection("product type", &schema.Resource{
 		Description: "Product types are used to describe common characteristics, most importantly common custom " +
 			"attributes, of many concrete products. Please note: to customize other resources than products, " +
 			"please refer to resource_type.\n\n" +
@@ -148,18 +148,51 @@ func resourceProductType() *schema.Resource {
 							Optional:    true,
 							Default:     false,
 						},
+						"previous_name": {
+							Description: "The name of the attribute before it was renamed. When an attribute with " +
+								"this name exists it is renamed using the changeAttributeName update action, " +
+								"keeping the values on existing products, instead of being removed and re-added",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"replace_on_type_change": {
+							Description: "Replace the attribute when its type or the element type of a set " +
+								"changes. The attribute with the new type is added under a temporary name, the " +
+								"old attribute is removed and the new attribute is renamed, all in a single " +
+								"update. **All values of this attribute on existing products are lost**. " +
+								"commercetools requires attributes with the same name to have the same type in " +
+								"all product types, so the plan fails when another product type defines the " +
+								"attribute with a different type",
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
 					},
 				},
 			},
+			"deletion_protection": deletionProtectionSchema(),
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"migration_warnings": migrationWarningsSchema(),
+			"check_data_loss":    checkDataLossSchema(),
+			"fail_on_data_loss":  failOnDataLossSchema(),
 		},
-		CustomizeDiff: customdiff.ValidateChange("attribute", func(ctx context.Context, old, new, meta any) error {
-			return resourceProductTypeValidateAttribute(old.([]any), new.([]any))
-		}),
-	}
+		CustomizeDiff: customdiff.All(
+			customdiff.ValidateChange("attribute", func(ctx context.Context, old, new, meta any) error {
+				return resourceProductTypeValidateAttribute(old.([]any), new.([]any))
+			}),
+			resourceProductTypeMigrationWarnings,
+			validateProductTypeAttributeTypeConflicts,
+			validateLocalizedStrings(
+				"attribute.*.label",
+				"attribute.*.input_tip",
+				"attribute.*.type.*.localized_value.*.label",
+				"attribute.*.type.*.element_type.*.localized_value.*.label",
+			),
+		),
+	})
 }
 
 func attributeTypeElement(setsAllowed bool) *schema.Resource {
@@ -168,7 +201,7 @@ func attributeTypeElement(setsAllowed bool) *schema.Resource {
 			Type: schema.TypeString,
 			Description: "Name of the field type. Some types require extra " +
 				"fields to be set. Note that changing the type after creating is " +
-				"not supported. You need to delete the attribute and re-add it",
+				"only supported when `replace_on_type_change` is set on the attribute",
 			Required: true,
 			ValidateFunc: func(val any, key string) (warns []string, errs []error) {
 				v := val.(string)
@@ -323,12 +356,32 @@ func resourceProductTypeRead(ctx context.Context, d *schema.ResourceData, m any)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		mergeProductTypeAttributeSettings(attrs, d.Get("attribute").([]any))
 
 		_ = d.Set("attribute", attrs)
+		_ = d.Set("migration_warnings", []string{})
 	}
 	return nil
 }
 
+// mergeProductTypeAttributeSettings copies the attribute settings which only
+// exist in the terraform configuration (and not in commercetools) from the
+// current attributes to the flattened attributes.
+func mergeProductTypeAttributeSettings(attrs []map[string]any, current []any) {
+	lookup := createLookup(current, "name")
+	for _, attr := range attrs {
+		existing, ok := lookup[attr["name"].(string)].(map[string]any)
+		if !ok {
+			continue
+		}
+		attr["previous_name"] = existing["previous_name"]
+		attr["replace_on_type_change"] = existing["replace_on_type_change"]
+		if attrType, ok := attr["type"].([]any); ok {
+			mergeEnumKeyHints(attrType, existing["type"].([]any), false)
+		}
+	}
+}
+
 func flattenProductTypeAttributeType(attrType platform.AttributeType, setsAllowed bool) ([]any, error) {
 	result := make(map[string]any)
 
@@ -440,6 +493,11 @@ func resourceProductTypeUpdate(ctx context.Context, d *schema.ResourceData, m an
 
 func resourceProductTypeDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
+
+	if diags := checkDeletionProtection(d, "product type"); diags.HasError() {
+		return diags
+	}
+
 	version := d.Get("version").(int)
 	err := retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
 		_, err := client.ProductTypes().WithId(d.Id()).Delete().Version(version).Execute(ctx)
@@ -450,11 +508,20 @@ func resourceProductTypeDelete(ctx context.Context, d *schema.ResourceData, m an
 
 func resourceProductTypeValidateAttribute(old, new []any) error {
 	oldLookup := createLookup(old, "name")
+	renames := productTypeAttributeRenames(old, new)
 
 	for _, attribute := range new {
 		newF := attribute.(map[string]any)
 		name := newF["name"].(string)
-		oldF, ok := oldLookup[name].(map[string]any)
+		if err := validateEnumKeyHints(name, newF["type"].([]any)); err != nil {
+			return err
+		}
+
+		oldName := name
+		if previousName, ok := renames[name]; ok {
+			oldName = previousName
+		}
+		oldF, ok := oldLookup[oldName].(map[string]any)
 		if !ok {
 			continue
 		}
@@ -464,16 +531,18 @@ func resourceProductTypeValidateAttribute(old, new []any) error {
 
 		oldTypeName := oldType["name"].(string)
 		newTypeName := newType["name"].(string)
+		replaceOnTypeChange, _ := newF["replace_on_type_change"].(bool)
 
 		if oldTypeName != newTypeName {
-			if oldTypeName == "" || newTypeName == "" {
+			if oldTypeName == "" || newTypeName == "" || replaceOnTypeChange {
 				continue
 			}
 
 			return fmt.Errorf(
 				"attribute '%s' type changed from %s to %s."+
 					" Changing types is not supported;"+
-					" please remove the attribute first and re-define it later",
+					" please remove the attribute first and re-define it later"+
+					" or set replace_on_type_change to replace the attribute",
 				name, oldTypeName, newTypeName)
 		}
 
@@ -483,11 +552,12 @@ func resourceProductTypeValidateAttribute(old, new []any) error {
 			oldElementName := oldElement["name"].(string)
 			newElementName := newElement["name"].(string)
 
-			if oldElementName != newElementName {
+			if oldElementName != newElementName && !replaceOnTypeChange {
 				return fmt.Errorf(
 					"attribute '%s' element type changed from %s to %s."+
 						" Changing element types is not supported;"+
-						" please remove the attribute first and re-define it later",
+						" please remove the attribute first and re-define it later"+
+						" or set replace_on_type_change to replace the attribute",
 					name, oldElementName, newElementName)
 			}
 		}
@@ -503,6 +573,230 @@ func resourceProductTypeValidateAttribute(old, new []any) error {
 	return nil
 }
 
+// resourceProductTypeMigrationWarnings adds a warning to the plan for every
+// attribute which is replaced because its type changes, since this removes
+// the values of the attribute from all existing products.
+func resourceProductTypeMigrationWarnings(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	old, new := d.GetChange("attribute")
+	warnings := productTypeAttributeMigrationWarnings(old.([]any), new.([]any))
+
+	removedAttributes, removedValues := productTypeAttributeRemovals(old.([]any), new.([]any))
+	dataLoss, err := checkDataLoss(ctx, d, m, productDataLossQueries(d.Id(), removedAttributes, removedValues))
+	if err != nil {
+		return err
+	}
+	return setMigrationWarnings(d, append(warnings, dataLoss...))
+}
+
+// productTypeAttributeRemovals returns the attributes and the enum values
+// (by attribute name) which are removed from the product type, including
+// attributes which are replaced because their type changes.
+func productTypeAttributeRemovals(old, new []any) ([]string, map[string][]string) {
+	newLookup := createLookup(new, "name")
+	renames := productTypeAttributeRenames(old, new)
+	renamed := map[string]string{}
+	for name, previousName := range renames {
+		renamed[previousName] = name
+	}
+
+	var removedAttributes []string
+	removedValues := map[string][]string{}
+	for _, attribute := range old {
+		oldF := attribute.(map[string]any)
+		oldName := oldF["name"].(string)
+		name := oldName
+		if newName, ok := renamed[oldName]; ok {
+			name = newName
+		}
+
+		newF, ok := newLookup[name].(map[string]any)
+		if !ok {
+			removedAttributes = append(removedAttributes, oldName)
+			continue
+		}
+
+		replace, _ := newF["replace_on_type_change"].(bool)
+		if productTypeAttributeTypeName(oldF) != productTypeAttributeTypeName(newF) {
+			if replace {
+				removedAttributes = append(removedAttributes, oldName)
+			}
+			continue
+		}
+
+		oldKeys := enumKeys(oldF["type"].([]any))
+		newKeys := enumKeys(newF["type"].([]any))
+		keyRenames := enumKeyRenames(oldKeys, newKeys, enumKeyHints(newF["type"].([]any)))
+		for _, key := range oldKeys {
+			if _, ok := keyRenames[key]; ok || slices.Contains(newKeys, key) {
+				continue
+			}
+			removedValues[oldName] = append(removedValues[oldName], key)
+		}
+	}
+	return removedAttributes, removedValues
+}
+
+func productTypeAttributeMigrationWarnings(old, new []any) []string {
+	var warnings []string
+	for _, change := range productTypeAttributeTypeChanges(old, new) {
+		warnings = append(warnings, fmt.Sprintf(
+			"attribute '%s' is replaced because its type changes from %s to %s; "+
+				"the values of this attribute on all existing products will be lost",
+			change.name, change.oldType, change.newType))
+	}
+	return warnings
+}
+
+// productTypeAttributeTypeChange describes an attribute which is replaced
+// because its type changes
+type productTypeAttributeTypeChange struct {
+	name    string
+	oldType string
+	newType string
+}
+
+// productTypeAttributeTypeChanges returns the attributes which are replaced
+// because their type changes and replace_on_type_change is set
+func productTypeAttributeTypeChanges(old, new []any) []productTypeAttributeTypeChange {
+	oldLookup := createLookup(old, "name")
+	renames := productTypeAttributeRenames(old, new)
+
+	var changes []productTypeAttributeTypeChange
+	for _, attribute := range new {
+		newF := attribute.(map[string]any)
+		name := newF["name"].(string)
+		oldName := name
+		if previousName, ok := renames[name]; ok {
+			oldName = previousName
+		}
+		oldF, ok := oldLookup[oldName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if replace, _ := newF["replace_on_type_change"].(bool); !replace {
+			continue
+		}
+
+		oldType := productTypeAttributeTypeName(oldF)
+		newType := productTypeAttributeTypeName(newF)
+		if oldType == newType {
+			continue
+		}
+		changes = append(changes, productTypeAttributeTypeChange{name: name, oldType: oldType, newType: newType})
+	}
+	return changes
+}
+
+// validateProductTypeAttributeTypeConflicts returns an error when an attribute
+// is replaced with a new type while another product type defines an attribute
+// with the same name and a different type. commercetools requires attributes
+// with the same name to have the same type in all product types, so the update
+// would fail with an AttributeDefinitionTypeConflict error.
+func validateProductTypeAttributeTypeConflicts(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if d.Id() == "" || !d.NewValueKnown("attribute") {
+		return nil
+	}
+	old, new := d.GetChange("attribute")
+	changes := productTypeAttributeTypeChanges(old.([]any), new.([]any))
+	if len(changes) == 0 {
+		return nil
+	}
+
+	client := getClient(m)
+	for _, change := range changes {
+		result, err := client.ProductTypes().Get().Where([]string{
+			fmt.Sprintf("attributes(name = %q)", change.name),
+			fmt.Sprintf("id != %q", d.Id()),
+		}).Limit(500).Execute(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, productType := range result.Results {
+			for _, attribute := range productType.Attributes {
+				if attribute.Name != change.name {
+					continue
+				}
+				attrType, err := flattenProductTypeAttributeType(attribute.Type, true)
+				if err != nil {
+					return err
+				}
+				typeName := productTypeAttributeTypeName(map[string]any{"type": attrType})
+				if typeName == change.newType {
+					continue
+				}
+				return fmt.Errorf(
+					"attribute '%s' can't be replaced with type %s, since product type %s also defines "+
+						"'%s' with type %s. commercetools requires attributes with the same name to have "+
+						"the same type in all product types; use a new name for the attribute instead",
+					change.name, change.newType, productTypeIdentifier(productType), change.name, typeName)
+			}
+		}
+	}
+	return nil
+}
+
+// productTypeIdentifier returns the key of the product type, or its name when
+// it has no key
+func productTypeIdentifier(productType platform.ProductType) string {
+	if productType.Key != nil && *productType.Key != "" {
+		return *productType.Key
+	}
+	return productType.Name
+}
+
+// productTypeAttributeTypeName returns a readable representation of the type
+// of the raw attribute, e.g. `text` or `set of enum`
+func productTypeAttributeTypeName(attribute map[string]any) string {
+	attrType := firstElementFromSlice(attribute["type"].([]any))
+	if attrType == nil {
+		return ""
+	}
+	name, _ := attrType["name"].(string)
+	if strings.EqualFold(name, "set") {
+		if element := elementFromSlice(attrType, "element_type"); element != nil {
+			return fmt.Sprintf("%s of %s", name, element["name"])
+		}
+	}
+	return name
+}
+
+// productTypeAttributeRenames returns the attributes which are renamed, as a
+// map from the new name to the previous name. An attribute is only renamed
+// when the previous name exists and is not used anymore and the new name
+// doesn't exist yet.
+func productTypeAttributeRenames(old, new []any) map[string]string {
+	oldLookup := createLookup(old, "name")
+	newLookup := createLookup(new, "name")
+
+	renames := map[string]string{}
+	claimed := map[string]bool{}
+	for _, attribute := range new {
+		newF := attribute.(map[string]any)
+		name := newF["name"].(string)
+		previousName, _ := newF["previous_name"].(string)
+		if previousName == "" || previousName == name || claimed[previousName] {
+			continue
+		}
+		if _, ok := oldLookup[name]; ok {
+			continue
+		}
+		if _, ok := oldLookup[previousName]; !ok {
+			continue
+		}
+		if _, ok := newLookup[previousName]; ok {
+			continue
+		}
+		renames[name] = previousName
+		claimed[previousName] = true
+	}
+	return renames
+}
+
 func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any) ([]platform.ProductTypeUpdateAction, error) {
 	oldAttrs, err := mapAttributeDefinition(oldValues)
 	if err != nil {
@@ -514,6 +808,25 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 		return nil, err
 	}
 
+	renames := productTypeAttributeRenames(oldValues, newValues)
+	renamed := map[string]bool{}
+	for _, previousName := range renames {
+		renamed[previousName] = true
+	}
+
+	replaceOnTypeChange := map[string]bool{}
+	enumHints := map[string]map[string]string{}
+	for _, raw := range newValues {
+		attribute := raw.(map[string]any)
+		name := attribute["name"].(string)
+		if replace, ok := attribute["replace_on_type_change"].(bool); ok && replace {
+			replaceOnTypeChange[name] = true
+		}
+		if attrType, ok := attribute["type"].([]any); ok {
+			enumHints[name] = enumKeyHints(attrType)
+		}
+	}
+
 	// Create a copy of the attribute order for commercetools. When we
 	// delete attributes commercetools already re-orders the attributes, and we need
 	// to not send a reorder command when the order already matches
@@ -525,6 +838,9 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 	// Check if we have attributes which are removed and generate the corresponding
 	// remove attribute actions
 	for _, name := range oldAttrs.Keys() {
+		if renamed[name] {
+			continue
+		}
 		if _, ok := newAttrs.Get(name); !ok {
 			actions = append(actions, platform.ProductTypeRemoveAttributeDefinitionAction{Name: name})
 			attrOrder = removeValueFromSlice(attrOrder, name)
@@ -533,7 +849,11 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 
 	for _, name := range newAttrs.Keys() {
 		newAttr, _ := newAttrs.Get(name)
-		oldAttr, isExisting := oldAttrs.Get(name)
+		oldName := name
+		if previousName, ok := renames[name]; ok {
+			oldName = previousName
+		}
+		oldAttr, isExisting := oldAttrs.Get(oldName)
 
 		// A new attribute is added. Create the update action skip the rest of the
 		// loop since there cannot be any change if the attribute didn't exist yet.
@@ -541,25 +861,46 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 			actions = append(
 				actions,
 				platform.ProductTypeAddAttributeDefinitionAction{
-					Attribute: platform.AttributeDefinitionDraft{
-						Type:                newAttr.Type,
-						Name:                newAttr.Name,
-						Label:               newAttr.Label,
-						Level:               ref(newAttr.Level),
-						IsRequired:          newAttr.IsRequired,
-						AttributeConstraint: &newAttr.AttributeConstraint,
-						InputTip:            newAttr.InputTip,
-						InputHint:           &newAttr.InputHint,
-						IsSearchable:        &newAttr.IsSearchable,
-					},
+					Attribute: attributeDefinitionDraft(newAttr, newAttr.Name),
 				})
 			attrOrder = append(attrOrder, newAttr.Name)
 			continue
 		}
 
-		// This should not be able to happen due to checks earlier
-		if reflect.TypeOf(oldAttr.Type) != reflect.TypeOf(newAttr.Type) {
-			return nil, fmt.Errorf("changing attribute types is not supported in commercetools")
+		if productTypeAttributeTypeChanged(oldAttr.Type, newAttr.Type) {
+			// This should not be able to happen due to checks earlier
+			if !replaceOnTypeChange[name] {
+				return nil, fmt.Errorf("changing attribute types is not supported in commercetools")
+			}
+
+			// Replace the attribute by adding the new definition under a
+			// temporary name, removing the old attribute and renaming the
+			// new attribute. The actions are applied in a single update, so
+			// the attribute is never missing from the product type.
+			tmpName := productTypeAttributeTemporaryName(name)
+			actions = append(
+				actions,
+				platform.ProductTypeAddAttributeDefinitionAction{
+					Attribute: attributeDefinitionDraft(newAttr, tmpName),
+				},
+				platform.ProductTypeRemoveAttributeDefinitionAction{Name: oldName},
+				platform.ProductTypeChangeAttributeNameAction{
+					AttributeName:    tmpName,
+					NewAttributeName: name,
+				})
+			attrOrder = removeValueFromSlice(attrOrder, oldName)
+			attrOrder = append(attrOrder, name)
+			continue
+		}
+
+		if oldName != name {
+			actions = append(
+				actions,
+				platform.ProductTypeChangeAttributeNameAction{
+					AttributeName:    oldName,
+					NewAttributeName: name,
+				})
+			attrOrder[slices.Index(attrOrder, oldName)] = name
 		}
 
 		// Check if we need to update the attribute label
@@ -572,14 +913,6 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 				})
 		}
 
-		if !reflect.DeepEqual(oldAttr.Name, newAttr.Name) {
-			actions = append(
-				actions,
-				platform.ProductTypeChangeNameAction{
-					Name: newAttr.Name,
-				})
-		}
-
 		if !reflect.DeepEqual(oldAttr.IsSearchable, newAttr.IsSearchable) {
 			actions = append(
 				actions,
@@ -626,7 +959,7 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 
 		case platform.AttributeLocalizedEnumType:
 			ot := oldAttr.Type.(platform.AttributeLocalizedEnumType)
-			subActions, err := updateAttributeLocalizedEnumType(name, ot, t)
+			subActions, err := updateAttributeLocalizedEnumType(name, ot, t, enumHints[name])
 			if err != nil {
 				return nil, err
 			}
@@ -634,7 +967,7 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 
 		case platform.AttributeEnumType:
 			ot := oldAttr.Type.(platform.AttributeEnumType)
-			subActions, err := updateAttributeEnumType(name, ot, t)
+			subActions, err := updateAttributeEnumType(name, ot, t, enumHints[name])
 			if err != nil {
 				return nil, err
 			}
@@ -643,16 +976,11 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 		case platform.AttributeSetType:
 			ot := oldAttr.Type.(platform.AttributeSetType)
 
-			// This should not be able to happen due to checks earlier
-			if reflect.TypeOf(ot.ElementType) != reflect.TypeOf(t.ElementType) {
-				return nil, fmt.Errorf("changing attribute types is not supported in commercetools")
-			}
-
 			switch st := t.ElementType.(type) {
 
 			case platform.AttributeEnumType:
 				ost := ot.ElementType.(platform.AttributeEnumType)
-				subActions, err := updateAttributeEnumType(name, ost, st)
+				subActions, err := updateAttributeEnumType(name, ost, st, enumHints[name])
 				if err != nil {
 					return nil, err
 				}
@@ -660,7 +988,7 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 
 			case platform.AttributeLocalizedEnumType:
 				ost := ot.ElementType.(platform.AttributeLocalizedEnumType)
-				subActions, err := updateAttributeLocalizedEnumType(name, ost, st)
+				subActions, err := updateAttributeLocalizedEnumType(name, ost, st, enumHints[name])
 				if err != nil {
 					return nil, err
 				}
@@ -687,22 +1015,78 @@ func resourceProductTypeAttributeChangeActions(oldValues []any, newValues []any)
 	return actions, nil
 }
 
-func updateAttributeEnumType(attrName string, old, new platform.AttributeEnumType) ([]platform.ProductTypeUpdateAction, error) {
-	oldValues := orderedmap.NewOrderedMap[string, platform.AttributePlainEnumValue]()
-	for i := range old.Values {
-		oldValues.Set(old.Values[i].Key, old.Values[i])
+// productTypeAttributeTypeChanged returns true when the attribute type or the
+// element type of a set attribute is changed.
+func productTypeAttributeTypeChanged(old, new platform.AttributeType) bool {
+	if reflect.TypeOf(old) != reflect.TypeOf(new) {
+		return true
+	}
+	if oldSet, ok := old.(platform.AttributeSetType); ok {
+		newSet := new.(platform.AttributeSetType)
+		return reflect.TypeOf(oldSet.ElementType) != reflect.TypeOf(newSet.ElementType)
+	}
+	return false
+}
+
+// productTypeAttributeTemporaryName returns the name used for an attribute
+// while it is being replaced. Attribute names are limited to 256 characters.
+func productTypeAttributeTemporaryName(name string) string {
+	tmpName := "tmp-" + name
+	if len(tmpName) > 256 {
+		tmpName = tmpName[:256]
+	}
+	return tmpName
+}
+
+func attributeDefinitionDraft(attr platform.AttributeDefinition, name string) platform.AttributeDefinitionDraft {
+	return platform.AttributeDefinitionDraft{
+		Type:                attr.Type,
+		Name:                name,
+		Label:               attr.Label,
+		Level:               ref(attr.Level),
+		IsRequired:          attr.IsRequired,
+		AttributeConstraint: &attr.AttributeConstraint,
+		InputTip:            attr.InputTip,
+		InputHint:           &attr.InputHint,
+		IsSearchable:        &attr.IsSearchable,
 	}
+}
 
+func updateAttributeEnumType(attrName string, old, new platform.AttributeEnumType, hints map[string]string) ([]platform.ProductTypeUpdateAction, error) {
 	newValues := orderedmap.NewOrderedMap[string, platform.AttributePlainEnumValue]()
 	for i := range new.Values {
 		newValues.Set(new.Values[i].Key, new.Values[i])
 	}
 
-	var valueOrder []string
-	valueOrder = append(valueOrder, oldValues.Keys()...)
+	oldKeys := make([]string, len(old.Values))
+	for i := range old.Values {
+		oldKeys[i] = old.Values[i].Key
+	}
+	renames := enumKeyRenames(oldKeys, newValues.Keys(), hints)
 
 	var actions []platform.ProductTypeUpdateAction
 
+	// Rename the keys of values for which a previous key is given. This keeps
+	// the value on existing products instead of removing it.
+	oldValues := orderedmap.NewOrderedMap[string, platform.AttributePlainEnumValue]()
+	for i := range old.Values {
+		value := old.Values[i]
+		if newKey, ok := renames[value.Key]; ok {
+			actions = append(
+				actions,
+				platform.ProductTypeChangeEnumKeyAction{
+					AttributeName: attrName,
+					Key:           value.Key,
+					NewKey:        newKey,
+				})
+			value.Key = newKey
+		}
+		oldValues.Set(value.Key, value)
+	}
+
+	var valueOrder []string
+	valueOrder = append(valueOrder, oldValues.Keys()...)
+
 	// Delete enum values
 	var removeKeys []string
 	for _, key := range oldValues.Keys() {
@@ -768,22 +1152,41 @@ func updateAttributeEnumType(attrName string, old, new platform.AttributeEnumTyp
 	return actions, nil
 }
 
-func updateAttributeLocalizedEnumType(attrName string, old, new platform.AttributeLocalizedEnumType) ([]platform.ProductTypeUpdateAction, error) {
-	oldValues := orderedmap.NewOrderedMap[string, platform.AttributeLocalizedEnumValue]()
-	for i := range old.Values {
-		oldValues.Set(old.Values[i].Key, old.Values[i])
-	}
-
+func updateAttributeLocalizedEnumType(attrName string, old, new platform.AttributeLocalizedEnumType, hints map[string]string) ([]platform.ProductTypeUpdateAction, error) {
 	newValues := orderedmap.NewOrderedMap[string, platform.AttributeLocalizedEnumValue]()
 	for i := range new.Values {
 		newValues.Set(new.Values[i].Key, new.Values[i])
 	}
 
-	var valueOrder []string
-	valueOrder = append(valueOrder, oldValues.Keys()...)
+	oldKeys := make([]string, len(old.Values))
+	for i := range old.Values {
+		oldKeys[i] = old.Values[i].Key
+	}
+	renames := enumKeyRenames(oldKeys, newValues.Keys(), hints)
 
 	var actions []platform.ProductTypeUpdateAction
 
+	// Rename the keys of values for which a previous key is given. This keeps
+	// the value on existing products instead of removing it.
+	oldValues := orderedmap.NewOrderedMap[string, platform.AttributeLocalizedEnumValue]()
+	for i := range old.Values {
+		value := old.Values[i]
+		if newKey, ok := renames[value.Key]; ok {
+			actions = append(
+				actions,
+				platform.ProductTypeChangeEnumKeyAction{
+					AttributeName: attrName,
+					Key:           value.Key,
+					NewKey:        newKey,
+				})
+			value.Key = newKey
+		}
+		oldValues.Set(value.Key, value)
+	}
+
+	var valueOrder []string
+	valueOrder = append(valueOrder, oldValues.Keys()...)
+
 	// Delete enum values
 	var removeKeys []string
 	for _, key := range oldValues.Keys() {
@@ -2,7 +2,10 @@ package commercetools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"testing"
 
@@ -11,6 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 type TestProductTypeAttrData struct {
@@ -91,6 +97,376 @@ func TestResourceProductTypeValidateAttributeSet(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestResourceProductTypeValidateAttributeReplaceOnTypeChange(t *testing.T) {
+	o := []any{testProductTypeAttribute("attr-one", "text")}
+
+	n := testProductTypeAttribute("attr-one", "boolean")
+	err := resourceProductTypeValidateAttribute(o, []any{n})
+	assert.ErrorContains(t, err, "set replace_on_type_change to replace the attribute")
+
+	n["replace_on_type_change"] = true
+	err = resourceProductTypeValidateAttribute(o, []any{n})
+	assert.NoError(t, err)
+}
+
+func TestResourceProductTypeValidateAttributeRenamed(t *testing.T) {
+	o := []any{testProductTypeAttribute("attr-one", "text")}
+
+	n := testProductTypeAttribute("attr-two", "boolean")
+	n["previous_name"] = "attr-one"
+	err := resourceProductTypeValidateAttribute(o, []any{n})
+	assert.ErrorContains(t, err, "attribute 'attr-two' type changed from text to boolean")
+}
+
+func TestProductTypeAttributeMigrationWarnings(t *testing.T) {
+	o := []any{
+		testProductTypeAttribute("attr-one", "text"),
+		testProductTypeAttribute("attr-two", "text"),
+	}
+
+	replaced := testProductTypeAttribute("attr-one", "number")
+	replaced["replace_on_type_change"] = true
+	unchanged := testProductTypeAttribute("attr-two", "text")
+	unchanged["replace_on_type_change"] = true
+
+	warnings := productTypeAttributeMigrationWarnings(o, []any{replaced, unchanged})
+	assert.Equal(t, []string{
+		"attribute 'attr-one' is replaced because its type changes from text to number; " +
+			"the values of this attribute on all existing products will be lost",
+	}, warnings)
+}
+
+func TestValidateProductTypeAttributeTypeConflicts(t *testing.T) {
+	otherKey := "other"
+	otherType := platform.AttributeType(platform.AttributeTextType{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test/product-types", r.URL.Path)
+		assert.Equal(t, []string{`attributes(name = "size")`, `id != "product-type"`}, r.URL.Query()["where"])
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(platform.ProductTypePagedQueryResponse{
+			Count: 1,
+			Results: []platform.ProductType{
+				{
+					ID:         "other-id",
+					Key:        &otherKey,
+					Name:       "Other",
+					Attributes: []platform.AttributeDefinition{{Name: "size", Type: otherType}},
+				},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := platform.NewClient(&platform.ClientConfig{URL: server.URL})
+	require.NoError(t, err)
+	meta := &utils.ProviderData{Client: client.WithProjectKey("test")}
+
+	state := &terraform.InstanceState{
+		ID: "product-type",
+		Attributes: map[string]string{
+			"id":                      "product-type",
+			"name":                    "Shoes",
+			"attribute.#":             "1",
+			"attribute.0.name":        "size",
+			"attribute.0.label.%":     "1",
+			"attribute.0.label.en":    "Size",
+			"attribute.0.type.#":      "1",
+			"attribute.0.type.0.name": "text",
+			"attribute.0.level":       string(platform.AttributeLevelEnumVariant),
+			"attribute.0.constraint":  string(platform.AttributeConstraintEnumNone),
+			"attribute.0.input_hint":  string(platform.TextInputHintSingleLine),
+			"attribute.0.required":    "false",
+			"attribute.0.searchable":  "false",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"name": "Shoes",
+		"attribute": []any{
+			map[string]any{
+				"name":                   "size",
+				"label":                  map[string]any{"en": "Size"},
+				"type":                   []any{map[string]any{"name": "number"}},
+				"replace_on_type_change": true,
+			},
+		},
+	})
+
+	_, err = resourceProductType().Diff(context.Background(), state, config, meta)
+	assert.EqualError(t, err, "attribute 'size' can't be replaced with type number, since product type other "+
+		"also defines 'size' with type text. commercetools requires attributes with the same name to have the "+
+		"same type in all product types; use a new name for the attribute instead")
+
+	// Other product types which already use the new type don't conflict
+	otherType = platform.AttributeNumberType{}
+	_, err = resourceProductType().Diff(context.Background(), state, config, meta)
+	assert.NoError(t, err)
+}
+
+func TestResourceProductTypeAttributeChangeActions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old      []any
+		new      []any
+		expected []platform.ProductTypeUpdateAction
+	}{
+		{
+			name: "rename attribute",
+			old: []any{
+				testProductTypeAttribute("attr-one", "text"),
+				testProductTypeAttribute("attr-two", "text"),
+			},
+			new: []any{
+				testProductTypeAttribute("attr-one", "text"),
+				func() map[string]any {
+					attr := testProductTypeAttribute("attr-renamed", "text")
+					attr["previous_name"] = "attr-two"
+					attr["label"] = map[string]any{"en": "Renamed"}
+					return attr
+				}(),
+			},
+			expected: []platform.ProductTypeUpdateAction{
+				platform.ProductTypeChangeAttributeNameAction{
+					AttributeName:    "attr-two",
+					NewAttributeName: "attr-renamed",
+				},
+				platform.ProductTypeChangeLabelAction{
+					AttributeName: "attr-renamed",
+					Label:         platform.LocalizedString{"en": "Renamed"},
+				},
+			},
+		},
+		{
+			name: "previous name already applied",
+			old: []any{
+				testProductTypeAttribute("attr-renamed", "text"),
+			},
+			new: []any{
+				func() map[string]any {
+					attr := testProductTypeAttribute("attr-renamed", "text")
+					attr["previous_name"] = "attr-two"
+					return attr
+				}(),
+			},
+			expected: nil,
+		},
+		{
+			name: "replace on type change",
+			old: []any{
+				testProductTypeAttribute("attr-one", "text"),
+				testProductTypeAttribute("attr-two", "text"),
+			},
+			new: []any{
+				func() map[string]any {
+					attr := testProductTypeAttribute("attr-one", "boolean")
+					attr["replace_on_type_change"] = true
+					return attr
+				}(),
+				testProductTypeAttribute("attr-two", "text"),
+			},
+			expected: []platform.ProductTypeUpdateAction{
+				platform.ProductTypeAddAttributeDefinitionAction{
+					Attribute: platform.AttributeDefinitionDraft{
+						Type:                platform.AttributeBooleanType{},
+						Name:                "tmp-attr-one",
+						Label:               platform.LocalizedString{"en": "Label"},
+						Level:               ref(platform.AttributeLevelEnumVariant),
+						AttributeConstraint: ref(platform.AttributeConstraintEnumNone),
+						InputTip:            ref(platform.LocalizedString(nil)),
+						InputHint:           ref(platform.TextInputHintSingleLine),
+						IsSearchable:        ref(false),
+					},
+				},
+				platform.ProductTypeRemoveAttributeDefinitionAction{Name: "attr-one"},
+				platform.ProductTypeChangeAttributeNameAction{
+					AttributeName:    "tmp-attr-one",
+					NewAttributeName: "attr-one",
+				},
+				platform.ProductTypeChangeAttributeOrderByNameAction{
+					AttributeNames: []string{"attr-one", "attr-two"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actions, err := resourceProductTypeAttributeChangeActions(tc.old, tc.new)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actions)
+		})
+	}
+}
+
+func TestResourceProductTypeAttributeChangeActionsTypeChange(t *testing.T) {
+	o := []any{testProductTypeAttribute("attr-one", "text")}
+	n := []any{testProductTypeAttribute("attr-one", "boolean")}
+
+	_, err := resourceProductTypeAttributeChangeActions(o, n)
+	assert.EqualError(t, err, "changing attribute types is not supported in commercetools")
+}
+
+func TestUpdateAttributeEnumTypeRenameKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old      []platform.AttributePlainEnumValue
+		new      []platform.AttributePlainEnumValue
+		hints    map[string]string
+		expected []platform.ProductTypeUpdateAction
+	}{
+		{
+			name: "rename key",
+			old: []platform.AttributePlainEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "value2", Label: "Value 2"},
+			},
+			new: []platform.AttributePlainEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "renamed", Label: "Value 2"},
+			},
+			hints: map[string]string{"renamed": "value2"},
+			expected: []platform.ProductTypeUpdateAction{
+				platform.ProductTypeChangeEnumKeyAction{
+					AttributeName: "test",
+					Key:           "value2",
+					NewKey:        "renamed",
+				},
+			},
+		},
+		{
+			name: "rename key and change label and order",
+			old: []platform.AttributePlainEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "value2", Label: "Value 2"},
+			},
+			new: []platform.AttributePlainEnumValue{
+				{Key: "renamed", Label: "Renamed"},
+				{Key: "value1", Label: "Value 1"},
+			},
+			hints: map[string]string{"renamed": "value2"},
+			expected: []platform.ProductTypeUpdateAction{
+				platform.ProductTypeChangeEnumKeyAction{
+					AttributeName: "test",
+					Key:           "value2",
+					NewKey:        "renamed",
+				},
+				platform.ProductTypeChangePlainEnumValueLabelAction{
+					AttributeName: "test",
+					NewValue:      platform.AttributePlainEnumValue{Key: "renamed", Label: "Renamed"},
+				},
+				platform.ProductTypeChangePlainEnumValueOrderAction{
+					AttributeName: "test",
+					Values: []platform.AttributePlainEnumValue{
+						{Key: "renamed", Label: "Renamed"},
+						{Key: "value1", Label: "Value 1"},
+					},
+				},
+			},
+		},
+		{
+			name: "rename already applied",
+			old: []platform.AttributePlainEnumValue{
+				{Key: "renamed", Label: "Value 2"},
+			},
+			new: []platform.AttributePlainEnumValue{
+				{Key: "renamed", Label: "Value 2"},
+			},
+			hints:    map[string]string{"renamed": "value2"},
+			expected: nil,
+		},
+		{
+			name: "without hint",
+			old: []platform.AttributePlainEnumValue{
+				{Key: "value2", Label: "Value 2"},
+			},
+			new: []platform.AttributePlainEnumValue{
+				{Key: "renamed", Label: "Value 2"},
+			},
+			expected: []platform.ProductTypeUpdateAction{
+				platform.ProductTypeRemoveEnumValuesAction{
+					AttributeName: "test",
+					Keys:          []string{"value2"},
+				},
+				platform.ProductTypeAddPlainEnumValueAction{
+					AttributeName: "test",
+					Value:         platform.AttributePlainEnumValue{Key: "renamed", Label: "Value 2"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actions, err := updateAttributeEnumType(
+				"test",
+				platform.AttributeEnumType{Values: tc.old},
+				platform.AttributeEnumType{Values: tc.new},
+				tc.hints,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actions)
+		})
+	}
+}
+
+func TestUpdateAttributeLocalizedEnumTypeRenameKey(t *testing.T) {
+	oldType := platform.AttributeLocalizedEnumType{
+		Values: []platform.AttributeLocalizedEnumValue{
+			{Key: "value1", Label: platform.LocalizedString{"en": "Value 1"}},
+		},
+	}
+	newType := platform.AttributeLocalizedEnumType{
+		Values: []platform.AttributeLocalizedEnumValue{
+			{Key: "renamed", Label: platform.LocalizedString{"en": "Value 1"}},
+		},
+	}
+
+	actions, err := updateAttributeLocalizedEnumType("test", oldType, newType, map[string]string{"renamed": "value1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []platform.ProductTypeUpdateAction{
+		platform.ProductTypeChangeEnumKeyAction{
+			AttributeName: "test",
+			Key:           "value1",
+			NewKey:        "renamed",
+		},
+	}, actions)
+}
+
+func TestMergeProductTypeAttributeSettings(t *testing.T) {
+	attrs := []map[string]any{
+		{"name": "attr-one"},
+		{"name": "attr-two"},
+	}
+	current := []any{
+		map[string]any{
+			"name":                   "attr-one",
+			"previous_name":          "attr-old",
+			"replace_on_type_change": true,
+		},
+	}
+
+	mergeProductTypeAttributeSettings(attrs, current)
+	assert.Equal(t, "attr-old", attrs[0]["previous_name"])
+	assert.Equal(t, true, attrs[0]["replace_on_type_change"])
+	assert.NotContains(t, attrs[1], "previous_name")
+}
+
+func testProductTypeAttribute(name, typeName string) map[string]any {
+	return map[string]any{
+		"name":       name,
+		"label":      map[string]any{"en": "Label"},
+		"level":      string(platform.AttributeLevelEnumVariant),
+		"required":   false,
+		"searchable": false,
+		"constraint": string(platform.AttributeConstraintEnumNone),
+		"input_hint": string(platform.TextInputHintSingleLine),
+		"type": []any{
+			map[string]any{
+				"name": typeName,
+			},
+		},
+	}
+}
+
 func TestAttributeTypeElement(t *testing.T) {
 	elem := attributeTypeElement(true)
 	elemType, ok := elem.Schema["element_type"]
@@ -2,11 +2,15 @@ package commercetools
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"slices"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
@@ -24,6 +28,18 @@ func resourceShippingMethod() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateCustomFields,
+			validateLocalizedStrings("localized_name", "localized_description"),
+			validateShippingMethodZoneRates,
+			validateCurrencies(
+				"zone_rate.*.shipping_rate.*.price.*.currency_code",
+				"zone_rate.*.shipping_rate.*.free_above.*.currency_code",
+				"zone_rate.*.shipping_rate.*.shipping_rate_price_tier.*.price.*.currency_code",
+				"zone_rate.*.shipping_rate.*.shipping_rate_price_tier.*.price_function.*.currency_code",
+			),
+			validateShippingRateTiers("zone_rate.*.shipping_rate.*.shipping_rate_price_tier.*.type"),
+		),
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Description: "User-specific unique identifier for the shipping method",
@@ -76,6 +92,32 @@ func resourceShippingMethod() *schema.Resource {
 				Optional:    true,
 			},
 			"custom": CustomFieldSchema(),
+			"zone_rate": {
+				Description: "The shipping rates of a [zone](https://docs.commercetools.com/api/projects/shippingMethods#zonerate). " +
+					"When set, all zones and shipping rates of the shipping method are managed by this resource " +
+					"in a single update, and commercetools_shipping_zone_rate can't be used for the shipping " +
+					"method: shipping rates which are not in the zone_rate blocks are shown as a change in the " +
+					"plan and removed. Removing all zone_rate blocks removes all zones from the shipping method",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"shipping_zone_id": {
+							Description: "ID of the [Zone](https://docs.commercetools.com/api/projects/zones#zone)",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"shipping_rate": {
+							Description: "The shipping rates of the zone, one for every currency",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: shippingRateSchema(map[string]*schema.Schema{}),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -90,7 +132,7 @@ func resourceShippingMethodCreate(ctx context.Context, d *schema.ResourceData, m
 	localizedDescription := expandLocalizedString(d.Get("localized_description"))
 	localizedName := expandLocalizedString(d.Get("localized_name"))
 
-	custom, err := CreateCustomFieldDraft(ctx, client, d)
+	custom, err := CreateCustomFieldDraft(ctx, m, d)
 	if err != nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -109,10 +151,17 @@ func resourceShippingMethodCreate(ctx context.Context, d *schema.ResourceData, m
 		Predicate:            nilIfEmpty(stringRef(d.Get("predicate"))),
 		Custom:               custom,
 		// Set default to empty array, otherwise the API will return an error if no shipping rates are added to the shipping method.
-		// Actual shipping rates are added in the shipping zone resource, see resource_shipping_zone_rate.go
+		// Shipping rates are added with the zone_rate blocks or with the shipping zone rate resource, see resource_shipping_zone_rate.go
 		ZoneRates: []platform.ZoneRateDraft{},
 	}
 
+	if zoneRates, ok := d.GetOk("zone_rate"); ok {
+		draft.ZoneRates, err = expandShippingMethodZoneRates(zoneRates.([]any))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	key := stringRef(d.Get("key"))
 	if *key != "" {
 		draft.Key = key
@@ -159,7 +208,14 @@ func resourceShippingMethodRead(ctx context.Context, d *schema.ResourceData, m a
 		_ = d.Set("is_default", shippingMethod.IsDefault)
 		_ = d.Set("tax_category_id", shippingMethod.TaxCategory.ID)
 		_ = d.Set("predicate", shippingMethod.Predicate)
-		_ = d.Set("custom", flattenCustomFields(shippingMethod.Custom))
+		_ = d.Set("custom", flattenCustomFields(shippingMethod.Custom, d.Get("custom").([]any)))
+
+		// The zone rates are only read when they are managed by this
+		// resource, otherwise these are managed by the shipping zone rate
+		// resources
+		if current, ok := d.Get("zone_rate").([]any); ok && len(current) > 0 {
+			_ = d.Set("zone_rate", flattenShippingMethodZoneRates(shippingMethod.ZoneRates, current))
+		}
 	}
 
 	return nil
@@ -247,7 +303,7 @@ func resourceShippingMethodUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("custom") {
-		actions, err := CustomFieldUpdateActions[platform.ShippingMethodSetCustomTypeAction, platform.ShippingMethodSetCustomFieldAction](ctx, client, d)
+		actions, err := CustomFieldUpdateActions[platform.ShippingMethodSetCustomTypeAction, platform.ShippingMethodSetCustomFieldAction](ctx, m, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -256,15 +312,28 @@ func resourceShippingMethodUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	err = retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
-		_, err := client.ShippingMethods().WithId(shippingMethod.ID).Post(input).Execute(ctx)
-		return utils.ProcessRemoteError(err)
-	})
-	if err != nil {
-		// Workaround invalid state to be written, see
-		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
-		d.Partial(true)
-		return diag.FromErr(err)
+	var zoneRates []platform.ZoneRateDraft
+	if d.HasChange("zone_rate") {
+		zoneRates, err = expandShippingMethodZoneRates(d.Get("zone_rate").([]any))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// The actions are based on the zone rates in commercetools instead of
+		// the state, so these are also correct after an import
+		input.Actions = append(input.Actions, shippingMethodZoneRateActions(shippingMethod.ZoneRates, zoneRates)...)
+	}
+
+	if len(input.Actions) > 0 {
+		err = retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
+			_, err := client.ShippingMethods().WithId(shippingMethod.ID).Post(input).Execute(ctx)
+			return utils.ProcessRemoteError(err)
+		})
+		if err != nil {
+			// Workaround invalid state to be written, see
+			// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceShippingMethodRead(ctx, d, m)
@@ -287,3 +356,207 @@ func resourceShippingMethodDelete(ctx context.Context, d *schema.ResourceData, m
 	})
 	return diag.FromErr(err)
 }
+
+// validateShippingMethodZoneRates validates that every zone is used once, and
+// that every zone has at most one shipping rate per currency, since
+// commercetools identifies the shipping rates by their currency
+func validateShippingMethodZoneRates(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.NewValueKnown("zone_rate") {
+		return nil
+	}
+
+	var zones []string
+	for i, raw := range d.Get("zone_rate").([]any) {
+		zoneRate, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		zoneID, _ := zoneRate["shipping_zone_id"].(string)
+		if zoneID == "" {
+			continue
+		}
+		if slices.Contains(zones, zoneID) {
+			return fmt.Errorf("zone_rate.%d.shipping_zone_id: zone %s is used in multiple zone_rate blocks", i, zoneID)
+		}
+		zones = append(zones, zoneID)
+
+		var currencies []string
+		rates, _ := zoneRate["shipping_rate"].([]any)
+		for j, rawRate := range rates {
+			rate, ok := rawRate.(map[string]any)
+			if !ok {
+				continue
+			}
+			price := elementFromSlice(rate, "price")
+			if price == nil {
+				continue
+			}
+			currency, _ := price["currency_code"].(string)
+			if currency == "" {
+				continue
+			}
+			if slices.Contains(currencies, currency) {
+				return fmt.Errorf("zone_rate.%d.shipping_rate.%d.price.0.currency_code: zone %s has multiple "+
+					"shipping rates in %s, a zone can have one shipping rate per currency", i, j, zoneID, currency)
+			}
+			currencies = append(currencies, currency)
+		}
+	}
+	return nil
+}
+
+func expandShippingMethodZoneRates(input []any) ([]platform.ZoneRateDraft, error) {
+	result := []platform.ZoneRateDraft{}
+	for _, raw := range input {
+		zoneRate := raw.(map[string]any)
+		zoneID := zoneRate["shipping_zone_id"].(string)
+
+		draft := platform.ZoneRateDraft{
+			Zone:          platform.ZoneResourceIdentifier{ID: &zoneID},
+			ShippingRates: []platform.ShippingRateDraft{},
+		}
+		rates, _ := zoneRate["shipping_rate"].([]any)
+		for _, rawRate := range rates {
+			rate, err := expandShippingRate(rawRate.(map[string]any))
+			if err != nil {
+				return nil, err
+			}
+			draft.ShippingRates = append(draft.ShippingRates, *rate)
+		}
+		result = append(result, draft)
+	}
+	return result, nil
+}
+
+// flattenShippingMethodZoneRates returns all zone rates of the shipping method
+// in the order of the current zone rates, so reordering by commercetools
+// doesn't result in a diff. Zones and rates which are not in the current zone
+// rates are appended, so the plan shows these are removed.
+func flattenShippingMethodZoneRates(zoneRates []platform.ZoneRate, current []any) []any {
+	zoneOrder := map[string]int{}
+	rateOrder := map[string]map[string]int{}
+	for i, raw := range current {
+		zoneRate, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		zoneID, _ := zoneRate["shipping_zone_id"].(string)
+		zoneOrder[zoneID] = i
+		rateOrder[zoneID] = map[string]int{}
+
+		rates, _ := zoneRate["shipping_rate"].([]any)
+		for j, rawRate := range rates {
+			rate, ok := rawRate.(map[string]any)
+			if !ok {
+				continue
+			}
+			if price := elementFromSlice(rate, "price"); price != nil {
+				currency, _ := price["currency_code"].(string)
+				rateOrder[zoneID][currency] = j
+			}
+		}
+	}
+
+	order := func(positions map[string]int, key string) int {
+		if position, ok := positions[key]; ok {
+			return position
+		}
+		return len(positions)
+	}
+
+	sorted := slices.Clone(zoneRates)
+	slices.SortStableFunc(sorted, func(a, b platform.ZoneRate) int {
+		return order(zoneOrder, a.Zone.ID) - order(zoneOrder, b.Zone.ID)
+	})
+
+	result := make([]any, 0, len(sorted))
+	for _, zoneRate := range sorted {
+		positions := rateOrder[zoneRate.Zone.ID]
+		rates := slices.Clone(zoneRate.ShippingRates)
+		slices.SortStableFunc(rates, func(a, b platform.ShippingRate) int {
+			return order(positions, a.Price.CurrencyCode) - order(positions, b.Price.CurrencyCode)
+		})
+
+		shippingRates := make([]any, 0, len(rates))
+		for i := range rates {
+			shippingRates = append(shippingRates, flattenShippingRate(&rates[i]))
+		}
+		result = append(result, map[string]any{
+			"shipping_zone_id": zoneRate.Zone.ID,
+			"shipping_rate":    shippingRates,
+		})
+	}
+	return result
+}
+
+// shippingMethodZoneRateActions returns the actions to change the zone rates
+// of the shipping method to the desired zone rates. Shipping rates are
+// identified by their currency, and changed rates are removed and added again.
+func shippingMethodZoneRateActions(current []platform.ZoneRate, desired []platform.ZoneRateDraft) []platform.ShippingMethodUpdateAction {
+	var actions []platform.ShippingMethodUpdateAction
+
+	for _, zoneRate := range current {
+		found := slices.ContainsFunc(desired, func(d platform.ZoneRateDraft) bool {
+			return *d.Zone.ID == zoneRate.Zone.ID
+		})
+		if !found {
+			zoneID := zoneRate.Zone.ID
+			actions = append(actions, platform.ShippingMethodRemoveZoneAction{
+				Zone: platform.ZoneResourceIdentifier{ID: &zoneID},
+			})
+		}
+	}
+
+	for _, zoneRate := range desired {
+		zone := platform.ZoneResourceIdentifier{ID: zoneRate.Zone.ID}
+
+		index := slices.IndexFunc(current, func(c platform.ZoneRate) bool {
+			return c.Zone.ID == *zoneRate.Zone.ID
+		})
+		if index < 0 {
+			actions = append(actions, platform.ShippingMethodAddZoneAction{Zone: zone})
+			for _, rate := range zoneRate.ShippingRates {
+				actions = append(actions, platform.ShippingMethodAddShippingRateAction{Zone: zone, ShippingRate: rate})
+			}
+			continue
+		}
+
+		currentRates := map[string]platform.ShippingRateDraft{}
+		for i := range current[index].ShippingRates {
+			rate := createShippingRateDraft(&current[index].ShippingRates[i])
+			currentRates[rate.Price.CurrencyCode] = *rate
+		}
+
+		desiredCurrencies := map[string]bool{}
+		for _, rate := range zoneRate.ShippingRates {
+			desiredCurrencies[rate.Price.CurrencyCode] = true
+		}
+		for _, rate := range current[index].ShippingRates {
+			if !desiredCurrencies[rate.Price.CurrencyCode] {
+				actions = append(actions, platform.ShippingMethodRemoveShippingRateAction{
+					Zone:         zone,
+					ShippingRate: currentRates[rate.Price.CurrencyCode],
+				})
+			}
+		}
+
+		for _, rate := range zoneRate.ShippingRates {
+			existing, ok := currentRates[rate.Price.CurrencyCode]
+			if ok && shippingRateDraftsEqual(existing, rate) {
+				continue
+			}
+			if ok {
+				actions = append(actions, platform.ShippingMethodRemoveShippingRateAction{Zone: zone, ShippingRate: existing})
+			}
+			actions = append(actions, platform.ShippingMethodAddShippingRateAction{Zone: zone, ShippingRate: rate})
+		}
+	}
+	return actions
+}
+
+func shippingRateDraftsEqual(a, b platform.ShippingRateDraft) bool {
+	if len(a.Tiers) == 0 && len(b.Tiers) == 0 {
+		a.Tiers, b.Tiers = nil, nil
+	}
+	return reflect.DeepEqual(a, b)
+}
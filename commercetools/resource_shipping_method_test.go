@@ -5,8 +5,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 func TestAccShippingMethod_createAndUpdateWithID(t *testing.T) {
@@ -104,6 +110,118 @@ func testAccShippingMethodConfig(name string, key string, description string, lo
 		})
 }
 
+func TestAccShippingMethod_zoneRates(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "commercetools_shipping_method.standard"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckShippingMethodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccShippingMethodZoneRatesConfig(name, 5000, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.0.shipping_rate.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.0.shipping_rate.0.price.0.cent_amount", "5000"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.0.shipping_rate.0.price.0.currency_code", "EUR"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.0.shipping_rate.0.shipping_rate_price_tier.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.0.shipping_rate.1.price.0.currency_code", "USD"),
+				),
+			},
+			{
+				Config: testAccShippingMethodZoneRatesConfig(name, 4000, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.0.shipping_rate.0.price.0.cent_amount", "4000"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.1.shipping_rate.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "zone_rate.1.shipping_rate.0.free_above.0.cent_amount", "10000"),
+				),
+			},
+		},
+	})
+}
+
+func testAccShippingMethodZoneRatesConfig(name string, centAmount int, addZone bool) string {
+	return hclTemplate(`
+		resource "commercetools_tax_category" "standard" {
+			name = "{{ .name }}"
+			key  = "{{ .name }}"
+		}
+
+		resource "commercetools_shipping_zone" "de" {
+			name = "{{ .name }}-de"
+			location {
+				country = "DE"
+			}
+		}
+
+		resource "commercetools_shipping_zone" "nl" {
+			name = "{{ .name }}-nl"
+			location {
+				country = "NL"
+			}
+		}
+
+		resource "commercetools_shipping_method" "standard" {
+			name            = "{{ .name }}"
+			key             = "{{ .name }}"
+			tax_category_id = commercetools_tax_category.standard.id
+
+			zone_rate {
+				shipping_zone_id = commercetools_shipping_zone.de.id
+
+				shipping_rate {
+					price {
+						cent_amount   = {{ .centAmount }}
+						currency_code = "EUR"
+					}
+
+					shipping_rate_price_tier {
+						type                = "CartValue"
+						minimum_cent_amount = 20000
+
+						price {
+							cent_amount   = 0
+							currency_code = "EUR"
+						}
+					}
+				}
+
+				shipping_rate {
+					price {
+						cent_amount   = 6000
+						currency_code = "USD"
+					}
+				}
+			}
+
+			{{ if .addZone }}
+			zone_rate {
+				shipping_zone_id = commercetools_shipping_zone.nl.id
+
+				shipping_rate {
+					price {
+						cent_amount   = 500
+						currency_code = "EUR"
+					}
+
+					free_above {
+						cent_amount   = 10000
+						currency_code = "EUR"
+					}
+				}
+			}
+			{{ end }}
+		}`,
+		map[string]any{
+			"name":       name,
+			"centAmount": centAmount,
+			"addZone":    addZone,
+		})
+}
+
 func testAccCheckShippingMethodDestroy(s *terraform.State) error {
 	client := getClient(testAccProvider.Meta())
 
@@ -142,3 +260,173 @@ func testAccCheckShippingMethodDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestValidateShippingMethodZoneRates(t *testing.T) {
+	zoneRate := func(zoneID string, currencies ...string) map[string]any {
+		var rates []any
+		for _, currency := range currencies {
+			rates = append(rates, map[string]any{
+				"price": []any{map[string]any{"currency_code": currency, "cent_amount": 500}},
+			})
+		}
+		return map[string]any{"shipping_zone_id": zoneID, "shipping_rate": rates}
+	}
+
+	cases := []struct {
+		name      string
+		zoneRates []any
+		error     string
+	}{
+		{
+			name:      "valid",
+			zoneRates: []any{zoneRate("zone-1", "EUR", "USD"), zoneRate("zone-2", "EUR")},
+		},
+		{
+			name:      "duplicate zone",
+			zoneRates: []any{zoneRate("zone-1", "EUR"), zoneRate("zone-1", "USD")},
+			error:     "zone_rate.1.shipping_zone_id: zone zone-1 is used in multiple zone_rate blocks",
+		},
+		{
+			name:      "duplicate currency",
+			zoneRates: []any{zoneRate("zone-1", "EUR", "EUR")},
+			error: "zone_rate.0.shipping_rate.1.price.0.currency_code: zone zone-1 has multiple shipping rates " +
+				"in EUR, a zone can have one shipping rate per currency",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]any{
+				"name":            "standard",
+				"tax_category_id": "tax-category",
+				"zone_rate":       c.zoneRates,
+			})
+			_, err := resourceShippingMethod().Diff(context.Background(), nil, config, &utils.ProviderData{})
+			if c.error == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, c.error)
+		})
+	}
+}
+
+func TestShippingMethodZoneRateActions(t *testing.T) {
+	zone1, zone2, zone3 := "zone-1", "zone-2", "zone-3"
+	eur := platform.ShippingRateDraft{Price: platform.Money{CurrencyCode: "EUR", CentAmount: 500}}
+	usd := platform.ShippingRateDraft{Price: platform.Money{CurrencyCode: "USD", CentAmount: 600}}
+	changedEUR := platform.ShippingRateDraft{
+		Price: platform.Money{CurrencyCode: "EUR", CentAmount: 500},
+		Tiers: []platform.ShippingRatePriceTier{
+			platform.CartValueTier{MinimumCentAmount: 1000, Price: platform.Money{CurrencyCode: "EUR", CentAmount: 0}},
+		},
+	}
+
+	current := []platform.ZoneRate{
+		{
+			Zone: platform.ZoneReference{ID: zone1},
+			ShippingRates: []platform.ShippingRate{
+				{Price: platform.CentPrecisionMoney{CurrencyCode: "EUR", CentAmount: 500}, Tiers: []platform.ShippingRatePriceTier{}},
+				{Price: platform.CentPrecisionMoney{CurrencyCode: "USD", CentAmount: 600}},
+			},
+		},
+		{
+			Zone:          platform.ZoneReference{ID: zone2},
+			ShippingRates: []platform.ShippingRate{{Price: platform.CentPrecisionMoney{CurrencyCode: "EUR", CentAmount: 500}}},
+		},
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		desired := []platform.ZoneRateDraft{
+			{Zone: platform.ZoneResourceIdentifier{ID: &zone1}, ShippingRates: []platform.ShippingRateDraft{eur, usd}},
+			{Zone: platform.ZoneResourceIdentifier{ID: &zone2}, ShippingRates: []platform.ShippingRateDraft{eur}},
+		}
+		assert.Empty(t, shippingMethodZoneRateActions(current, desired))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		desired := []platform.ZoneRateDraft{
+			{Zone: platform.ZoneResourceIdentifier{ID: &zone1}, ShippingRates: []platform.ShippingRateDraft{changedEUR}},
+			{Zone: platform.ZoneResourceIdentifier{ID: &zone3}, ShippingRates: []platform.ShippingRateDraft{usd}},
+		}
+
+		expected := []platform.ShippingMethodUpdateAction{
+			platform.ShippingMethodRemoveZoneAction{Zone: platform.ZoneResourceIdentifier{ID: &zone2}},
+			platform.ShippingMethodRemoveShippingRateAction{Zone: platform.ZoneResourceIdentifier{ID: &zone1}, ShippingRate: usd},
+			platform.ShippingMethodRemoveShippingRateAction{Zone: platform.ZoneResourceIdentifier{ID: &zone1}, ShippingRate: platform.ShippingRateDraft{
+				Price: platform.Money{CurrencyCode: "EUR", CentAmount: 500},
+				Tiers: []platform.ShippingRatePriceTier{},
+			}},
+			platform.ShippingMethodAddShippingRateAction{Zone: platform.ZoneResourceIdentifier{ID: &zone1}, ShippingRate: changedEUR},
+			platform.ShippingMethodAddZoneAction{Zone: platform.ZoneResourceIdentifier{ID: &zone3}},
+			platform.ShippingMethodAddShippingRateAction{Zone: platform.ZoneResourceIdentifier{ID: &zone3}, ShippingRate: usd},
+		}
+		assert.Equal(t, expected, shippingMethodZoneRateActions(current, desired))
+	})
+}
+
+func TestFlattenShippingMethodZoneRates(t *testing.T) {
+	zoneRates := []platform.ZoneRate{
+		{
+			Zone: platform.ZoneReference{ID: "zone-1"},
+			ShippingRates: []platform.ShippingRate{
+				{Price: platform.CentPrecisionMoney{CurrencyCode: "USD", CentAmount: 600}},
+				{Price: platform.CentPrecisionMoney{CurrencyCode: "EUR", CentAmount: 500}},
+			},
+		},
+		{
+			Zone:          platform.ZoneReference{ID: "zone-2"},
+			ShippingRates: []platform.ShippingRate{{Price: platform.CentPrecisionMoney{CurrencyCode: "GBP", CentAmount: 400}}},
+		},
+		{
+			Zone:          platform.ZoneReference{ID: "zone-3"},
+			ShippingRates: []platform.ShippingRate{{Price: platform.CentPrecisionMoney{CurrencyCode: "EUR", CentAmount: 500}}},
+		},
+	}
+	current := []any{
+		map[string]any{"shipping_zone_id": "zone-2"},
+		map[string]any{
+			"shipping_zone_id": "zone-1",
+			"shipping_rate": []any{
+				map[string]any{"price": []any{map[string]any{"currency_code": "EUR", "cent_amount": 500}}},
+				map[string]any{"price": []any{map[string]any{"currency_code": "USD", "cent_amount": 600}}},
+			},
+		},
+	}
+
+	result := flattenShippingMethodZoneRates(zoneRates, current)
+	require.Len(t, result, 3)
+	assert.Equal(t, "zone-2", result[0].(map[string]any)["shipping_zone_id"])
+	assert.Equal(t, "zone-1", result[1].(map[string]any)["shipping_zone_id"])
+
+	// Rates and zones which aren't in the state are added at the end, so
+	// these show up in the plan
+	assert.Equal(t, "zone-3", result[2].(map[string]any)["shipping_zone_id"])
+	assert.Len(t, result[0].(map[string]any)["shipping_rate"], 1)
+
+	rates := result[1].(map[string]any)["shipping_rate"].([]any)
+	require.Len(t, rates, 2)
+	assert.Equal(t, []any{map[string]any{"currency_code": "EUR", "cent_amount": 500}}, rates[0].(map[string]any)["price"])
+	assert.Equal(t, []any{map[string]any{"currency_code": "USD", "cent_amount": 600}}, rates[1].(map[string]any)["price"])
+}
+
+func TestCheckShippingZoneRateConflict(t *testing.T) {
+	shippingMethod := &platform.ShippingMethod{
+		ID: "shipping-method",
+		ZoneRates: []platform.ZoneRate{
+			{
+				Zone:          platform.ZoneReference{ID: "zone-1"},
+				ShippingRates: []platform.ShippingRate{{Price: platform.CentPrecisionMoney{CurrencyCode: "EUR", CentAmount: 500}}},
+			},
+		},
+	}
+
+	err := checkShippingZoneRateConflict(shippingMethod, "zone-1", "EUR")
+	assert.EqualError(t, err, "shipping method shipping-method already has a shipping rate for EUR in zone zone-1. "+
+		"When the zone rates are managed by the zone_rate blocks of commercetools_shipping_method, move this "+
+		"shipping rate to a zone_rate block, since the zone_rate blocks remove the rates of "+
+		"commercetools_shipping_zone_rate")
+
+	assert.NoError(t, checkShippingZoneRateConflict(shippingMethod, "zone-1", "USD"))
+	assert.NoError(t, checkShippingZoneRateConflict(shippingMethod, "zone-2", "EUR"))
+}
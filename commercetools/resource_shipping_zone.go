@@ -12,7 +12,7 @@ import (
 )
 
 func resourceShippingZone() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection("shipping zone", &schema.Resource{
 		CreateContext: resourceShippingZoneCreate,
 		ReadContext:   resourceShippingZoneRead,
 		UpdateContext: resourceShippingZoneUpdate,
@@ -53,12 +53,17 @@ func resourceShippingZone() *schema.Resource {
 					},
 				},
 			},
+			"deletion_protection": deletionProtectionSchema(),
+			"force_destroy": forceDestroySchema(
+				"the zone rates of shipping methods, including the shipping rates for the zone",
+				"Shipping zones are only referenced by shipping methods, so all references are removed",
+			),
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceShippingZoneCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -183,6 +188,18 @@ func resourceShippingZoneUpdate(ctx context.Context, d *schema.ResourceData, m a
 func resourceShippingZoneDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
 
+	if diags := checkDeletionProtection(d, "shipping zone"); diags.HasError() {
+		return diags
+	}
+
+	var diags diag.Diagnostics
+	if d.Get("force_destroy").(bool) {
+		diags = detachZoneFromShippingMethods(ctx, client, d.Id())
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	// Lock to prevent concurrent updates due to Version number conflicts
 	ctMutexKV.Lock(d.Id())
 	defer ctMutexKV.Unlock(d.Id())
@@ -192,7 +209,7 @@ func resourceShippingZoneDelete(ctx context.Context, d *schema.ResourceData, m a
 		_, err := client.Zones().WithId(d.Id()).Delete().Version(version).Execute(ctx)
 		return utils.ProcessRemoteError(err)
 	})
-	return diag.FromErr(err)
+	return append(diags, diag.FromErr(err)...)
 }
 
 func expandShippingZoneLocations(input *schema.Set) []platform.Location {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/labd/commercetools-go-sdk/platform"
@@ -25,7 +26,16 @@ func resourceShippingZoneRate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceShippingZoneRateImportState,
 		},
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: customdiff.All(
+			validateCurrencies(
+				"price.*.currency_code",
+				"free_above.*.currency_code",
+				"shipping_rate_price_tier.*.price.*.currency_code",
+				"shipping_rate_price_tier.*.price_function.*.currency_code",
+			),
+			validateShippingRateTiers("shipping_rate_price_tier.*.type"),
+		),
+		Schema: shippingRateSchema(map[string]*schema.Schema{
 			"shipping_method_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -36,117 +46,127 @@ func resourceShippingZoneRate() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
-			"price": {
-				Type:     schema.TypeList,
-				Required: true,
-				MinItems: 1,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"currency_code": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: ValidateCurrencyCode,
-						},
-						"cent_amount": {
-							Type:     schema.TypeInt,
-							Required: true,
-						},
+		}),
+	}
+}
+
+// shippingRateSchema adds the fields of a shipping rate to the schema. These
+// are shared by the shipping zone rate resource and the zone_rate blocks of
+// the shipping method resource.
+func shippingRateSchema(result map[string]*schema.Schema) map[string]*schema.Schema {
+	fields := map[string]*schema.Schema{
+		"price": {
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"currency_code": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: ValidateCurrencyCode,
+					},
+					"cent_amount": {
+						Type:     schema.TypeInt,
+						Required: true,
 					},
 				},
 			},
-			"free_above": {
-				Description: "The shipping is free if the sum of the (custom) line item prices reaches the freeAbove value",
-				Type:        schema.TypeList,
-				MinItems:    1,
-				MaxItems:    1,
-				Optional:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"currency_code": {
-							Description:  "The currency code compliant to [ISO 4217](https://en.wikipedia.org/wiki/ISO_4217)",
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: ValidateCurrencyCode,
-						},
-						"cent_amount": {
-							Description: "The amount in cents (the smallest indivisible unit of the currency)",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
+		},
+		"free_above": {
+			Description: "The shipping is free if the sum of the (custom) line item prices reaches the freeAbove value",
+			Type:        schema.TypeList,
+			MinItems:    1,
+			MaxItems:    1,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"currency_code": {
+						Description:  "The currency code compliant to [ISO 4217](https://en.wikipedia.org/wiki/ISO_4217)",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: ValidateCurrencyCode,
+					},
+					"cent_amount": {
+						Description: "The amount in cents (the smallest indivisible unit of the currency)",
+						Type:        schema.TypeInt,
+						Required:    true,
 					},
 				},
 			},
-			"shipping_rate_price_tier": {
-				Description: "A price tier is selected instead of the default price when a certain threshold or " +
-					"specific cart value is reached. If no tiered price is suitable for the cart, the base price of the " +
-					"shipping rate is used\n. " +
-					"See also [Shipping Rate Price Tier API Docs](https://docs.commercetools.com/api/projects/shippingMethods#shippingratepricetier)",
-				Type:     schema.TypeList,
-				MinItems: 1,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Description: "CartValue, CartScore or CartClassification",
-							Type:        schema.TypeString,
-							Required:    true,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(platform.ShippingRateTierTypeCartValue),
-								string(platform.ShippingRateTierTypeCartScore),
-								string(platform.ShippingRateTierTypeCartClassification),
-							}, false),
-						},
-						"minimum_cent_amount": {
-							Description: "If type is CartValue this represents the cent amount of the tier",
-							Type:        schema.TypeInt,
-							Optional:    true,
-						},
-						"value": {
-							Description: "If type is CartClassification, must be a valid key of the CartClassification",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"score": {
-							Description: "If type is CartScore. Sets a fixed price for this score value",
-							Type:        schema.TypeInt,
-							Optional:    true,
-						},
-						"price": {
-							Description: "The price of the score, value or minimum_cent_amount tier",
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"currency_code": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: ValidateCurrencyCode,
-									},
-									"cent_amount": {
-										Type:     schema.TypeInt,
-										Required: true,
-									},
+		},
+		"shipping_rate_price_tier": {
+			Description: "A price tier is selected instead of the default price when a certain threshold or " +
+				"specific cart value is reached. If no tiered price is suitable for the cart, the base price of the " +
+				"shipping rate is used\n. " +
+				"See also [Shipping Rate Price Tier API Docs](https://docs.commercetools.com/api/projects/shippingMethods#shippingratepricetier)",
+			Type:     schema.TypeList,
+			MinItems: 1,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Description: "CartValue, CartScore or CartClassification",
+						Type:        schema.TypeString,
+						Required:    true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(platform.ShippingRateTierTypeCartValue),
+							string(platform.ShippingRateTierTypeCartScore),
+							string(platform.ShippingRateTierTypeCartClassification),
+						}, false),
+					},
+					"minimum_cent_amount": {
+						Description: "If type is CartValue this represents the cent amount of the tier",
+						Type:        schema.TypeInt,
+						Optional:    true,
+					},
+					"value": {
+						Description: "If type is CartClassification, must be a valid key of the CartClassification",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"score": {
+						Description: "If type is CartScore. Sets a fixed price for this score value",
+						Type:        schema.TypeInt,
+						Optional:    true,
+					},
+					"price": {
+						Description: "The price of the score, value or minimum_cent_amount tier",
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"currency_code": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: ValidateCurrencyCode,
+								},
+								"cent_amount": {
+									Type:     schema.TypeInt,
+									Required: true,
 								},
 							},
 						},
-						"price_function": {
-							Description: "If type is CartScore. Allows to calculate a price dynamically for the score.",
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"currency_code": {
-										Type:         schema.TypeString,
-										Required:     true,
-										ValidateFunc: ValidateCurrencyCode,
-									},
-									"function": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
+					},
+					"price_function": {
+						Description: "If type is CartScore. Allows to calculate a price dynamically for the score.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"currency_code": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: ValidateCurrencyCode,
+								},
+								"function": {
+									Description:  "The function, for example `(50 * x) + 4950`, where x is the score of the cart",
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validatePriceFunction,
 								},
 							},
 						},
@@ -155,6 +175,10 @@ func resourceShippingZoneRate() *schema.Resource {
 			},
 		},
 	}
+	for key, value := range fields {
+		result[key] = value
+	}
+	return result
 }
 
 func resourceShippingZoneRateImportState(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
@@ -201,6 +225,10 @@ func resourceShippingZoneRateCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := checkShippingZoneRateConflict(shippingMethod, shippingZoneID, draft.Price.CurrencyCode); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Add the zone to the shipping method if it isn't set yet.
 	zoneNotFound := true
 	for _, v := range shippingMethod.ZoneRates {
@@ -256,6 +284,20 @@ func resourceShippingZoneRateRead(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
+// checkShippingZoneRateConflict returns an error when the shipping method
+// already has a shipping rate for the currency in the zone. This is the case
+// when the zone rates are managed by the zone_rate blocks of
+// commercetools_shipping_method, which remove the rates of this resource.
+func checkShippingZoneRateConflict(shippingMethod *platform.ShippingMethod, shippingZoneID, currencyCode string) error {
+	if _, err := findShippingZoneRate(shippingMethod, shippingZoneID, currencyCode); err != nil {
+		return nil
+	}
+	return fmt.Errorf("shipping method %s already has a shipping rate for %s in zone %s. When the zone "+
+		"rates are managed by the zone_rate blocks of commercetools_shipping_method, move this shipping "+
+		"rate to a zone_rate block, since the zone_rate blocks remove the rates of "+
+		"commercetools_shipping_zone_rate", shippingMethod.ID, currencyCode, shippingZoneID)
+}
+
 func resourceShippingZoneRateUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	shippingMethodID, shippingZoneID, currencyCode := getShippingIDs(d.Id())
 	ctMutexKV.Lock(shippingMethodID)
@@ -417,7 +459,9 @@ func findShippingZoneRate(shippingMethod *platform.ShippingMethod, shippingZoneI
 		}
 	}
 
-	return nil, fmt.Errorf("couldn't find shipping zone rate")
+	return nil, fmt.Errorf("couldn't find the shipping rate for %s in zone %s of shipping method %s. "+
+		"It may have been removed by the zone_rate blocks of commercetools_shipping_method, which "+
+		"can't be combined with commercetools_shipping_zone_rate", currencyCode, shippingZoneID, shippingMethod.ID)
 }
 
 func setShippingZoneRateState(d *schema.ResourceData, shippingMethod *platform.ShippingMethod) error {
@@ -432,34 +476,35 @@ func setShippingZoneRateState(d *schema.ResourceData, shippingMethod *platform.S
 		return err
 	}
 
-	tiers := flattenShippingZoneRateTiers(shippingRate)
-	_ = d.Set("shipping_rate_price_tier", tiers)
-
-	price := map[string]any{
-		"currency_code": shippingRate.Price.CurrencyCode,
-		"cent_amount":   shippingRate.Price.CentAmount,
-	}
-	err = d.Set("price", []any{price})
-	if err != nil {
-		return err
+	for key, value := range flattenShippingRate(shippingRate) {
+		if err := d.Set(key, value); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// flattenShippingRate returns the shipping rate fields, see shippingRateSchema
+func flattenShippingRate(shippingRate *platform.ShippingRate) map[string]any {
+	result := map[string]any{
+		"price": []any{
+			map[string]any{
+				"currency_code": shippingRate.Price.CurrencyCode,
+				"cent_amount":   shippingRate.Price.CentAmount,
+			},
+		},
+		"free_above":               nil,
+		"shipping_rate_price_tier": flattenShippingZoneRateTiers(shippingRate),
+	}
 	if shippingRate.FreeAbove != nil {
-		freeAbove := map[string]any{
-			"currency_code": shippingRate.FreeAbove.CurrencyCode,
-			"cent_amount":   shippingRate.FreeAbove.CentAmount,
-		}
-		err = d.Set("free_above", []any{freeAbove})
-		if err != nil {
-			return err
-		}
-	} else {
-		err = d.Set("free_above", nil)
-		if err != nil {
-			return err
+		result["free_above"] = []any{
+			map[string]any{
+				"currency_code": shippingRate.FreeAbove.CurrencyCode,
+				"cent_amount":   shippingRate.FreeAbove.CentAmount,
+			},
 		}
 	}
-	return nil
+	return result
 }
 
 func flattenShippingZoneRateTiers(shippingRate *platform.ShippingRate) []any {
@@ -519,7 +564,18 @@ func flattenShippingZoneRateTiers(shippingRate *platform.ShippingRate) []any {
 }
 
 func expandShippingRateDraft(d *schema.ResourceData) (*platform.ShippingRateDraft, error) {
-	shippingRatePriceTiers, err := expandShippingRatePriceTiers(d)
+	return expandShippingRate(map[string]any{
+		"price":                    d.Get("price"),
+		"free_above":               d.Get("free_above"),
+		"shipping_rate_price_tier": d.Get("shipping_rate_price_tier"),
+	})
+}
+
+// expandShippingRate returns the draft of the shipping rate fields, see
+// shippingRateSchema
+func expandShippingRate(input map[string]any) (*platform.ShippingRateDraft, error) {
+	values, _ := input["shipping_rate_price_tier"].([]any)
+	shippingRatePriceTiers, err := expandShippingRatePriceTiers(values)
 	if err != nil {
 		return nil, err
 	}
@@ -528,14 +584,14 @@ func expandShippingRateDraft(d *schema.ResourceData) (*platform.ShippingRateDraf
 		Tiers: shippingRatePriceTiers,
 	}
 
-	if price, _ := elementFromList(d, "price"); price != nil {
+	if price := elementFromSlice(input, "price"); price != nil {
 		draft.Price = platform.Money{
 			CurrencyCode: price["currency_code"].(string),
 			CentAmount:   price["cent_amount"].(int),
 		}
 	}
 
-	if price, _ := elementFromList(d, "free_above"); price != nil {
+	if price := elementFromSlice(input, "free_above"); price != nil {
 		draft.FreeAbove = &platform.Money{
 			CurrencyCode: price["currency_code"].(string),
 			CentAmount:   price["cent_amount"].(int),
@@ -546,14 +602,13 @@ func expandShippingRateDraft(d *schema.ResourceData) (*platform.ShippingRateDraf
 
 }
 
-func expandShippingRatePriceTiers(d *schema.ResourceData) ([]platform.ShippingRatePriceTier, error) {
-	values, ok := d.GetOk("shipping_rate_price_tier")
-	if !ok {
+func expandShippingRatePriceTiers(values []any) ([]platform.ShippingRatePriceTier, error) {
+	if len(values) == 0 {
 		return []platform.ShippingRatePriceTier{}, nil
 	}
 
 	var tiers []platform.ShippingRatePriceTier
-	for _, priceTier := range values.([]any) {
+	for _, priceTier := range values {
 		tierMap := priceTier.(map[string]any)
 
 		var price *platform.Money
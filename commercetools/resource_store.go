@@ -3,10 +3,14 @@ package commercetools
 import (
 	"context"
 	"errors"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"fmt"
+	"slices"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
@@ -24,6 +28,12 @@ func resourceStore() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateCustomFields,
+			validateLocalizedStrings("name"),
+			validateCountries("countries"),
+			validateStoreProductSelections,
+		),
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Description: "User-specific unique identifier for the store. The key is mandatory and immutable. " +
@@ -79,9 +89,16 @@ func resourceStore() *schema.Resource {
 							Required:    true,
 						},
 						"product_selection_id": {
-							Description: "Resource Identifier of a ProductSelection",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description: "Resource Identifier of a ProductSelection. Either product_selection_id or " +
+								"product_selection_key must be set",
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"product_selection_key": {
+							Description: "Key of a ProductSelection. Either product_selection_id or " +
+								"product_selection_key must be set",
+							Type:     schema.TypeString,
+							Optional: true,
 						},
 					},
 				},
@@ -99,7 +116,7 @@ func resourceStoreCreate(ctx context.Context, d *schema.ResourceData, m any) dia
 	scIdentifiers := expandStoreChannels(d.Get("supply_channels"))
 	psIdentifiers := expandProductSelections(d.Get("product_selection").(*schema.Set))
 
-	custom, err := CreateCustomFieldDraft(ctx, client, d)
+	custom, err := CreateCustomFieldDraft(ctx, m, d)
 	if err != nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -186,14 +203,14 @@ func resourceStoreRead(ctx context.Context, d *schema.ResourceData, m any) diag.
 	}
 
 	if store.ProductSelections != nil {
-		selections, err := flattenProductSelections(store.ProductSelections)
+		selections, err := flattenProductSelections(store.ProductSelections, d.Get("product_selection").(*schema.Set))
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		_ = d.Set("product_selection", selections)
 	}
 
-	_ = d.Set("custom", flattenCustomFields(store.Custom))
+	_ = d.Set("custom", flattenCustomFields(store.Custom, d.Get("custom").([]any)))
 	return nil
 }
 
@@ -254,31 +271,31 @@ func resourceStoreUpdate(ctx context.Context, d *schema.ResourceData, m any) dia
 	if d.HasChange("product_selection") {
 		o, n := d.GetChange("product_selection")
 
-		oldProductSelections := expandProductSelections(o.(*schema.Set))
-		newProductSelections := expandProductSelections(n.(*schema.Set))
-
-		for i, productSelection := range oldProductSelections {
-			if !productSelectionInSlice(productSelection, newProductSelections) {
-				input.Actions = append(
-					input.Actions,
-					&platform.StoreRemoveProductSelectionAction{ProductSelection: oldProductSelections[i].ProductSelection})
-			}
-		}
-		for i, location := range newProductSelections {
-			if !productSelectionInSlice(location, oldProductSelections) {
-				input.Actions = append(
-					input.Actions,
-					&platform.StoreAddProductSelectionAction{
-						ProductSelection: newProductSelections[i].ProductSelection,
-						Active:           newProductSelections[i].Active,
-					})
-			}
+		// The product selections of the store are fetched to resolve their
+		// keys, so switching between the ID and the key of a product
+		// selection doesn't remove it from the store
+		store, err := client.Stores().
+			WithId(d.Id()).
+			Get().
+			Expand([]string{"productSelections[*].productSelection"}).
+			Execute(ctx)
+		if err != nil {
+			// Workaround invalid state to be written, see
+			// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
+			d.Partial(true)
+			return diag.FromErr(err)
 		}
+
+		input.Actions = append(input.Actions, storeProductSelectionActions(
+			expandProductSelections(o.(*schema.Set)),
+			expandProductSelections(n.(*schema.Set)),
+			productSelectionIDs(store.ProductSelections),
+		)...)
 	}
 
 	if d.HasChange("custom") {
 
-		actions, err := CustomFieldUpdateActions[platform.StoreSetCustomTypeAction, platform.StoreSetCustomFieldAction](ctx, client, d)
+		actions, err := CustomFieldUpdateActions[platform.StoreSetCustomTypeAction, platform.StoreSetCustomFieldAction](ctx, m, d)
 		if err != nil {
 			// Workaround invalid state to be written, see
 			// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -342,15 +359,11 @@ func expandProductSelections(input *schema.Set) []platform.ProductSelectionSetti
 			active = false
 		}
 
-		var productSelectionRef *string
-		if productSelection, ok := raw["product_selection_id"].(string); ok && productSelection != "" {
-			productSelectionRef = &productSelection
-		}
-
 		result[i] = platform.ProductSelectionSettingDraft{
 			Active: utils.BoolRef(active),
 			ProductSelection: platform.ProductSelectionResourceIdentifier{
-				ID: productSelectionRef,
+				ID:  nilIfEmpty(stringRef(raw["product_selection_id"])),
+				Key: nilIfEmpty(stringRef(raw["product_selection_key"])),
 			},
 		}
 	}
@@ -385,13 +398,29 @@ func flattenStoreChannels(channels []platform.ChannelReference) ([]string, error
 	return channelKeys, nil
 }
 
-func flattenProductSelections(selections []platform.ProductSelectionSetting) ([]map[string]any, error) {
-	result := make([]map[string]any, len(selections))
+// flattenProductSelections returns the product selections of the store. The
+// product selections which are referenced by their key in the current state
+// are referenced by their key, the others by their ID.
+func flattenProductSelections(selections []platform.ProductSelectionSetting, current *schema.Set) ([]map[string]any, error) {
+	var keys []string
+	for _, draft := range expandProductSelections(current) {
+		if draft.ProductSelection.Key != nil {
+			keys = append(keys, *draft.ProductSelection.Key)
+		}
+	}
 
+	result := make([]map[string]any, len(selections))
 	for i := range selections {
 		result[i] = map[string]any{
-			"active":               selections[i].Active,
-			"product_selection_id": selections[i].ProductSelection.ID,
+			"active":                selections[i].Active,
+			"product_selection_id":  selections[i].ProductSelection.ID,
+			"product_selection_key": "",
+		}
+
+		obj := selections[i].ProductSelection.Obj
+		if obj != nil && obj.Key != nil && slices.Contains(keys, *obj.Key) {
+			result[i]["product_selection_id"] = ""
+			result[i]["product_selection_key"] = *obj.Key
 		}
 	}
 
@@ -406,11 +435,96 @@ func flattenCountries(countries []platform.StoreCountry) ([]string, error) {
 	return countryCodes, nil
 }
 
-func productSelectionInSlice(needle platform.ProductSelectionSettingDraft, haystack []platform.ProductSelectionSettingDraft) bool {
-	for _, item := range haystack {
-		if item == needle {
-			return true
+// validateStoreProductSelections validates that every product selection is
+// referenced either by its ID or by its key
+func validateStoreProductSelections(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.NewValueKnown("product_selection") {
+		return nil
+	}
+
+	for _, raw := range d.Get("product_selection").(*schema.Set).List() {
+		selection, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		id, _ := selection["product_selection_id"].(string)
+		key, _ := selection["product_selection_key"].(string)
+		if id == "" && key == "" {
+			return fmt.Errorf("product_selection: either product_selection_id or product_selection_key must be set")
+		}
+		if id != "" && key != "" {
+			return fmt.Errorf("product_selection: only one of product_selection_id and product_selection_key "+
+				"can be set, got product_selection_id %s and product_selection_key %s", id, key)
+		}
+	}
+	return nil
+}
+
+// productSelectionIDs returns the IDs of the product selections of the store
+// by their key
+func productSelectionIDs(selections []platform.ProductSelectionSetting) map[string]string {
+	result := map[string]string{}
+	for _, selection := range selections {
+		if obj := selection.ProductSelection.Obj; obj != nil && obj.Key != nil {
+			result[*obj.Key] = selection.ProductSelection.ID
+		}
+	}
+	return result
+}
+
+// productSelectionIdentity returns the ID of the product selection, which
+// identifies the product selection in the old and new state regardless of
+// whether it is referenced by its ID or by its key. Keys which aren't in ids
+// are returned as is, since the product selection isn't part of the store yet.
+func productSelectionIdentity(draft platform.ProductSelectionSettingDraft, ids map[string]string) string {
+	if key := draft.ProductSelection.Key; key != nil {
+		if id, ok := ids[*key]; ok {
+			return id
+		}
+		return "key:" + *key
+	}
+	if draft.ProductSelection.ID != nil {
+		return *draft.ProductSelection.ID
+	}
+	return ""
+}
+
+// storeProductSelectionActions returns the actions to change the product
+// selections of the store. Changing only the active setting of a product
+// selection changes it in place, so its assortment is never removed from the
+// store. Product selections referenced by their key are matched with ids.
+func storeProductSelectionActions(current, desired []platform.ProductSelectionSettingDraft, ids map[string]string) []platform.StoreUpdateAction {
+	var actions []platform.StoreUpdateAction
+
+	for _, selection := range current {
+		found := slices.ContainsFunc(desired, func(d platform.ProductSelectionSettingDraft) bool {
+			return productSelectionIdentity(d, ids) == productSelectionIdentity(selection, ids)
+		})
+		if !found {
+			actions = append(actions, &platform.StoreRemoveProductSelectionAction{
+				ProductSelection: selection.ProductSelection,
+			})
+		}
+	}
+
+	for _, selection := range desired {
+		index := slices.IndexFunc(current, func(c platform.ProductSelectionSettingDraft) bool {
+			return productSelectionIdentity(c, ids) == productSelectionIdentity(selection, ids)
+		})
+		if index < 0 {
+			actions = append(actions, &platform.StoreAddProductSelectionAction{
+				ProductSelection: selection.ProductSelection,
+				Active:           selection.Active,
+			})
+			continue
+		}
+
+		if *current[index].Active != *selection.Active {
+			actions = append(actions, &platform.StoreChangeProductSelectionAction{
+				ProductSelection: selection.ProductSelection,
+				Active:           selection.Active,
+			})
 		}
 	}
-	return false
+	return actions
 }
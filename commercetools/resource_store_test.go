@@ -6,9 +6,12 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 func TestAccStore_createAndUpdateWithID(t *testing.T) {
@@ -476,6 +479,146 @@ func testAccStoreConfigWithCustomField(id, name, key string, languages []string)
 	})
 }
 
+func TestStoreProductSelectionActions(t *testing.T) {
+	selection := func(id, key string, active bool) platform.ProductSelectionSettingDraft {
+		return platform.ProductSelectionSettingDraft{
+			Active: utils.BoolRef(active),
+			ProductSelection: platform.ProductSelectionResourceIdentifier{
+				ID:  nilIfEmpty(&id),
+				Key: nilIfEmpty(&key),
+			},
+		}
+	}
+
+	current := []platform.ProductSelectionSettingDraft{
+		selection("ps-1", "", true),
+		selection("", "summer", false),
+		selection("ps-3", "", true),
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		desired := []platform.ProductSelectionSettingDraft{
+			selection("ps-3", "", true),
+			selection("ps-1", "", true),
+			selection("", "summer", false),
+		}
+		assert.Empty(t, storeProductSelectionActions(current, desired, nil))
+	})
+
+	t.Run("switched between ID and key", func(t *testing.T) {
+		ids := map[string]string{"summer": "ps-2", "autumn": "ps-1"}
+		desired := []platform.ProductSelectionSettingDraft{
+			selection("", "autumn", true),
+			selection("ps-2", "", false),
+			selection("ps-3", "", true),
+		}
+		assert.Empty(t, storeProductSelectionActions(current, desired, ids))
+
+		desired[0] = selection("", "autumn", false)
+		expected := []platform.StoreUpdateAction{
+			&platform.StoreChangeProductSelectionAction{
+				ProductSelection: platform.ProductSelectionResourceIdentifier{Key: utils.StringRef("autumn")},
+				Active:           utils.BoolRef(false),
+			},
+		}
+		assert.Equal(t, expected, storeProductSelectionActions(current, desired, ids))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		desired := []platform.ProductSelectionSettingDraft{
+			selection("ps-1", "", true),
+			selection("", "summer", true),
+			selection("", "winter", true),
+		}
+		expected := []platform.StoreUpdateAction{
+			&platform.StoreRemoveProductSelectionAction{
+				ProductSelection: platform.ProductSelectionResourceIdentifier{ID: utils.StringRef("ps-3")},
+			},
+			&platform.StoreChangeProductSelectionAction{
+				ProductSelection: platform.ProductSelectionResourceIdentifier{Key: utils.StringRef("summer")},
+				Active:           utils.BoolRef(true),
+			},
+			&platform.StoreAddProductSelectionAction{
+				ProductSelection: platform.ProductSelectionResourceIdentifier{Key: utils.StringRef("winter")},
+				Active:           utils.BoolRef(true),
+			},
+		}
+		assert.Equal(t, expected, storeProductSelectionActions(current, desired, map[string]string{"summer": "ps-2"}))
+	})
+}
+
+func TestProductSelectionIDs(t *testing.T) {
+	summer := "summer"
+	selections := []platform.ProductSelectionSetting{
+		{ProductSelection: platform.ProductSelectionReference{ID: "ps-1", Obj: &platform.ProductSelection{Key: &summer}}},
+		{ProductSelection: platform.ProductSelectionReference{ID: "ps-2", Obj: &platform.ProductSelection{}}},
+		{ProductSelection: platform.ProductSelectionReference{ID: "ps-3"}},
+	}
+	assert.Equal(t, map[string]string{"summer": "ps-1"}, productSelectionIDs(selections))
+}
+
+func TestFlattenProductSelections(t *testing.T) {
+	summer, winter := "summer", "winter"
+	selections := []platform.ProductSelectionSetting{
+		{ProductSelection: platform.ProductSelectionReference{ID: "ps-1", Obj: &platform.ProductSelection{Key: &summer}}, Active: true},
+		{ProductSelection: platform.ProductSelectionReference{ID: "ps-2", Obj: &platform.ProductSelection{Key: &winter}}, Active: false},
+	}
+	current := schema.NewSet(schema.HashResource(resourceStore().Schema["product_selection"].Elem.(*schema.Resource)), []any{
+		map[string]any{"active": true, "product_selection_key": "summer", "product_selection_id": ""},
+		map[string]any{"active": false, "product_selection_id": "ps-2", "product_selection_key": ""},
+	})
+
+	result, err := flattenProductSelections(selections, current)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		{"active": true, "product_selection_id": "", "product_selection_key": "summer"},
+		{"active": false, "product_selection_id": "ps-2", "product_selection_key": ""},
+	}, result)
+}
+
+func TestValidateStoreProductSelections(t *testing.T) {
+	cases := []struct {
+		name      string
+		selection map[string]any
+		error     string
+	}{
+		{
+			name:      "by ID",
+			selection: map[string]any{"active": true, "product_selection_id": "ps-1"},
+		},
+		{
+			name:      "by key",
+			selection: map[string]any{"active": true, "product_selection_key": "summer"},
+		},
+		{
+			name:      "missing reference",
+			selection: map[string]any{"active": true},
+			error:     "product_selection: either product_selection_id or product_selection_key must be set",
+		},
+		{
+			name:      "both references",
+			selection: map[string]any{"active": true, "product_selection_id": "ps-1", "product_selection_key": "summer"},
+			error: "product_selection: only one of product_selection_id and product_selection_key can be set, " +
+				"got product_selection_id ps-1 and product_selection_key summer",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]any{
+				"key":               "store",
+				"product_selection": []any{c.selection},
+			})
+			_, err := resourceStore().Diff(context.Background(), nil, config, &utils.ProviderData{})
+			if c.error == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, c.error)
+		})
+	}
+}
+
 func testAccCheckStoreDestroy(s *terraform.State) error {
 	client := getClient(testAccProvider.Meta())
 
@@ -2,17 +2,22 @@ package commercetools
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"fmt"
+	"reflect"
+	"slices"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 func resourceTaxCategory() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection("tax category", &schema.Resource{
 		Description: "Tax Categories define how products are to be taxed in different countries.\n\n" +
 			"See also the [Tax Category API Documentation](https://docs.commercetools.com/api/projects/taxCategories)",
 		CreateContext: resourceTaxCategoryCreate,
@@ -22,6 +27,10 @@ func resourceTaxCategory() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			validateTaxCategoryRates,
+			validateCountries("rate.*.country"),
+		),
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Description: "User-specific unique identifier for the tax category",
@@ -36,22 +45,50 @@ func resourceTaxCategory() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"rate": {
+				Description: "The [tax rates](https://docs.commercetools.com/api/projects/taxCategories#taxrate) " +
+					"of the tax category. When set, all rates of the tax category are managed by this resource " +
+					"in a single update, and commercetools_tax_category_rate can't be used for the tax category: " +
+					"rates which are not in the rate blocks are shown as a change in the plan and removed. " +
+					"Rates are matched by their key, or by their country and state when they have no key. " +
+					"Removing all rate blocks removes all rates from the tax category",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: taxRateSchema(map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the rate, which changes when the rate is changed",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					}),
+				},
+			},
+			"deletion_protection": deletionProtectionSchema(),
+			"force_destroy": forceDestroySchema(
+				"the tax category of products",
+				"Shipping methods require a tax category, so a tax category used "+
+					"by a shipping method is reported as an error and not deleted",
+			),
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceTaxCategoryCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
-	var emptyTaxRates []platform.TaxRateDraft
+	taxRates, err := expandTaxCategoryRates(d.Get("rate").([]any))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	draft := platform.TaxCategoryDraft{
 		Name:        d.Get("name").(string),
 		Description: stringRef(d.Get("description")),
-		Rates:       emptyTaxRates,
+		Rates:       taxRates,
 	}
 
 	key := stringRef(d.Get("key"))
@@ -60,7 +97,7 @@ func resourceTaxCategoryCreate(ctx context.Context, d *schema.ResourceData, m an
 	}
 
 	var taxCategory *platform.TaxCategory
-	err := retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
+	err = retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
 		var err error
 		taxCategory, err = client.TaxCategories().Post(draft).Execute(ctx)
 		return utils.ProcessRemoteError(err)
@@ -91,6 +128,13 @@ func resourceTaxCategoryRead(ctx context.Context, d *schema.ResourceData, m any)
 	_ = d.Set("key", taxCategory.Key)
 	_ = d.Set("name", taxCategory.Name)
 	_ = d.Set("description", taxCategory.Description)
+
+	// The rates are only read when they are managed by this resource,
+	// otherwise these are managed by the tax category rate resources. All
+	// rates are read, so rates added by other tools show up in the plan
+	if current, ok := d.Get("rate").([]any); ok && len(current) > 0 {
+		_ = d.Set("rate", flattenTaxCategoryRates(taxCategory.Rates, current))
+	}
 	return nil
 }
 
@@ -137,15 +181,28 @@ func resourceTaxCategoryUpdate(ctx context.Context, d *schema.ResourceData, m an
 			&platform.TaxCategorySetDescriptionAction{Description: &newDescription})
 	}
 
-	err = retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
-		_, err := client.TaxCategories().WithId(d.Id()).Post(input).Execute(ctx)
-		return utils.ProcessRemoteError(err)
-	})
-	if err != nil {
-		// Workaround invalid state to be written, see
-		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
-		d.Partial(true)
-		return diag.FromErr(err)
+	var taxRates []platform.TaxRateDraft
+	if d.HasChange("rate") {
+		taxRates, err = expandTaxCategoryRates(d.Get("rate").([]any))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// Rates are matched with the rates fetched above instead of the IDs in
+		// the state, since the ID of a rate changes every time it is replaced
+		input.Actions = append(input.Actions, taxCategoryRateActions(taxCategory.Rates, taxRates)...)
+	}
+
+	if len(input.Actions) > 0 {
+		err = retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
+			_, err := client.TaxCategories().WithId(d.Id()).Post(input).Execute(ctx)
+			return utils.ProcessRemoteError(err)
+		})
+		if err != nil {
+			// Workaround invalid state to be written, see
+			// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceTaxCategoryRead(ctx, d, m)
@@ -154,17 +211,195 @@ func resourceTaxCategoryUpdate(ctx context.Context, d *schema.ResourceData, m an
 func resourceTaxCategoryDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
 
+	if diags := checkDeletionProtection(d, "tax category"); diags.HasError() {
+		return diags
+	}
+
+	var diags diag.Diagnostics
+	if d.Get("force_destroy").(bool) {
+		diags = detachTaxCategory(ctx, client, d.Id())
+		if diags.HasError() {
+			return diags
+		}
+	}
+
 	// Lock to prevent concurrent updates due to Version number conflicts
 	ctMutexKV.Lock(d.Id())
 	defer ctMutexKV.Unlock(d.Id())
 
 	taxCategory, err := client.TaxCategories().WithId(d.Id()).Get().Execute(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 	err = retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
 		_, err = client.TaxCategories().WithId(d.Id()).Delete().Version(taxCategory.Version).Execute(ctx)
 		return utils.ProcessRemoteError(err)
 	})
-	return diag.FromErr(err)
+	return append(diags, diag.FromErr(err)...)
+}
+
+// validateTaxCategoryRates validates that the keys of the rates are unique,
+// and that every country and state has one rate, since the rates are matched
+// by their key or by their country and state
+func validateTaxCategoryRates(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.NewValueKnown("rate") {
+		return nil
+	}
+
+	var keys, locations []string
+	for i, raw := range d.Get("rate").([]any) {
+		rate, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if key, _ := rate["key"].(string); key != "" {
+			if slices.Contains(keys, key) {
+				return fmt.Errorf("rate.%d.key: key %s is used by multiple rates", i, key)
+			}
+			keys = append(keys, key)
+		}
+
+		country, _ := rate["country"].(string)
+		if country == "" {
+			continue
+		}
+		location := country
+		if state, _ := rate["state"].(string); state != "" {
+			location = fmt.Sprintf("%s, %s", state, country)
+		}
+		if slices.Contains(locations, location) {
+			return fmt.Errorf("rate.%d.country: %s has multiple rates, a tax category can have one rate "+
+				"per country and state", i, location)
+		}
+		locations = append(locations, location)
+	}
+	return nil
+}
+
+// describeTaxRate returns the name of the rate with its key, or with its
+// country and state when it has no key, since rates are matched by these
+func describeTaxRate(rate platform.TaxRate) string {
+	location := rate.Country
+	if rate.State != nil && *rate.State != "" {
+		location = fmt.Sprintf("%s, %s", *rate.State, rate.Country)
+	}
+	if rate.Key != nil {
+		return fmt.Sprintf("%s (key %s, %s)", rate.Name, *rate.Key, location)
+	}
+	return fmt.Sprintf("%s (%s)", rate.Name, location)
+}
+
+func expandTaxCategoryRates(input []any) ([]platform.TaxRateDraft, error) {
+	result := []platform.TaxRateDraft{}
+	for _, raw := range input {
+		rate, err := expandTaxRate(raw.(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *rate)
+	}
+	return result, nil
+}
+
+// taxRateMatches returns whether the rate is the rate of the draft. Rates are
+// matched by their key, or by their country and state when they have no key,
+// since the ID of a rate changes when it is replaced.
+func taxRateMatches(rate platform.TaxRate, draft platform.TaxRateDraft) bool {
+	if rate.Key != nil || draft.Key != nil {
+		return rate.Key != nil && draft.Key != nil && *rate.Key == *draft.Key
+	}
+	return rate.Country == draft.Country && taxRateStatesEqual(rate.State, draft.State)
+}
+
+// flattenTaxCategoryRates returns all rates of the tax category, ordered by
+// the matching rate block so reordering by commercetools doesn't result in a
+// diff. Rates without a matching rate block, such as a rate created by
+// commercetools_tax_category_rate, are appended.
+func flattenTaxCategoryRates(rates []platform.TaxRate, current []any) []any {
+	var drafts []platform.TaxRateDraft
+	for _, raw := range current {
+		if rate, ok := raw.(map[string]any); ok {
+			if draft, err := expandTaxRate(rate); err == nil {
+				drafts = append(drafts, *draft)
+			}
+		}
+	}
+
+	order := func(rate platform.TaxRate) int {
+		index := slices.IndexFunc(drafts, func(draft platform.TaxRateDraft) bool {
+			return taxRateMatches(rate, draft)
+		})
+		if index < 0 {
+			return len(drafts)
+		}
+		return index
+	}
+
+	sorted := slices.Clone(rates)
+	slices.SortStableFunc(sorted, func(a, b platform.TaxRate) int {
+		return order(a) - order(b)
+	})
+
+	result := make([]any, 0, len(sorted))
+	for i := range sorted {
+		rate := flattenTaxRate(&sorted[i])
+		rate["id"] = *sorted[i].ID
+		result = append(result, rate)
+	}
+	return result
+}
+
+// taxCategoryRateActions returns the actions to change the rates of the tax
+// category to the desired rates. Rates which are no longer desired are removed
+// first, so a rate for the same country and state can be added in the same
+// update.
+func taxCategoryRateActions(current []platform.TaxRate, desired []platform.TaxRateDraft) []platform.TaxCategoryUpdateAction {
+	var actions []platform.TaxCategoryUpdateAction
+
+	for _, rate := range current {
+		found := slices.ContainsFunc(desired, func(draft platform.TaxRateDraft) bool {
+			return taxRateMatches(rate, draft)
+		})
+		if !found {
+			actions = append(actions, platform.TaxCategoryRemoveTaxRateAction{TaxRateId: rate.ID})
+		}
+	}
+
+	for _, draft := range desired {
+		index := slices.IndexFunc(current, func(rate platform.TaxRate) bool {
+			return taxRateMatches(rate, draft)
+		})
+		if index < 0 {
+			actions = append(actions, platform.TaxCategoryAddTaxRateAction{TaxRate: draft})
+			continue
+		}
+		if taxRateDraftsEqual(createTaxRateDraftFromRate(current[index]), draft) {
+			continue
+		}
+		actions = append(actions, platform.TaxCategoryReplaceTaxRateAction{
+			TaxRateId: current[index].ID,
+			TaxRate:   draft,
+		})
+	}
+	return actions
+}
+
+func createTaxRateDraftFromRate(rate platform.TaxRate) platform.TaxRateDraft {
+	amount := rate.Amount
+	return platform.TaxRateDraft{
+		Key:             rate.Key,
+		Name:            rate.Name,
+		Amount:          &amount,
+		IncludedInPrice: rate.IncludedInPrice,
+		Country:         rate.Country,
+		State:           nilIfEmpty(rate.State),
+		SubRates:        rate.SubRates,
+	}
+}
+
+func taxRateDraftsEqual(a, b platform.TaxRateDraft) bool {
+	if len(a.SubRates) == 0 && len(b.SubRates) == 0 {
+		a.SubRates, b.SubRates = nil, nil
+	}
+	return reflect.DeepEqual(a, b)
 }
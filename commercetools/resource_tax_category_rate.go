@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"slices"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -24,63 +25,77 @@ func resourceTaxCategoryRate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceTaxCategoryRateImportState,
 		},
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: validateCountries("country"),
+		Schema: taxRateSchema(map[string]*schema.Schema{
 			"tax_category_id": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"key": {
-				Description: "User-specific unique identifier for the tax category rate",
-				Type:        schema.TypeString,
-				Optional:    true,
-			},
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"amount": {
-				Description: "Number Percentage in the range of [0..1]. The sum of the amounts of all subRates, " +
-					"if there are any",
-				Type:         schema.TypeFloat,
-				Optional:     true,
-				ValidateFunc: validateTaxRateAmount,
-			},
-			"included_in_price": {
-				Type:     schema.TypeBool,
-				Required: true,
-			},
-			"country": {
-				Description: "A two-digit country code as per [ISO 3166-1 alpha-2](https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2)",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"state": {
-				Description: "The state in the country",
-				Type:        schema.TypeString,
-				Optional:    true,
-			},
-			"sub_rate": {
-				Description: "For countries (for example the US) where the total tax is a combination of multiple " +
-					"taxes (for example state and local taxes)",
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"amount": {
-							Description:  "Number Percentage in the range of [0..1]",
-							Type:         schema.TypeFloat,
-							Required:     true,
-							ValidateFunc: validateTaxRateAmount,
-						},
+		}),
+	}
+}
+
+// taxRateSchema adds the fields of a tax rate to the schema. These are shared
+// by the tax category rate resource and the rate blocks of the tax category
+// resource.
+func taxRateSchema(result map[string]*schema.Schema) map[string]*schema.Schema {
+	fields := map[string]*schema.Schema{
+		"key": {
+			Description: "User-specific unique identifier for the tax category rate. The rate is identified by " +
+				"its key when set, so it is found again after its ID changed",
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"amount": {
+			Description: "Number Percentage in the range of [0..1]. The sum of the amounts of all subRates, " +
+				"if there are any",
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			ValidateFunc: validateTaxRateAmount,
+		},
+		"included_in_price": {
+			Type:     schema.TypeBool,
+			Required: true,
+		},
+		"country": {
+			Description: "A two-digit country code as per [ISO 3166-1 alpha-2](https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2)",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"state": {
+			Description: "The state in the country",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"sub_rate": {
+			Description: "For countries (for example the US) where the total tax is a combination of multiple " +
+				"taxes (for example state and local taxes)",
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"amount": {
+						Description:  "Number Percentage in the range of [0..1]",
+						Type:         schema.TypeFloat,
+						Required:     true,
+						ValidateFunc: validateTaxRateAmount,
 					},
 				},
 			},
 		},
 	}
+	for key, value := range fields {
+		result[key] = value
+	}
+	return result
 }
 
 func resourceTaxCategoryRateImportState(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
@@ -150,6 +165,10 @@ func resourceTaxCategoryRateCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := checkTaxCategoryRateConflict(taxCategory, *taxRateDraft); err != nil {
+		return diag.FromErr(err)
+	}
+
 	input.Actions = append(input.Actions, platform.TaxCategoryAddTaxRateAction{TaxRate: *taxRateDraft})
 
 	err = retry.RetryContext(ctx, 30*time.Second, func() *retry.RetryError {
@@ -164,8 +183,11 @@ func resourceTaxCategoryRateCreate(ctx context.Context, d *schema.ResourceData,
 	// Refresh the taxCategory. When a tax rate is added the ID is different
 	// from the ID returned in the response
 	updatedTaxCategory, err := client.TaxCategories().WithId(taxCategoryID).Get().Execute(ctx)
-	newTaxRate := findNewTaxRate(updatedTaxCategory, oldTaxRateIds)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
+	newTaxRate := findNewTaxRate(updatedTaxCategory, oldTaxRateIds, taxRateDraft)
 	if newTaxRate == nil {
 		return diag.Errorf("No tax category rate created?")
 	}
@@ -177,10 +199,14 @@ func resourceTaxCategoryRateCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceTaxCategoryRateRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	_, taxRate, err := readResourcesFromStateIDs(ctx, d, m)
+	taxCategory, taxRate, err := readResourcesFromStateIDs(ctx, d, m)
 
 	if err != nil {
+		taxRateID := d.Id()
 		d.SetId("")
+		if taxCategory != nil {
+			return removedTaxRateDiagnostics(taxCategory.ID, taxRateID)
+		}
 		return nil
 	}
 
@@ -190,21 +216,9 @@ func resourceTaxCategoryRateRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func setTaxRateState(d *schema.ResourceData, taxRate *platform.TaxRate) {
-	_ = d.Set("key", taxRate.Key)
-	_ = d.Set("name", taxRate.Name)
-	_ = d.Set("amount", taxRate.Amount)
-	_ = d.Set("included_in_price", taxRate.IncludedInPrice)
-	_ = d.Set("country", taxRate.Country)
-	_ = d.Set("state", taxRate.State)
-
-	subRateData := make([]map[string]any, len(taxRate.SubRates))
-	for srIndex, subRate := range taxRate.SubRates {
-		subRateData[srIndex] = map[string]any{
-			"name":   subRate.Name,
-			"amount": subRate.Amount,
-		}
+	for key, value := range flattenTaxRate(taxRate) {
+		_ = d.Set(key, value)
 	}
-	_ = d.Set("sub_rate", subRateData)
 }
 
 func resourceTaxCategoryRateUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -229,20 +243,25 @@ func resourceTaxCategoryRateUpdate(ctx context.Context, d *schema.ResourceData,
 		Actions: []platform.TaxCategoryUpdateAction{},
 	}
 
+	taxRateDraft, err := createTaxRateDraft(d)
+	if err != nil {
+		// Workaround invalid state to be written, see
+		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
+		d.Partial(true)
+		return diag.FromErr(err)
+	}
+
 	if d.HasChange("key") || d.HasChange("name") || d.HasChange("amount") || d.HasChange("included_in_price") || d.HasChange("country") || d.HasChange("state") || d.HasChange("sub_rate") {
-		taxRateDraft, err := createTaxRateDraft(d)
-		if err != nil {
-			// Workaround invalid state to be written, see
-			// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
-			d.Partial(true)
-			return diag.FromErr(err)
-		}
 		input.Actions = append(input.Actions, platform.TaxCategoryReplaceTaxRateAction{
 			TaxRateId: stringRef(d.Id()),
 			TaxRate:   *taxRateDraft,
 		})
 	}
 
+	if len(input.Actions) == 0 {
+		return resourceTaxCategoryRateRead(ctx, d, m)
+	}
+
 	client := getClient(m)
 	err = retry.RetryContext(ctx, 30*time.Second, func() *retry.RetryError {
 		_, err := client.TaxCategories().WithId(taxCategory.ID).Post(input).Execute(ctx)
@@ -265,7 +284,7 @@ func resourceTaxCategoryRateUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	newTaxRate := findNewTaxRate(updatedTaxCategory, oldTaxRateIds)
+	newTaxRate := findNewTaxRate(updatedTaxCategory, oldTaxRateIds, taxRateDraft)
 	if newTaxRate == nil {
 		// Workaround invalid state to be written, see
 		// https://github.com/hashicorp/terraform-plugin-sdk/issues/476
@@ -279,40 +298,82 @@ func resourceTaxCategoryRateUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func createTaxRateDraft(d *schema.ResourceData) (*platform.TaxRateDraft, error) {
+	return expandTaxRate(map[string]any{
+		"key":               d.Get("key"),
+		"name":              d.Get("name"),
+		"amount":            d.Get("amount"),
+		"included_in_price": d.Get("included_in_price"),
+		"country":           d.Get("country"),
+		"state":             d.Get("state"),
+		"sub_rate":          d.Get("sub_rate"),
+	})
+}
+
+// expandTaxRate returns the tax rate draft of a tax category rate resource or
+// a rate block of a tax category
+func expandTaxRate(input map[string]any) (*platform.TaxRateDraft, error) {
 	var subRates []platform.SubRate
 	var err error
-	if subRateRaw, ok := d.GetOk("sub_rate"); ok {
-		subRates, err = resourceTaxCategoryRateGetSubRates(subRateRaw.([]any))
+	if subRateRaw, ok := input["sub_rate"].([]any); ok && len(subRateRaw) > 0 {
+		subRates, err = resourceTaxCategoryRateGetSubRates(subRateRaw)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	var key *string
-	if value, ok := d.Get("key").(string); ok && value != "" {
+	if value, ok := input["key"].(string); ok && value != "" {
 		key = &value
 	}
 
 	var countryCode string
-	if value, ok := d.Get("country").(string); ok {
+	if value, ok := input["country"].(string); ok {
 		countryCode = value
 	}
 
-	amountRaw := d.Get("amount").(float64)
+	amountRaw, _ := input["amount"].(float64)
 
 	taxRateDraft := platform.TaxRateDraft{
 		Key:             key,
-		Name:            d.Get("name").(string),
+		Name:            input["name"].(string),
 		Amount:          &amountRaw,
-		IncludedInPrice: d.Get("included_in_price").(bool),
+		IncludedInPrice: input["included_in_price"].(bool),
 		Country:         countryCode,
-		State:           stringRef(d.Get("state")),
+		State:           nilIfEmpty(stringRef(input["state"])),
 		SubRates:        subRates,
 	}
 
 	return &taxRateDraft, nil
 }
 
+// flattenTaxRate returns the state of a tax rate, see expandTaxRate
+func flattenTaxRate(taxRate *platform.TaxRate) map[string]any {
+	subRateData := make([]any, len(taxRate.SubRates))
+	for srIndex, subRate := range taxRate.SubRates {
+		subRateData[srIndex] = map[string]any{
+			"name":   subRate.Name,
+			"amount": subRate.Amount,
+		}
+	}
+
+	result := map[string]any{
+		"key":               "",
+		"name":              taxRate.Name,
+		"amount":            taxRate.Amount,
+		"included_in_price": taxRate.IncludedInPrice,
+		"country":           taxRate.Country,
+		"state":             "",
+		"sub_rate":          subRateData,
+	}
+	if taxRate.Key != nil {
+		result["key"] = *taxRate.Key
+	}
+	if taxRate.State != nil {
+		result["state"] = *taxRate.State
+	}
+	return result
+}
+
 func resourceTaxCategoryRateDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	taxCategoryID := d.Get("tax_category_id").(string)
 
@@ -356,7 +417,17 @@ func readResourcesFromStateIDs(ctx context.Context, d *schema.ResourceData, m an
 
 	taxRate := getTaxRateWithID(taxCategory, taxRateID)
 	if taxRate == nil {
-		return nil, nil, fmt.Errorf("could not find tax rate %s in tax category %s", taxRateID, taxCategory.ID)
+		// The ID of the rate changes when it is replaced, e.g. by another
+		// tool. Rates with a key are found again by their key.
+		if key := d.Get("key").(string); key != "" {
+			taxRate = getTaxRateWithKey(taxCategory, key)
+		}
+		if taxRate != nil {
+			d.SetId(*taxRate.ID)
+		}
+	}
+	if taxRate == nil {
+		return taxCategory, nil, fmt.Errorf("could not find tax rate %s in tax category %s", taxRateID, taxCategory.ID)
 	}
 	return taxCategory, taxRate, nil
 }
@@ -378,16 +449,38 @@ func getTaxRateIds(taxCategory *platform.TaxCategory) []string {
 	return taxRateIds
 }
 
-// Find new tax rate by comparing with tax rate ids created just before adding new tax rate
-func findNewTaxRate(taxCategory *platform.TaxCategory, oldTaxRateIds []string) *platform.TaxRate {
+// findNewTaxRate returns the tax rate which was added or replaced with the
+// draft. Rates with a key are found by their key. Other rates are found by
+// comparing with the tax rate IDs from before the update, and by their country
+// and state, so rates which are added concurrently aren't mixed up.
+func findNewTaxRate(taxCategory *platform.TaxCategory, oldTaxRateIds []string, draft *platform.TaxRateDraft) *platform.TaxRate {
+	if draft.Key != nil {
+		return getTaxRateWithKey(taxCategory, *draft.Key)
+	}
 	for _, taxRate := range taxCategory.Rates {
-		if !stringInSlice(*taxRate.ID, oldTaxRateIds) {
+		if stringInSlice(*taxRate.ID, oldTaxRateIds) {
+			continue
+		}
+		if taxRate.Country == draft.Country && taxRateStatesEqual(taxRate.State, draft.State) {
 			return &taxRate
 		}
 	}
 	return nil
 }
 
+func taxRateStatesEqual(a, b *string) bool {
+	return (a == nil || *a == "") && (b == nil || *b == "") || a != nil && b != nil && *a == *b
+}
+
+func getTaxRateWithKey(taxCategory *platform.TaxCategory, key string) *platform.TaxRate {
+	for _, rate := range taxCategory.Rates {
+		if rate.Key != nil && *rate.Key == key {
+			return &rate
+		}
+	}
+	return nil
+}
+
 func getTaxRateWithID(taxCategory *platform.TaxCategory, taxRateID string) *platform.TaxRate {
 	for _, rate := range taxCategory.Rates {
 		if *rate.ID == taxRateID {
@@ -408,3 +501,34 @@ func findTaxRate(taxRateID string, taxCategories []platform.TaxCategory) (*platf
 	}
 	return nil, nil
 }
+
+// checkTaxCategoryRateConflict returns an error when the tax category already
+// has a rate with the key of the draft, or for its country and state. This is
+// the case when the rates are managed by the rate blocks of
+// commercetools_tax_category, which remove the rates of this resource.
+func checkTaxCategoryRateConflict(taxCategory *platform.TaxCategory, draft platform.TaxRateDraft) error {
+	index := slices.IndexFunc(taxCategory.Rates, func(rate platform.TaxRate) bool {
+		return taxRateMatches(rate, draft)
+	})
+	if index < 0 {
+		return nil
+	}
+	return fmt.Errorf("tax category %s already has the rate %s. When the rates are managed by the rate "+
+		"blocks of commercetools_tax_category, move this rate to a rate block, since the rate blocks "+
+		"remove the rates of commercetools_tax_category_rate",
+		taxCategory.ID, describeTaxRate(taxCategory.Rates[index]))
+}
+
+// removedTaxRateDiagnostics returns a warning for a rate which was removed
+// from its tax category, after which the rate is created again
+func removedTaxRateDiagnostics(taxCategoryID, taxRateID string) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Tax rate %s was removed from tax category %s", taxRateID, taxCategoryID),
+			Detail: "The rate is created again. Rates are removed by the rate blocks of " +
+				"commercetools_tax_category, which can't be combined with commercetools_tax_category_rate " +
+				"for the same tax category.",
+		},
+	}
+}
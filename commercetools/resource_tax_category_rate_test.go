@@ -8,6 +8,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccTaxCategoryRate_createAndUpdateWithID(t *testing.T) {
@@ -225,6 +227,49 @@ func testAccTaxCategoryRateDualUpdateConfig(description string, name string, amo
 	})
 }
 
+func TestFindNewTaxRate(t *testing.T) {
+	oldID, newNLID, newDEID := "rate-old", "rate-nl", "rate-de"
+	key := "de"
+	taxCategory := &platform.TaxCategory{
+		Rates: []platform.TaxRate{
+			{ID: &oldID, Country: "DE"},
+			{ID: &newNLID, Country: "NL"},
+			{ID: &newDEID, Key: &key, Country: "DE"},
+		},
+	}
+
+	// A rate which is added concurrently isn't mistaken for the new rate
+	rate := findNewTaxRate(taxCategory, []string{oldID}, &platform.TaxRateDraft{Country: "DE"})
+	assert.Equal(t, &newDEID, rate.ID)
+
+	rate = findNewTaxRate(taxCategory, nil, &platform.TaxRateDraft{Key: &key, Country: "DE"})
+	assert.Equal(t, &newDEID, rate.ID)
+
+	assert.Nil(t, findNewTaxRate(taxCategory, []string{oldID, newNLID, newDEID}, &platform.TaxRateDraft{Country: "NL"}))
+}
+
+func TestCheckTaxCategoryRateConflict(t *testing.T) {
+	deID, nlID := "rate-de", "rate-nl"
+	deKey := "de"
+	taxCategory := &platform.TaxCategory{
+		ID: "tax-category",
+		Rates: []platform.TaxRate{
+			{ID: &deID, Key: &deKey, Name: "Germany", Amount: 0.19, IncludedInPrice: true, Country: "DE"},
+			{ID: &nlID, Name: "Netherlands", Amount: 0.21, IncludedInPrice: true, Country: "NL"},
+		},
+	}
+
+	err := checkTaxCategoryRateConflict(taxCategory, platform.TaxRateDraft{Name: "Netherlands", Country: "NL"})
+	assert.EqualError(t, err, "tax category tax-category already has the rate Netherlands (NL). When the rates "+
+		"are managed by the rate blocks of commercetools_tax_category, move this rate to a rate block, since "+
+		"the rate blocks remove the rates of commercetools_tax_category_rate")
+
+	err = checkTaxCategoryRateConflict(taxCategory, platform.TaxRateDraft{Key: &deKey, Name: "Germany", Country: "DE"})
+	assert.ErrorContains(t, err, "already has the rate Germany (key de, DE)")
+
+	assert.NoError(t, checkTaxCategoryRateConflict(taxCategory, platform.TaxRateDraft{Name: "Belgium", Country: "BE"}))
+}
+
 func testAccCheckTaxCategoryRateDestroy(s *terraform.State) error {
 	client := getClient(testAccProvider.Meta())
 	var rateIDs []string
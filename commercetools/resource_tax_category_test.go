@@ -7,6 +7,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 func TestAccTaxCategory_createAndUpdateWithID(t *testing.T) {
@@ -59,6 +64,215 @@ func testAccTaxCategoryConfig(name, key, description string) string {
 
 }
 
+func TestAccTaxCategory_rates(t *testing.T) {
+	resourceName := "commercetools_tax_category.standard"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckTaxCategoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTaxCategoryRatesConfig(0.19, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rate.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rate.0.key", "de"),
+					resource.TestCheckResourceAttr(resourceName, "rate.0.amount", "0.19"),
+					resource.TestCheckResourceAttrSet(resourceName, "rate.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "rate.1.country", "NL"),
+					resource.TestCheckResourceAttr(resourceName, "rate.1.sub_rate.#", "0"),
+				),
+			},
+			{
+				Config: testAccTaxCategoryRatesConfig(0.16, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rate.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rate.0.key", "de"),
+					resource.TestCheckResourceAttr(resourceName, "rate.0.amount", "0.16"),
+					resource.TestCheckResourceAttr(resourceName, "rate.1.country", "US"),
+					resource.TestCheckResourceAttr(resourceName, "rate.1.state", "NY"),
+					resource.TestCheckResourceAttr(resourceName, "rate.1.sub_rate.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTaxCategoryRatesConfig(amount float64, replaceNL bool) string {
+	return hclTemplate(`
+		resource "commercetools_tax_category" "standard" {
+			name = "test-rates-category"
+			key  = "test-rates-category"
+
+			rate {
+				key               = "de"
+				name              = "Germany"
+				amount            = {{ .amount }}
+				included_in_price = true
+				country           = "DE"
+			}
+
+			{{ if .replaceNL }}
+			rate {
+				name              = "New York"
+				amount            = 0.08
+				included_in_price = false
+				country           = "US"
+				state             = "NY"
+
+				sub_rate {
+					name   = "State"
+					amount = 0.04
+				}
+				sub_rate {
+					name   = "City"
+					amount = 0.04
+				}
+			}
+			{{ else }}
+			rate {
+				name              = "Netherlands"
+				amount            = 0.21
+				included_in_price = true
+				country           = "NL"
+			}
+			{{ end }}
+		}
+	`, map[string]any{
+		"amount":    amount,
+		"replaceNL": replaceNL,
+	})
+}
+
+func TestValidateTaxCategoryRates(t *testing.T) {
+	rate := func(key, country, state string) map[string]any {
+		return map[string]any{
+			"key":               key,
+			"name":              "rate",
+			"amount":            0.2,
+			"included_in_price": true,
+			"country":           country,
+			"state":             state,
+		}
+	}
+
+	cases := []struct {
+		name  string
+		rates []any
+		error string
+	}{
+		{
+			name:  "valid",
+			rates: []any{rate("de", "DE", ""), rate("", "US", "NY"), rate("", "US", "CA")},
+		},
+		{
+			name:  "duplicate key",
+			rates: []any{rate("standard", "DE", ""), rate("standard", "NL", "")},
+			error: "rate.1.key: key standard is used by multiple rates",
+		},
+		{
+			name:  "duplicate country",
+			rates: []any{rate("", "US", "NY"), rate("", "DE", ""), rate("", "US", "NY")},
+			error: "rate.2.country: NY, US has multiple rates, a tax category can have one rate per country and state",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]any{
+				"name": "standard",
+				"rate": c.rates,
+			})
+			_, err := resourceTaxCategory().Diff(context.Background(), nil, config, &utils.ProviderData{})
+			if c.error == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, c.error)
+		})
+	}
+}
+
+func TestTaxCategoryRateActions(t *testing.T) {
+	deID, nlID, nyID := "rate-de", "rate-nl", "rate-ny"
+	deKey := "de"
+	ny := "NY"
+
+	current := []platform.TaxRate{
+		{ID: &deID, Key: &deKey, Name: "Germany", Amount: 0.19, IncludedInPrice: true, Country: "DE", SubRates: []platform.SubRate{}},
+		{ID: &nlID, Name: "Netherlands", Amount: 0.21, IncludedInPrice: true, Country: "NL"},
+		{ID: &nyID, Name: "New York", Amount: 0.08, Country: "US", State: &ny},
+	}
+
+	rate := func(key *string, name string, amount float64, includedInPrice bool, country string, state *string) platform.TaxRateDraft {
+		return platform.TaxRateDraft{
+			Key:             key,
+			Name:            name,
+			Amount:          &amount,
+			IncludedInPrice: includedInPrice,
+			Country:         country,
+			State:           state,
+		}
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		desired := []platform.TaxRateDraft{
+			rate(nil, "New York", 0.08, false, "US", &ny),
+			rate(&deKey, "Germany", 0.19, true, "DE", nil),
+			rate(nil, "Netherlands", 0.21, true, "NL", nil),
+		}
+		assert.Empty(t, taxCategoryRateActions(current, desired))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		changedDE := rate(&deKey, "Germany", 0.16, true, "DE", nil)
+		// The NL rate without a key doesn't match the NL rate with a key
+		nlKey := "nl"
+		keyedNL := rate(&nlKey, "Netherlands", 0.21, true, "NL", nil)
+		be := rate(nil, "Belgium", 0.21, true, "BE", nil)
+
+		expected := []platform.TaxCategoryUpdateAction{
+			platform.TaxCategoryRemoveTaxRateAction{TaxRateId: &nlID},
+			platform.TaxCategoryRemoveTaxRateAction{TaxRateId: &nyID},
+			platform.TaxCategoryReplaceTaxRateAction{TaxRateId: &deID, TaxRate: changedDE},
+			platform.TaxCategoryAddTaxRateAction{TaxRate: keyedNL},
+			platform.TaxCategoryAddTaxRateAction{TaxRate: be},
+		}
+		assert.Equal(t, expected, taxCategoryRateActions(current, []platform.TaxRateDraft{changedDE, keyedNL, be}))
+	})
+}
+
+func TestFlattenTaxCategoryRates(t *testing.T) {
+	deID, nlID, beID := "rate-de", "rate-nl", "rate-be"
+	deKey := "de"
+	rates := []platform.TaxRate{
+		{ID: &nlID, Name: "Netherlands", Amount: 0.21, IncludedInPrice: true, Country: "NL"},
+		{ID: &beID, Name: "Belgium", Amount: 0.21, IncludedInPrice: true, Country: "BE"},
+		{ID: &deID, Key: &deKey, Name: "Germany", Amount: 0.19, IncludedInPrice: true, Country: "DE"},
+	}
+	current := []any{
+		map[string]any{"key": "de", "name": "Germany", "included_in_price": true, "country": "DE"},
+		map[string]any{"name": "Netherlands", "included_in_price": true, "country": "NL"},
+	}
+
+	// The BE rate has no rate block, it is added at the end so the plan
+	// removes it
+	result := flattenTaxCategoryRates(rates, current)
+	require.Len(t, result, 3)
+	assert.Equal(t, map[string]any{
+		"id":                "rate-de",
+		"key":               "de",
+		"name":              "Germany",
+		"amount":            0.19,
+		"included_in_price": true,
+		"country":           "DE",
+		"state":             "",
+		"sub_rate":          []any{},
+	}, result[0])
+	assert.Equal(t, "rate-nl", result[1].(map[string]any)["id"])
+	assert.Equal(t, "rate-be", result[2].(map[string]any)["id"])
+}
+
 func testAccCheckTaxCategoryDestroy(s *terraform.State) error {
 	client := getClient(testAccProvider.Meta())
 
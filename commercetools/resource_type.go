@@ -115,13 +115,56 @@ func resourceType() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"migration_warnings": migrationWarningsSchema(),
+			"check_data_loss":    checkDataLossSchema(),
+			"fail_on_data_loss":  failOnDataLossSchema(),
 		},
-		CustomizeDiff: customdiff.ValidateChange("field", func(ctx context.Context, old, new, meta any) error {
-			return resourceTypeValidateField(old.([]any), new.([]any))
-		}),
+		CustomizeDiff: customdiff.All(
+			customdiff.ValidateChange("field", func(ctx context.Context, old, new, meta any) error {
+				return resourceTypeValidateField(old.([]any), new.([]any))
+			}),
+			resourceTypeDataLossWarnings,
+			validateLocalizedStrings(
+				"name",
+				"description",
+				"field.*.label",
+				"field.*.type.*.localized_value.*.label",
+				"field.*.type.*.element_type.*.localized_value.*.label",
+			),
+		),
 	}
 }
 
+// resourceTypeDataLossWarnings reports the resources which lose the values of
+// removed fields when check_data_loss or fail_on_data_loss is set.
+func resourceTypeDataLossWarnings(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if !d.HasChange("field") {
+		return setMigrationWarnings(d, nil)
+	}
+
+	old, new := d.GetChange("field")
+	newLookup := createLookup(new.([]any), "name")
+	var removedFields []string
+	for _, field := range old.([]any) {
+		name := field.(map[string]any)["name"].(string)
+		if _, ok := newLookup[name]; !ok {
+			removedFields = append(removedFields, name)
+		}
+	}
+
+	// Query the resource types the fields were available on
+	oldResourceTypeIDs, _ := d.GetChange("resource_type_ids")
+	queries := customFieldDataLossQueries(d.Id(), expandStringArray(oldResourceTypeIDs.([]any)), removedFields)
+	warnings, err := checkDataLoss(ctx, d, m, queries)
+	if err != nil {
+		return err
+	}
+	return setMigrationWarnings(d, warnings)
+}
+
 func resourceTypeCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	client := getClient(m)
 
@@ -178,6 +221,7 @@ func resourceTypeRead(ctx context.Context, d *schema.ResourceData, m any) diag.D
 
 	if err != nil {
 		if utils.IsResourceNotFoundError(err) {
+			getTypeCache(m).Invalidate(d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -187,6 +231,8 @@ func resourceTypeRead(ctx context.Context, d *schema.ResourceData, m any) diag.D
 	if ctType == nil {
 		d.SetId("")
 	} else {
+		getTypeCache(m).Set(ctType)
+
 		_ = d.Set("version", ctType.Version)
 		_ = d.Set("key", ctType.Key)
 		_ = d.Set("name", ctType.Name)
@@ -194,10 +240,12 @@ func resourceTypeRead(ctx context.Context, d *schema.ResourceData, m any) diag.D
 		_ = d.Set("resource_type_ids", ctType.ResourceTypeIds)
 
 		if fields, err := flattenTypeFields(ctType); err == nil {
+			mergeTypeFieldEnumKeyHints(fields, d.Get("field").([]any))
 			_ = d.Set("field", fields)
 		} else {
 			return diag.FromErr(err)
 		}
+		_ = d.Set("migration_warnings", []string{})
 	}
 	return nil
 }
@@ -244,6 +292,10 @@ func resourceTypeUpdate(ctx context.Context, d *schema.ResourceData, m any) diag
 		input.Actions = append(input.Actions, fieldChangeActions...)
 	}
 
+	// Make sure resources using this type never encode their custom fields
+	// against the old definition, even when the update fails halfway.
+	getTypeCache(m).Invalidate(d.Id())
+
 	err := retry.RetryContext(ctx, 1*time.Minute, func() *retry.RetryError {
 		_, err := client.Types().WithId(d.Id()).Post(input).Execute(ctx)
 		return utils.ProcessRemoteError(err)
@@ -265,6 +317,7 @@ func resourceTypeDelete(ctx context.Context, d *schema.ResourceData, m any) diag
 		_, err := client.Types().WithId(d.Id()).Delete().Version(version).Execute(ctx)
 		return utils.ProcessRemoteError(err)
 	})
+	getTypeCache(m).Invalidate(d.Id())
 	return diag.FromErr(err)
 }
 
@@ -274,6 +327,10 @@ func resourceTypeValidateField(old, new []any) error {
 	for _, field := range new {
 		newF := field.(map[string]any)
 		name := newF["name"].(string)
+		if err := validateEnumKeyHints(name, newF["type"].([]any)); err != nil {
+			return err
+		}
+
 		oldF, ok := oldLookup[name].(map[string]any)
 		if !ok {
 			continue
@@ -335,6 +392,7 @@ func localizedValueElement() *schema.Resource {
 				ValidateDiagFunc: validateLocalizedStringKey,
 				Required:         true,
 			},
+			"previous_key": enumPreviousKeySchema(),
 		},
 	}
 }
@@ -350,6 +408,7 @@ func valueElement() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"previous_key": enumPreviousKeySchema(),
 		},
 	}
 }
@@ -561,6 +620,22 @@ func flattenTypeFields(t *platform.Type) ([]map[string]any, error) {
 	return fields, nil
 }
 
+// mergeTypeFieldEnumKeyHints copies the previous_key hints of enum values
+// from the current fields to the flattened fields and removes the values
+// which were replaced by a renamed value.
+func mergeTypeFieldEnumKeyHints(fields []map[string]any, current []any) {
+	lookup := createLookup(current, "name")
+	for _, field := range fields {
+		existing, ok := lookup[field["name"].(string)].(map[string]any)
+		if !ok {
+			continue
+		}
+		if fieldType, ok := field["type"].([]any); ok {
+			mergeEnumKeyHints(fieldType, existing["type"].([]any), true)
+		}
+	}
+}
+
 func flattenTypeFieldType(fieldType platform.FieldType, setsAllowed bool) ([]any, error) {
 	typeData := make(map[string]any)
 
@@ -656,6 +731,9 @@ func resourceTypeFieldChangeActions(oldValues []any, newValues []any) ([]platfor
 		return nil, err
 	}
 
+	oldHints := typeFieldEnumKeyHints(oldValues)
+	newHints := typeFieldEnumKeyHints(newValues)
+
 	// Create a copy of the field order for commercetools. When we
 	// delete fields commercetools already re-orders the fields, and we need
 	// to not send a reorder command when the order already matches
@@ -719,7 +797,7 @@ func resourceTypeFieldChangeActions(oldValues []any, newValues []any) ([]platfor
 
 		case platform.CustomFieldLocalizedEnumType:
 			ot := oldField.Type.(platform.CustomFieldLocalizedEnumType)
-			subActions, err := updateCustomFieldLocalizedEnumType(name, ot, t)
+			subActions, err := updateCustomFieldLocalizedEnumType(name, ot, t, oldHints[name], newHints[name])
 			if err != nil {
 				return nil, err
 			}
@@ -727,7 +805,7 @@ func resourceTypeFieldChangeActions(oldValues []any, newValues []any) ([]platfor
 
 		case platform.CustomFieldEnumType:
 			ot := oldField.Type.(platform.CustomFieldEnumType)
-			subActions, err := updateCustomFieldEnumType(name, ot, t)
+			subActions, err := updateCustomFieldEnumType(name, ot, t, oldHints[name], newHints[name])
 			if err != nil {
 				return nil, err
 			}
@@ -745,7 +823,7 @@ func resourceTypeFieldChangeActions(oldValues []any, newValues []any) ([]platfor
 
 			case platform.CustomFieldEnumType:
 				ost := ot.ElementType.(platform.CustomFieldEnumType)
-				subActions, err := updateCustomFieldEnumType(name, ost, st)
+				subActions, err := updateCustomFieldEnumType(name, ost, st, oldHints[name], newHints[name])
 				if err != nil {
 					return nil, err
 				}
@@ -753,7 +831,7 @@ func resourceTypeFieldChangeActions(oldValues []any, newValues []any) ([]platfor
 
 			case platform.CustomFieldLocalizedEnumType:
 				ost := ot.ElementType.(platform.CustomFieldLocalizedEnumType)
-				subActions, err := updateCustomFieldLocalizedEnumType(name, ost, st)
+				subActions, err := updateCustomFieldLocalizedEnumType(name, ost, st, oldHints[name], newHints[name])
 				if err != nil {
 					return nil, err
 				}
@@ -774,7 +852,20 @@ func resourceTypeFieldChangeActions(oldValues []any, newValues []any) ([]platfor
 	return actions, nil
 }
 
-func updateCustomFieldEnumType(fieldName string, old, new platform.CustomFieldEnumType) ([]platform.TypeUpdateAction, error) {
+// typeFieldEnumKeyHints returns the previous_key hints of the enum values of
+// the given raw fields, by field name.
+func typeFieldEnumKeyHints(values []any) map[string]map[string]string {
+	hints := map[string]map[string]string{}
+	for _, raw := range values {
+		field := raw.(map[string]any)
+		if fieldType, ok := field["type"].([]any); ok {
+			hints[field["name"].(string)] = enumKeyHints(fieldType)
+		}
+	}
+	return hints
+}
+
+func updateCustomFieldEnumType(fieldName string, old, new platform.CustomFieldEnumType, oldHints, newHints map[string]string) ([]platform.TypeUpdateAction, error) {
 	oldValues := orderedmap.NewOrderedMap[string, platform.CustomFieldEnumValue]()
 	for i := range old.Values {
 		oldValues.Set(old.Values[i].Key, old.Values[i])
@@ -785,6 +876,13 @@ func updateCustomFieldEnumType(fieldName string, old, new platform.CustomFieldEn
 		newValues.Set(new.Values[i].Key, new.Values[i])
 	}
 
+	// Renamed values are added with their new key, the values with the
+	// previous key are kept in commercetools but are no longer managed
+	retiredKeys := retiredEnumKeys(oldValues.Keys(), newValues.Keys(), oldHints, newHints)
+	for _, key := range retiredKeys {
+		oldValues.Delete(key)
+	}
+
 	for _, oldValue := range oldValues.Keys() {
 		if !newValues.Has(oldValue) {
 			return nil, fmt.Errorf("trying to delete enum value %s. Deleting enum values is not supported", oldValue)
@@ -830,7 +928,7 @@ func updateCustomFieldEnumType(fieldName string, old, new platform.CustomFieldEn
 			actions,
 			platform.TypeChangeEnumValueOrderAction{
 				FieldName: fieldName,
-				Keys:      newValues.Keys(),
+				Keys:      append(newValues.Keys(), retiredKeys...),
 			})
 
 	}
@@ -838,7 +936,7 @@ func updateCustomFieldEnumType(fieldName string, old, new platform.CustomFieldEn
 	return actions, nil
 }
 
-func updateCustomFieldLocalizedEnumType(fieldName string, old, new platform.CustomFieldLocalizedEnumType) ([]platform.TypeUpdateAction, error) {
+func updateCustomFieldLocalizedEnumType(fieldName string, old, new platform.CustomFieldLocalizedEnumType, oldHints, newHints map[string]string) ([]platform.TypeUpdateAction, error) {
 	oldValues := orderedmap.NewOrderedMap[string, platform.CustomFieldLocalizedEnumValue]()
 	for i := range old.Values {
 		oldValues.Set(old.Values[i].Key, old.Values[i])
@@ -849,6 +947,13 @@ func updateCustomFieldLocalizedEnumType(fieldName string, old, new platform.Cust
 		newValues.Set(new.Values[i].Key, new.Values[i])
 	}
 
+	// Renamed values are added with their new key, the values with the
+	// previous key are kept in commercetools but are no longer managed
+	retiredKeys := retiredEnumKeys(oldValues.Keys(), newValues.Keys(), oldHints, newHints)
+	for _, key := range retiredKeys {
+		oldValues.Delete(key)
+	}
+
 	var valueOrder []string
 	valueOrder = append(valueOrder, oldValues.Keys()...)
 
@@ -888,7 +993,7 @@ func updateCustomFieldLocalizedEnumType(fieldName string, old, new platform.Cust
 			actions,
 			platform.TypeChangeLocalizedEnumValueOrderAction{
 				FieldName: fieldName,
-				Keys:      newValues.Keys(),
+				Keys:      append(newValues.Keys(), retiredKeys...),
 			})
 
 	}
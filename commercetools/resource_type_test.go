@@ -208,7 +208,7 @@ func TestUpdateCustomFieldEnumTypeAdd(t *testing.T) {
 		},
 	}
 
-	actions, err := updateCustomFieldEnumType("test", oldType, newType)
+	actions, err := updateCustomFieldEnumType("test", oldType, newType, nil, nil)
 	assert.NoError(t, err)
 	assert.Len(t, actions, 1)
 }
@@ -227,7 +227,7 @@ func TestUpdateCustomFieldEnumTypeDelete(t *testing.T) {
 		},
 	}
 
-	_, err := updateCustomFieldEnumType("test", oldType, newType)
+	_, err := updateCustomFieldEnumType("test", oldType, newType, nil, nil)
 	assert.ErrorContains(t, err, "trying to delete enum value value2. Deleting enum values is not supported")
 }
 
@@ -247,10 +247,162 @@ func TestUpdateCustomFieldEnumTypeAddAndDelete(t *testing.T) {
 		},
 	}
 
-	_, err := updateCustomFieldEnumType("test", oldType, newType)
+	_, err := updateCustomFieldEnumType("test", oldType, newType, nil, nil)
 	assert.ErrorContains(t, err, "trying to delete enum value value2. Deleting enum values is not supported")
 }
 
+func TestUpdateCustomFieldEnumTypeRenameKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old      []platform.CustomFieldEnumValue
+		new      []platform.CustomFieldEnumValue
+		oldHints map[string]string
+		newHints map[string]string
+		expected []platform.TypeUpdateAction
+	}{
+		{
+			name: "rename last value",
+			old: []platform.CustomFieldEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "value2", Label: "Value 2"},
+			},
+			new: []platform.CustomFieldEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "renamed", Label: "Value 2"},
+			},
+			newHints: map[string]string{"renamed": "value2"},
+			expected: []platform.TypeUpdateAction{
+				platform.TypeAddEnumValueAction{
+					FieldName: "test",
+					Value:     platform.CustomFieldEnumValue{Key: "renamed", Label: "Value 2"},
+				},
+			},
+		},
+		{
+			name: "rename value in the middle",
+			old: []platform.CustomFieldEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "value2", Label: "Value 2"},
+				{Key: "value3", Label: "Value 3"},
+			},
+			new: []platform.CustomFieldEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "renamed", Label: "Value 2"},
+				{Key: "value3", Label: "Value 3"},
+			},
+			newHints: map[string]string{"renamed": "value2"},
+			expected: []platform.TypeUpdateAction{
+				platform.TypeAddEnumValueAction{
+					FieldName: "test",
+					Value:     platform.CustomFieldEnumValue{Key: "renamed", Label: "Value 2"},
+				},
+				platform.TypeChangeEnumValueOrderAction{
+					FieldName: "test",
+					Keys:      []string{"value1", "renamed", "value3", "value2"},
+				},
+			},
+		},
+		{
+			name: "reorder after earlier rename",
+			old: []platform.CustomFieldEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "renamed", Label: "Value 2"},
+			},
+			new: []platform.CustomFieldEnumValue{
+				{Key: "renamed", Label: "Value 2"},
+				{Key: "value1", Label: "Value 1"},
+			},
+			oldHints: map[string]string{"renamed": "value2"},
+			newHints: map[string]string{"renamed": "value2"},
+			expected: []platform.TypeUpdateAction{
+				platform.TypeChangeEnumValueOrderAction{
+					FieldName: "test",
+					Keys:      []string{"renamed", "value1", "value2"},
+				},
+			},
+		},
+		{
+			name: "hint for unknown key",
+			old: []platform.CustomFieldEnumValue{
+				{Key: "value1", Label: "Value 1"},
+			},
+			new: []platform.CustomFieldEnumValue{
+				{Key: "value1", Label: "Value 1"},
+				{Key: "value2", Label: "Value 2"},
+			},
+			newHints: map[string]string{"value2": "unknown"},
+			expected: []platform.TypeUpdateAction{
+				platform.TypeAddEnumValueAction{
+					FieldName: "test",
+					Value:     platform.CustomFieldEnumValue{Key: "value2", Label: "Value 2"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actions, err := updateCustomFieldEnumType(
+				"test",
+				platform.CustomFieldEnumType{Values: tc.old},
+				platform.CustomFieldEnumType{Values: tc.new},
+				tc.oldHints,
+				tc.newHints,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actions)
+		})
+	}
+}
+
+func TestUpdateCustomFieldLocalizedEnumTypeRenameKey(t *testing.T) {
+	oldType := platform.CustomFieldLocalizedEnumType{
+		Values: []platform.CustomFieldLocalizedEnumValue{
+			{Key: "value1", Label: platform.LocalizedString{"en": "Value 1"}},
+			{Key: "value2", Label: platform.LocalizedString{"en": "Value 2"}},
+		},
+	}
+	newType := platform.CustomFieldLocalizedEnumType{
+		Values: []platform.CustomFieldLocalizedEnumValue{
+			{Key: "renamed", Label: platform.LocalizedString{"en": "Value 1"}},
+			{Key: "value2", Label: platform.LocalizedString{"en": "Value 2"}},
+		},
+	}
+
+	actions, err := updateCustomFieldLocalizedEnumType(
+		"test", oldType, newType, nil, map[string]string{"renamed": "value1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []platform.TypeUpdateAction{
+		platform.TypeAddLocalizedEnumValueAction{
+			FieldName: "test",
+			Value:     platform.CustomFieldLocalizedEnumValue{Key: "renamed", Label: platform.LocalizedString{"en": "Value 1"}},
+		},
+		platform.TypeChangeLocalizedEnumValueOrderAction{
+			FieldName: "test",
+			Keys:      []string{"renamed", "value2", "value1"},
+		},
+	}, actions)
+}
+
+func TestResourceTypeValidateFieldEnumKeyHints(t *testing.T) {
+	n := []any{
+		map[string]any{
+			"name": "field-one",
+			"type": []any{
+				map[string]any{
+					"name": "Enum",
+					"value": []any{
+						map[string]any{"key": "value1", "label": "Value 1"},
+						map[string]any{"key": "value2", "label": "Value 2", "previous_key": "value1"},
+					},
+				},
+			},
+		},
+	}
+	err := resourceTypeValidateField(nil, n)
+	assert.EqualError(t, err, "previous_key 'value1' of enum value 'value2' of 'field-one' is still used as key of another value")
+}
+
 func TestAccTypes_basic(t *testing.T) {
 	key := "acctest-type"
 	identifier := "acctest_type"
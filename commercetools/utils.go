@@ -6,8 +6,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/labd/commercetools-go-sdk/platform"
-	"golang.org/x/text/language"
 	"reflect"
+
+	"github.com/labd/terraform-provider-commercetools/internal/customtypes"
+	"github.com/labd/terraform-provider-commercetools/internal/predicate"
+	"github.com/labd/terraform-provider-commercetools/internal/pricefunction"
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 // TypeLocalizedString defined merely for documentation,
@@ -16,8 +20,13 @@ import (
 const TypeLocalizedString = schema.TypeMap
 
 func getClient(m any) *platform.ByProjectKeyRequestBuilder {
-	client := m.(*platform.ByProjectKeyRequestBuilder)
-	return client
+	data := m.(*utils.ProviderData)
+	return data.Client
+}
+
+func getTypeCache(m any) *utils.TypeCache {
+	data := m.(*utils.ProviderData)
+	return data.TypeCache
 }
 
 func ref[T any](value T) *T {
@@ -342,12 +351,11 @@ var validateLocalizedStringKey schema.SchemaValidateDiagFunc = func(v interface{
 	}
 
 	for key := range m {
-		_, err := language.Parse(key)
-		if err != nil {
+		if err := customtypes.ValidateLanguageTag(key); err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity:      diag.Error,
 				Summary:       "Bad language tag",
-				Detail:        fmt.Sprintf("Language tag %s is not valid: %s", key, err.Error()),
+				Detail:        err.Error(),
 				AttributePath: append(path, cty.IndexStep{Key: cty.StringVal(key)}),
 			})
 			continue
@@ -357,6 +365,32 @@ var validateLocalizedStringKey schema.SchemaValidateDiagFunc = func(v interface{
 	return diags
 }
 
+// validatePredicate checks the syntax of a predicate, like the condition of an
+// API extension trigger. Empty values are left to the required check.
+func validatePredicate(val any, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if v == "" {
+		return
+	}
+	if err := predicate.Validate(v); err != nil {
+		errs = append(errs, fmt.Errorf("%q is not a valid predicate: %w", key, err))
+	}
+	return
+}
+
+// validatePriceFunction checks the syntax of the price function of a CartScore
+// shipping rate tier. Empty values are left to the required check.
+func validatePriceFunction(val any, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if v == "" {
+		return
+	}
+	if err := pricefunction.Validate(v); err != nil {
+		errs = append(errs, fmt.Errorf("%q is not a valid price function: %w", key, err))
+	}
+	return
+}
+
 func compareDateString(a, b string) bool {
 	if a == b {
 		return true
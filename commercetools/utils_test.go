@@ -103,6 +103,8 @@ func TestValidateLocalizedStringKey(t *testing.T) {
 		{map[string]any{"es-419": "Spanish (Latin America)"}, 0},
 		{map[string]any{"rm-sursilv": "Romansh Sursilvan"}, 0},
 		{map[string]any{"sr-Cyrl": "Serbian in Cyrillic"}, 0},
+		{map[string]any{"zh-Hans-CN": "Chinese (Simplified, China)"}, 0},
+		{map[string]any{"en-us": "English (United States)"}, 1},
 		{map[string]any{"foobar": "Fail"}, 1},
 		{"foobar", 1},
 		{1, 1},
@@ -114,3 +116,25 @@ func TestValidateLocalizedStringKey(t *testing.T) {
 		assert.Equal(t, tt.failures, len(diag), fmt.Sprintf("%+v", diag))
 	}
 }
+
+func TestValidatePredicate(t *testing.T) {
+	_, errs := validatePredicate(`cart.totalPrice.centAmount > 1000`, "condition")
+	assert.Empty(t, errs)
+
+	_, errs = validatePredicate("", "condition")
+	assert.Empty(t, errs)
+
+	_, errs = validatePredicate(`name = "Michael`, "condition")
+	assert.Len(t, errs, 1)
+}
+
+func TestValidatePriceFunction(t *testing.T) {
+	_, errs := validatePriceFunction(`(50 * x) + 4950`, "function")
+	assert.Empty(t, errs)
+
+	_, errs = validatePriceFunction("", "function")
+	assert.Empty(t, errs)
+
+	_, errs = validatePriceFunction(`(50 * x + 4950`, "function")
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,20 @@
+data "commercetools_product_type" "shoes" {
+  key = "shoes"
+}
+
+resource "commercetools_attribute_group" "sizing" {
+  key = "sizing"
+  name = {
+    en = "Sizing"
+  }
+
+  dynamic "attribute" {
+    for_each = [
+      for a in data.commercetools_product_type.shoes.attribute : a
+      if startswith(a.name, "size-")
+    ]
+    content {
+      key = attribute.value.name
+    }
+  }
+}
@@ -0,0 +1,11 @@
+ephemeral "commercetools_access_token" "orders" {
+  scopes    = ["view_orders"]
+  store_key = "my-store"
+}
+
+provider "restapi" {
+  uri = "https://api.europe-west1.gcp.commercetools.com"
+  headers = {
+    Authorization = "Bearer ${ephemeral.commercetools_access_token.orders.access_token}"
+  }
+}
@@ -0,0 +1,19 @@
+resource "commercetools_api_client" "my-api-client" {
+  name         = "My API Client"
+  scope        = ["view_orders:my-ct-project-key"]
+  store_secret = false
+}
+
+ephemeral "commercetools_api_client_secret" "my-api-client" {
+  client_id = commercetools_api_client.my-api-client.id
+}
+
+resource "vault_kv_secret_v2" "my-api-client" {
+  mount = "secret"
+  name  = "commercetools/my-api-client"
+  data_json_wo = jsonencode({
+    client_id     = commercetools_api_client.my-api-client.id
+    client_secret = ephemeral.commercetools_api_client_secret.my-api-client.secret
+  })
+  data_json_wo_version = 1
+}
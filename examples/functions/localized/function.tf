@@ -0,0 +1,8 @@
+resource "commercetools_category" "shoes" {
+  key = "shoes"
+  name = merge(
+    provider::commercetools::localized("Shoes", ["en", "en-GB", "en-US"]),
+    { nl = "Schoenen" },
+  )
+  slug = provider::commercetools::localized("shoes", ["en", "en-GB", "en-US", "nl"])
+}
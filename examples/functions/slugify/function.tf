@@ -0,0 +1,12 @@
+locals {
+  category_name = "Crème Brûlée & Co"
+}
+
+resource "commercetools_category" "desserts" {
+  name = {
+    en = local.category_name
+  }
+  slug = {
+    en = provider::commercetools::slugify(local.category_name)
+  }
+}
@@ -0,0 +1,9 @@
+resource "commercetools_shipping_zone_rate" "standard-de" {
+  shipping_method_id = commercetools_shipping_method.standard.id
+  shipping_zone_id   = commercetools_shipping_zone.de.id
+
+  price {
+    cent_amount   = provider::commercetools::to_cent_amount(4.95, "EUR")
+    currency_code = "EUR"
+  }
+}
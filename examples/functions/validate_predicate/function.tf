@@ -0,0 +1,17 @@
+resource "commercetools_cart_discount" "large-orders" {
+  name = {
+    en = "Large orders"
+  }
+  sort_order = "0.9"
+  predicate  = provider::commercetools::validate_predicate("totalPrice.centAmount > 10000 and country = \"DE\"")
+
+  target {
+    type      = "lineItems"
+    predicate = "1=1"
+  }
+
+  value {
+    type      = "relative"
+    permyriad = 1000
+  }
+}
@@ -2,3 +2,30 @@ resource "commercetools_api_client" "my-api-client" {
   name  = "My API Client"
   scope = ["manage_orders:my-ct-project-key", "manage_payments:my-ct-project-key"]
 }
+
+locals {
+  api_client_rotation = 1
+}
+
+resource "commercetools_api_client" "rotated-api-client" {
+  name         = "Rotated API Client"
+  scope        = ["view_orders:my-ct-project-key"]
+  store_secret = false
+
+  rotation {
+    trigger = local.api_client_rotation
+    overlap = "24h"
+  }
+}
+
+# The secret is only available during the apply which creates or rotates the
+# client, the version of the write-only attribute changes with the rotation
+ephemeral "commercetools_api_client_secret" "rotated-api-client" {
+  client_id = commercetools_api_client.rotated-api-client.id
+}
+
+resource "aws_secretsmanager_secret_version" "rotated-api-client" {
+  secret_id                = aws_secretsmanager_secret.rotated-api-client.id
+  secret_string_wo         = ephemeral.commercetools_api_client_secret.rotated-api-client.secret
+  secret_string_wo_version = local.api_client_rotation
+}
@@ -31,6 +31,42 @@ resource "commercetools_api_extension" "my-awslambda-extension" {
   }
 }
 
+# AWS Lambda api extension using IAM authentication
+resource "commercetools_api_extension" "my-awslambda-iam-extension" {
+  key = "my-awslambda-iam-extension-key"
+
+  destination {
+    type                = "awslambda"
+    authentication_mode = "IAM"
+    arn                 = "arn:aws:lambda:us-east-1:123456789012:function:mylambda"
+  }
+
+  trigger {
+    resource_type_id = "customer"
+    actions          = ["Create", "Update"]
+  }
+}
+
+# HTTP api extension with a write-only authorization header, which is never
+# stored in the state (requires Terraform 1.11 or later)
+resource "commercetools_api_extension" "my-write-only-http-extension" {
+  key                = "my-write-only-http-extension-key"
+  verify_destination = true
+
+  destination {
+    type                            = "HTTP"
+    authentication_mode             = "AuthorizationHeader"
+    url                             = "https://example.com"
+    authorization_header_wo         = var.authorization_header
+    authorization_header_wo_version = 1
+  }
+
+  trigger {
+    resource_type_id = "customer"
+    actions          = ["Create", "Update"]
+  }
+}
+
 # Google Cloud Function api extension
 resource "commercetools_api_extension" "my-googlecloudfunction-extension" {
   key = "my-googlecloudfunction-extension-key"
@@ -3,3 +3,15 @@ resource "commercetools_custom_object" "my-custom-object" {
   key       = "my-key"
   value     = jsonencode(10)
 }
+
+resource "commercetools_custom_object" "my-dynamic-custom-object" {
+  container = "my-container"
+  key       = "my-dynamic-key"
+  value_dynamic = {
+    address = {
+      street = "Main Street"
+      number = 10
+    }
+    tags = ["a", "b"]
+  }
+}
@@ -12,3 +12,32 @@ resource "commercetools_shipping_method" "standard" {
   tax_category_id = commercetools_tax_category.some-tax-category.id
   predicate       = "1 = 1"
 }
+
+resource "commercetools_shipping_zone" "de" {
+  name = "DE"
+  location {
+    country = "DE"
+  }
+}
+
+resource "commercetools_shipping_method" "express" {
+  key             = "express-key"
+  name            = "Express"
+  tax_category_id = commercetools_tax_category.some-tax-category.id
+
+  zone_rate {
+    shipping_zone_id = commercetools_shipping_zone.de.id
+
+    shipping_rate {
+      price {
+        cent_amount   = 1000
+        currency_code = "EUR"
+      }
+
+      free_above {
+        cent_amount   = 10000
+        currency_code = "EUR"
+      }
+    }
+  }
+}
@@ -42,6 +42,13 @@ resource "commercetools_type" "my-store-type" {
   }
 }
 
+resource "commercetools_product_selection" "summer-assortment" {
+  key = "summer-assortment"
+  name = {
+    en = "Summer assortment"
+  }
+  mode = "Individual"
+}
 
 resource "commercetools_store" "my-store" {
   key = "my-store"
@@ -53,6 +60,11 @@ resource "commercetools_store" "my-store" {
   distribution_channels = ["US-DIST"]
   supply_channels       = ["US-SUP"]
 
+  product_selection {
+    product_selection_key = commercetools_product_selection.summer-assortment.key
+    active                = true
+  }
+
   custom {
     type_id = commercetools_type.my-store-type.id
     fields = {
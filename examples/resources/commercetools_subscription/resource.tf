@@ -22,3 +22,20 @@ resource "commercetools_subscription" "my-sqs-subscription" {
     types            = ["ImportContainerCreated"]
   }
 }
+
+# The connection string is never stored in the state when the write-only
+# variant is used (requires Terraform 1.11 or later). Increase the version to
+# update the connection string after a rotation.
+resource "commercetools_subscription" "my-service-bus-subscription" {
+  key = "my-service-bus-subscription-key"
+  destination {
+    type                         = "AzureServiceBus"
+    connection_string_wo         = var.service_bus_connection_string
+    connection_string_wo_version = 1
+  }
+
+  message {
+    resource_type_id = "order"
+    types            = ["OrderCreated"]
+  }
+}
@@ -3,3 +3,24 @@ resource "commercetools_tax_category" "my-tax-category" {
   name        = "Standard tax category"
   description = "Example category"
 }
+
+resource "commercetools_tax_category" "reduced" {
+  key  = "reduced"
+  name = "Reduced tax category"
+
+  rate {
+    key               = "reduced-de"
+    name              = "7% DE"
+    amount            = 0.07
+    included_in_price = true
+    country           = "DE"
+  }
+
+  rate {
+    key               = "reduced-nl"
+    name              = "9% NL"
+    amount            = 0.09
+    included_in_price = true
+    country           = "NL"
+  }
+}
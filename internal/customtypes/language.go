@@ -0,0 +1,22 @@
+package customtypes
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// ValidateLanguageTag returns an error when the tag is not a valid IETF
+// language tag, e.g. en, en-US, es-419 or zh-Hans-CN. Tags have to be written
+// in their canonical casing, so en-us is rejected in favour of en-US, since
+// commercetools treats them as different languages.
+func ValidateLanguageTag(tag string) error {
+	parsed, err := language.Raw.Parse(tag)
+	if err != nil {
+		return fmt.Errorf("language tag %s is not valid: %w", tag, err)
+	}
+	if parsed.String() != tag {
+		return fmt.Errorf("language tag %s is not valid, did you mean %s?", tag, parsed.String())
+	}
+	return nil
+}
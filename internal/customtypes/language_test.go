@@ -0,0 +1,36 @@
+package customtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLanguageTag(t *testing.T) {
+	var cases = []struct {
+		tag      string
+		expected string
+	}{
+		{"en", ""},
+		{"en-US", ""},
+		{"es-419", ""},
+		{"zh-Hans-CN", ""},
+		{"sr-Cyrl", ""},
+		{"rm-sursilv", ""},
+		{"en-us", "language tag en-us is not valid, did you mean en-US?"},
+		{"en_US", "language tag en_US is not valid, did you mean en-US?"},
+		{"foobar", "language tag foobar is not valid"},
+		{"es-409", "language tag es-409 is not valid"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.tag, func(t *testing.T) {
+			err := ValidateLanguageTag(tt.tag)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
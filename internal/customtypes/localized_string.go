@@ -3,22 +3,17 @@ package customtypes
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/labd/commercetools-go-sdk/platform"
 )
 
-var reLocalizedStringKey = regexp.MustCompile("^[a-z]{2}(-[A-Z]{2})?$")
-
 type LocalizedStringOpts struct {
 	Optional bool
 }
@@ -39,18 +34,12 @@ type LocalizedStringValue struct {
 	types.Map
 }
 
+var _ xattr.ValidateableAttribute = LocalizedStringValue{}
+
 func LocalizedString(opts LocalizedStringOpts) schema.MapAttribute {
 	attr := schema.MapAttribute{
 		Optional:   opts.Optional,
 		CustomType: NewLocalizedStringType(),
-		Validators: []validator.Map{
-			mapvalidator.KeysAre(
-				stringvalidator.RegexMatches(
-					reLocalizedStringKey,
-					"invalid locale specified, ",
-				),
-			),
-		},
 	}
 
 	return attr
@@ -114,6 +103,19 @@ func (l LocalizedStringType) Equal(o attr.Type) bool {
 	return false
 }
 
+// ValidateAttribute validates the keys of the localized string are valid
+// language tags, see ValidateLanguageTag
+func (l LocalizedStringValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if l.IsNull() || l.IsUnknown() {
+		return
+	}
+	for key := range l.Elements() {
+		if err := ValidateLanguageTag(key); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtMapKey(key), "Invalid language tag", err.Error())
+		}
+	}
+}
+
 func (l LocalizedStringValue) ValueLocalizedString() platform.LocalizedString {
 	result := platform.LocalizedString{}
 	if l.IsUnknown() {
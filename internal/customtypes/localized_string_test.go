@@ -1,9 +1,13 @@
 package customtypes
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/stretchr/testify/assert"
@@ -28,3 +32,15 @@ func TestLocalizedStringUnknown(t *testing.T) {
 	expected := platform.LocalizedString(nil)
 	assert.Equal(t, expected, result)
 }
+
+func TestLocalizedStringValidateAttribute(t *testing.T) {
+	val := NewLocalizedStringValue(map[string]attr.Value{
+		"es-419": types.StringValue("foobar"),
+		"en-us":  types.StringValue("foobar"),
+	})
+
+	resp := &xattr.ValidateAttributeResponse{}
+	val.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("name")}, resp)
+	assert.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, path.Root("name").AtMapKey("en-us"), resp.Diagnostics[0].(diag.DiagnosticWithPath).Path())
+}
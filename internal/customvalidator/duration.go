@@ -0,0 +1,45 @@
+package customvalidator
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func DurationValidator() validator.String {
+	return durationValidator{}
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that the value is a duration which can be parsed
+// by time.ParseDuration, for example 5m
+type durationValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a duration, for example 5m"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration <= 0 {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			req.ConfigValue.ValueString(),
+		))
+	}
+}
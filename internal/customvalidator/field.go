@@ -3,6 +3,7 @@ package customvalidator
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -95,3 +96,76 @@ func (v dependencyValidator) ValidateString(ctx context.Context, req validator.S
 	}
 
 }
+
+func DependencyAnyValidator(value string, expressions ...path.Expression) validator.String {
+	return dependencyAnyValidator{
+		value:           value,
+		pathExpressions: expressions,
+	}
+}
+
+var _ validator.String = dependencyAnyValidator{}
+
+// dependencyAnyValidator validates that at least one of the attributes is set
+// when the attribute has the given value, for example either a secret or its
+// write-only variant.
+type dependencyAnyValidator struct {
+	value           string
+	pathExpressions path.Expressions
+}
+
+// Description describes the validation in plain text formatting.
+func (v dependencyAnyValidator) Description(ctx context.Context) string {
+	return "Validate the existence of one of the attributes when the attribute has the given value"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v dependencyAnyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v dependencyAnyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	sourceVal := req.ConfigValue.ValueString()
+	if sourceVal != v.value {
+		return
+	}
+
+	expressions := req.PathExpression.MergeExpressions(v.pathExpressions...)
+
+	var names []string
+	for _, expression := range expressions {
+		matchedPaths, diags := req.Config.PathMatches(ctx, expression)
+		resp.Diagnostics.Append(diags...)
+
+		// Collect all errors
+		if diags.HasError() {
+			continue
+		}
+
+		for _, mp := range matchedPaths {
+			var mpVal attr.Value
+			diags := req.Config.GetAttribute(ctx, mp, &mpVal)
+			resp.Diagnostics.Append(diags...)
+
+			// Collect all errors
+			if diags.HasError() {
+				continue
+			}
+
+			// Delay validation until all involved attribute have a known value
+			if mpVal.IsUnknown() || !mpVal.IsNull() {
+				return
+			}
+			names = append(names, fmt.Sprintf("%q", mp))
+		}
+	}
+
+	if len(names) > 0 {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeCombinationDiagnostic(
+			req.Path,
+			fmt.Sprintf("One of the attributes %s must be specified when %q is %q",
+				strings.Join(names, ", "), req.Path, sourceVal),
+		))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
@@ -87,3 +88,66 @@ func (v requireValueValidator) ValidateList(ctx context.Context, req validator.L
 	}
 
 }
+
+func RequireValueStringValidator(value string, expressions ...path.Expression) validator.String {
+	return requireValueStringValidator{
+		value:           value,
+		pathExpressions: expressions,
+	}
+}
+
+var _ validator.String = requireValueStringValidator{}
+
+// requireValueStringValidator validates that an attribute is only specified
+// when the attributes of the expressions have the given value.
+type requireValueStringValidator struct {
+	value           string
+	pathExpressions path.Expressions
+}
+
+// Description describes the validation in plain text formatting.
+func (v requireValueStringValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Can only be specified when the attributes are %q", v.value)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v requireValueStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v requireValueStringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	expressions := req.PathExpression.MergeExpressions(v.pathExpressions...)
+
+	for _, expression := range expressions {
+		matchedPaths, diags := req.Config.PathMatches(ctx, expression)
+		resp.Diagnostics.Append(diags...)
+
+		// Collect all errors
+		if diags.HasError() {
+			continue
+		}
+
+		for _, mp := range matchedPaths {
+			var mpVal types.String
+			diags := req.Config.GetAttribute(ctx, mp, &mpVal)
+			resp.Diagnostics.Append(diags...)
+
+			// Collect all errors
+			if diags.HasError() || mpVal.IsUnknown() {
+				continue
+			}
+
+			if mpVal.ValueString() != v.value {
+				resp.Diagnostics.Append(validatordiag.InvalidAttributeCombinationDiagnostic(
+					req.Path,
+					fmt.Sprintf("Attribute %q can only be specified when %q is %q", req.Path, mp, v.value),
+				))
+			}
+		}
+	}
+}
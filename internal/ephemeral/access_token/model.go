@@ -0,0 +1,95 @@
+package access_token
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+type AccessToken struct {
+	ClientID     types.String   `tfsdk:"client_id"`
+	ClientSecret types.String   `tfsdk:"client_secret"`
+	Scopes       []types.String `tfsdk:"scopes"`
+	StoreKey     types.String   `tfsdk:"store_key"`
+	AccessToken  types.String   `tfsdk:"access_token"`
+	TokenType    types.String   `tfsdk:"token_type"`
+	Scope        types.String   `tfsdk:"scope"`
+	ExpiresAt    types.String   `tfsdk:"expires_at"`
+}
+
+// credentials returns the client credentials configuration used to request
+// the token. Values which are not set on the ephemeral resource are taken from
+// the provider configuration.
+func (a AccessToken) credentials(base *clientcredentials.Config, projectKey string) (*clientcredentials.Config, error) {
+	if base == nil {
+		return nil, fmt.Errorf("the provider is not configured")
+	}
+
+	result := &clientcredentials.Config{
+		ClientID:     base.ClientID,
+		ClientSecret: base.ClientSecret,
+		TokenURL:     base.TokenURL,
+		Scopes:       base.Scopes,
+	}
+
+	if !a.ClientID.IsNull() {
+		result.ClientID = a.ClientID.ValueString()
+	}
+	if !a.ClientSecret.IsNull() {
+		result.ClientSecret = a.ClientSecret.ValueString()
+	}
+	if result.ClientID == "" || result.ClientSecret == "" {
+		return nil, fmt.Errorf("no client credentials configured on the resource or the provider")
+	}
+
+	if len(a.Scopes) > 0 {
+		result.Scopes = expandScopes(
+			pie.Map(a.Scopes, func(v types.String) string { return v.ValueString() }),
+			projectKey,
+			a.StoreKey.ValueString(),
+		)
+	} else if !a.StoreKey.IsNull() {
+		return nil, fmt.Errorf("scopes must be set when requesting a store scoped token")
+	}
+
+	return result, nil
+}
+
+// expandScopes adds the project key, and optionally the store key, to scopes
+// which are given without one. For example `view_orders` is expanded to
+// `view_orders:my-project:my-store`. Scopes which already contain a project key
+// are returned as is.
+func expandScopes(scopes []string, projectKey, storeKey string) []string {
+	result := make([]string, len(scopes))
+	for i, scope := range scopes {
+		if strings.Contains(scope, ":") {
+			result[i] = scope
+			continue
+		}
+
+		result[i] = fmt.Sprintf("%s:%s", scope, projectKey)
+		if storeKey != "" {
+			result[i] = fmt.Sprintf("%s:%s", result[i], storeKey)
+		}
+	}
+	return result
+}
+
+// setToken stores the token on the model. The scopes are used when the token
+// response doesn't contain the granted scopes.
+func (a *AccessToken) setToken(token *oauth2.Token, scopes []string) {
+	a.AccessToken = types.StringValue(token.AccessToken)
+	a.TokenType = types.StringValue(token.Type())
+	a.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+
+	if scope, ok := token.Extra("scope").(string); ok {
+		a.Scope = types.StringValue(scope)
+	} else {
+		a.Scope = types.StringValue(strings.Join(scopes, " "))
+	}
+}
@@ -0,0 +1,141 @@
+package access_token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+func TestExpandScopes(t *testing.T) {
+	cases := []struct {
+		name     string
+		scopes   []string
+		storeKey string
+		expected []string
+	}{
+		{
+			"project scoped",
+			[]string{"view_products", "manage_orders"},
+			"",
+			[]string{"view_products:my-project", "manage_orders:my-project"},
+		},
+		{
+			"store scoped",
+			[]string{"manage_orders"},
+			"my-store",
+			[]string{"manage_orders:my-project:my-store"},
+		},
+		{
+			"explicit scopes are kept",
+			[]string{"manage_orders:other-project", "view_orders"},
+			"my-store",
+			[]string{"manage_orders:other-project", "view_orders:my-project:my-store"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, expandScopes(c.scopes, "my-project", c.storeKey))
+		})
+	}
+}
+
+func TestAccessToken_Credentials(t *testing.T) {
+	base := &clientcredentials.Config{
+		ClientID:     "provider-id",
+		ClientSecret: "provider-secret",
+		TokenURL:     "https://auth.example.com/oauth/token",
+		Scopes:       []string{"manage_project:my-project"},
+	}
+
+	cases := []struct {
+		name     string
+		token    AccessToken
+		expected *clientcredentials.Config
+		err      string
+	}{
+		{
+			"provider defaults",
+			AccessToken{
+				ClientID:     types.StringNull(),
+				ClientSecret: types.StringNull(),
+				StoreKey:     types.StringNull(),
+			},
+			base,
+			"",
+		},
+		{
+			"explicit credentials and scopes",
+			AccessToken{
+				ClientID:     types.StringValue("my-id"),
+				ClientSecret: types.StringValue("my-secret"),
+				Scopes:       []types.String{types.StringValue("view_orders")},
+				StoreKey:     types.StringValue("my-store"),
+			},
+			&clientcredentials.Config{
+				ClientID:     "my-id",
+				ClientSecret: "my-secret",
+				TokenURL:     "https://auth.example.com/oauth/token",
+				Scopes:       []string{"view_orders:my-project:my-store"},
+			},
+			"",
+		},
+		{
+			"store key without scopes",
+			AccessToken{
+				ClientID:     types.StringNull(),
+				ClientSecret: types.StringNull(),
+				StoreKey:     types.StringValue("my-store"),
+			},
+			nil,
+			"scopes must be set when requesting a store scoped token",
+		},
+		{
+			"empty secret",
+			AccessToken{
+				ClientID:     types.StringNull(),
+				ClientSecret: types.StringValue(""),
+				StoreKey:     types.StringNull(),
+			},
+			nil,
+			"no client credentials configured on the resource or the provider",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := c.token.credentials(base, "my-project")
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, result)
+		})
+	}
+}
+
+func TestAccessToken_SetToken(t *testing.T) {
+	expiry := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	token := (&oauth2.Token{
+		AccessToken: "secret-token",
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}).WithExtra(map[string]any{"scope": "view_orders:my-project"})
+
+	var data AccessToken
+	data.setToken(token, []string{"manage_project:my-project"})
+
+	assert.Equal(t, types.StringValue("secret-token"), data.AccessToken)
+	assert.Equal(t, types.StringValue("Bearer"), data.TokenType)
+	assert.Equal(t, types.StringValue("view_orders:my-project"), data.Scope)
+	assert.Equal(t, types.StringValue("2024-01-02T03:04:05Z"), data.ExpiresAt)
+
+	data.setToken(&oauth2.Token{AccessToken: "secret-token", Expiry: expiry}, []string{"a", "b"})
+	assert.Equal(t, types.StringValue("a b"), data.Scope)
+}
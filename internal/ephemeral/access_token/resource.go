@@ -0,0 +1,124 @@
+package access_token
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &accessTokenResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenResource{}
+)
+
+// NewEphemeralResource is a helper function to simplify the provider implementation.
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenResource{}
+}
+
+// accessTokenResource is the ephemeral resource implementation.
+type accessTokenResource struct {
+	projectKey  string
+	credentials *clientcredentials.Config
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *accessTokenResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *accessTokenResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Requests a short-lived OAuth access token using the client credentials flow. " +
+			"The token is never persisted in the plan or state, which makes it suitable to seed data or call " +
+			"API extensions and Connect deployments during the same run.\n\n" +
+			"By default the credentials and scopes of the provider are used.\n\n" +
+			"See also the [Authorization API Documentation](https://docs.commercetools.com/api/authorization)",
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "The OAuth Client ID to use. Defaults to the client ID of the provider",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "The OAuth Client Secret to use. Defaults to the client secret of the provider",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "The [scopes](https://docs.commercetools.com/api/scopes) to request. " +
+					"Scopes without a project key, like `view_products`, are expanded with the project key of " +
+					"the provider (and the `store_key` when set). Defaults to the scopes of the provider",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"store_key": schema.StringAttribute{
+				MarkdownDescription: "Request a store scoped token for the given store. Requires `scopes` to be set",
+				Optional:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "The bearer token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the token, usually `Bearer`",
+				Computed:            true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Space separated list of the scopes granted to the token",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The time the token expires, in RFC 3339 format",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured credentials to the ephemeral resource.
+func (r *accessTokenResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*utils.ProviderData)
+	r.projectKey = data.ProjectKey
+	r.credentials = data.Credentials
+}
+
+// Open requests the access token.
+func (r *accessTokenResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AccessToken
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := data.credentials(r.credentials, r.projectKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid access token configuration", err.Error())
+		return
+	}
+
+	token, err := config.Token(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to request access token", err.Error())
+		return
+	}
+
+	data.setToken(token, config.Scopes)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,99 @@
+package api_client_secret
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &apiClientSecretResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &apiClientSecretResource{}
+)
+
+// NewEphemeralResource is a helper function to simplify the provider implementation.
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &apiClientSecretResource{}
+}
+
+// apiClientSecretResource is the ephemeral resource implementation.
+type apiClientSecretResource struct {
+	secrets *utils.APIClientSecrets
+}
+
+type APIClientSecret struct {
+	ClientID  types.String `tfsdk:"client_id"`
+	Secret    types.String `tfsdk:"secret"`
+	Available types.Bool   `tfsdk:"available"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *apiClientSecretResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_client_secret"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *apiClientSecretResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the secret of a `commercetools_api_client` without storing it in the plan " +
+			"or state, for example to pass it to a write-only attribute of a secret manager.\n\n" +
+			"commercetools only returns the secret when a client is created, so the secret is only available " +
+			"during the apply which creates or rotates the client. Use a value which changes with the secret, " +
+			"like the `trigger` of the `rotation` block, as the version of the write-only attribute.",
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the API client",
+				Required:            true,
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The secret of the API client, or null when the client wasn't created or " +
+					"rotated during this apply",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"available": schema.BoolAttribute{
+				MarkdownDescription: "Whether the secret is available",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the secrets of the API clients created by the provider.
+func (r *apiClientSecretResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*utils.ProviderData)
+	r.secrets = data.APIClientSecrets
+}
+
+// Open returns the secret when the API client was created during this run.
+func (r *apiClientSecretResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data APIClientSecret
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setSecret(r.secrets)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// setSecret sets the secret of the client, when it is available
+func (a *APIClientSecret) setSecret(secrets *utils.APIClientSecrets) {
+	a.Secret = types.StringNull()
+	a.Available = types.BoolValue(false)
+	if secrets == nil {
+		return
+	}
+	if secret, ok := secrets.Get(a.ClientID.ValueString()); ok {
+		a.Secret = types.StringValue(secret)
+		a.Available = types.BoolValue(true)
+	}
+}
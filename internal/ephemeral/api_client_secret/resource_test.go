@@ -0,0 +1,29 @@
+package api_client_secret
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func TestAPIClientSecretSetSecret(t *testing.T) {
+	secrets := utils.APIClientSecretsFor("https://api.example.com", "ephemeral-secret")
+	secrets.Set("client-1", "secret")
+
+	data := APIClientSecret{ClientID: types.StringValue("client-1")}
+	data.setSecret(secrets)
+	assert.Equal(t, types.StringValue("secret"), data.Secret)
+	assert.Equal(t, types.BoolValue(true), data.Available)
+
+	// Clients which weren't created during this run have no secret
+	data = APIClientSecret{ClientID: types.StringValue("client-2")}
+	data.setSecret(secrets)
+	assert.True(t, data.Secret.IsNull())
+	assert.Equal(t, types.BoolValue(false), data.Available)
+
+	data.setSecret(nil)
+	assert.True(t, data.Secret.IsNull())
+}
@@ -0,0 +1,67 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/text/language"
+)
+
+var _ function.Function = &localizedFunction{}
+
+func NewLocalizedFunction() function.Function {
+	return &localizedFunction{}
+}
+
+type localizedFunction struct{}
+
+func (f *localizedFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "localized"
+}
+
+func (f *localizedFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds a localized string with the same value for every language",
+		MarkdownDescription: "Builds a [LocalizedString](https://docs.commercetools.com/api/types#localizedstring) " +
+			"map which contains the value for each of the given languages. " +
+			"`localized(\"Shoes\", [\"en\", \"en-GB\"])` returns `{ en = \"Shoes\", en-GB = \"Shoes\" }`. " +
+			"Use `merge()` to override the value for specific languages.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The value to use for every language",
+			},
+			function.ListParameter{
+				Name:                "languages",
+				MarkdownDescription: "List of IETF language tags",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *localizedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	var languages []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value, &languages))
+	if resp.Error != nil {
+		return
+	}
+
+	result := make(map[string]string, len(languages))
+	for _, lang := range languages {
+		if _, err := language.Parse(lang); err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid language tag %q: %s", lang, err))
+			return
+		}
+		result[lang] = value
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,74 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizedFunction(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     string
+		languages []string
+		expected  function.RunResponse
+	}{
+		{
+			"multiple languages",
+			"Shoes",
+			[]string{"en", "en-GB", "es-419"},
+			function.RunResponse{
+				Result: function.NewResultData(types.MapValueMust(types.StringType, map[string]attr.Value{
+					"en":     types.StringValue("Shoes"),
+					"en-GB":  types.StringValue("Shoes"),
+					"es-419": types.StringValue("Shoes"),
+				})),
+			},
+		},
+		{
+			"no languages",
+			"Shoes",
+			[]string{},
+			function.RunResponse{
+				Result: function.NewResultData(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+			},
+		},
+		{
+			"invalid language",
+			"Shoes",
+			[]string{"en", "english"},
+			function.RunResponse{
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+				Error: function.NewArgumentFuncError(
+					1, "invalid language tag \"english\": language: tag is not well-formed",
+				),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			languages := make([]attr.Value, len(c.languages))
+			for i, l := range c.languages {
+				languages[i] = types.StringValue(l)
+			}
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(c.value),
+					types.ListValueMust(types.StringType, languages),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+			}
+
+			NewLocalizedFunction().Run(context.Background(), req, &resp)
+			assert.Equal(t, c.expected, resp)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var _ function.Function = &slugifyFunction{}
+
+func NewSlugifyFunction() function.Function {
+	return &slugifyFunction{}
+}
+
+type slugifyFunction struct{}
+
+func (f *slugifyFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "slugify"
+}
+
+func (f *slugifyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a text to a valid commercetools slug",
+		MarkdownDescription: "Converts a text to a slug which matches the commercetools slug rules: " +
+			"between 2 and 256 characters consisting of letters, digits, `_` and `-`. Accents are removed, " +
+			"the text is lowercased and all other characters are replaced by a `-`. " +
+			"`slugify(\"Crème Brûlée & Co\")` returns `creme-brulee-co`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "text",
+				MarkdownDescription: "The text to convert",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *slugifyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &text))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := slugify(text)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+const slugMaxLength = 256
+
+func slugify(text string) (string, error) {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, text)
+	if err != nil {
+		return "", err
+	}
+
+	result := slugInvalidChars.ReplaceAllString(strings.ToLower(normalized), "-")
+	result = strings.Trim(result, "-")
+	if len(result) > slugMaxLength {
+		result = strings.TrimRight(result[:slugMaxLength], "-")
+	}
+
+	if len(result) < 2 {
+		return "", fmt.Errorf("%q results in the slug %q which is shorter than 2 characters", text, result)
+	}
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package functions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugifyFunction(t *testing.T) {
+	cases := []struct {
+		name     string
+		text     string
+		expected function.RunResponse
+	}{
+		{
+			"accents and punctuation",
+			"Crème Brûlée & Co",
+			function.RunResponse{Result: function.NewResultData(types.StringValue("creme-brulee-co"))},
+		},
+		{
+			"underscores and digits are kept",
+			"  My_Product 2000!! ",
+			function.RunResponse{Result: function.NewResultData(types.StringValue("my_product-2000"))},
+		},
+		{
+			"long text is truncated",
+			strings.Repeat("ab ", 100),
+			function.RunResponse{
+				Result: function.NewResultData(types.StringValue(strings.Repeat("ab-", 85) + "a")),
+			},
+		},
+		{
+			"too short",
+			"!a!",
+			function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+				Error: function.NewArgumentFuncError(
+					0, "\"!a!\" results in the slug \"a\" which is shorter than 2 characters",
+				),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(c.text)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewSlugifyFunction().Run(context.Background(), req, &resp)
+			assert.Equal(t, c.expected, resp)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/text/currency"
+)
+
+var _ function.Function = &toCentAmountFunction{}
+
+func NewToCentAmountFunction() function.Function {
+	return &toCentAmountFunction{}
+}
+
+type toCentAmountFunction struct{}
+
+func (f *toCentAmountFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_cent_amount"
+}
+
+func (f *toCentAmountFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts an amount to the cent amount of the given currency",
+		MarkdownDescription: "Converts an amount, for example `19.99`, to the `cent_amount` used by commercetools " +
+			"for the given ISO 4217 currency, taking the fraction digits of the currency into account. " +
+			"`to_cent_amount(19.99, \"EUR\")` returns `1999` and `to_cent_amount(1500, \"JPY\")` returns `1500`. " +
+			"An error is returned when the amount has more decimals than the currency supports.",
+		Parameters: []function.Parameter{
+			function.NumberParameter{
+				Name:                "amount",
+				MarkdownDescription: "The amount in the main unit of the currency",
+			},
+			function.StringParameter{
+				Name:                "currency",
+				MarkdownDescription: "The ISO 4217 currency code",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *toCentAmountFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var amount *big.Float
+	var currencyCode string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &amount, &currencyCode))
+	if resp.Error != nil {
+		return
+	}
+
+	unit, err := currency.ParseISO(currencyCode)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unknown currency code %q", currencyCode))
+		return
+	}
+
+	scale, _ := currency.Standard.Rounding(unit)
+	result, err := toCentAmount(amount, scale)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%s for currency %s", err, unit))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// toCentAmount converts the amount to an integer with the given number of
+// fraction digits. The conversion is done on the decimal representation of
+// the amount to prevent rounding issues, so 19.99 results in 1999 and not in
+// 1998.
+func toCentAmount(amount *big.Float, scale int) (int64, error) {
+	value := amount.Text('f', -1)
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > scale {
+		return 0, fmt.Errorf("amount %s has more than %d fraction digits", value, scale)
+	}
+	fraction += strings.Repeat("0", scale-len(fraction))
+
+	result, ok := new(big.Int).SetString(whole+fraction, 10)
+	if !ok || !result.IsInt64() {
+		return 0, fmt.Errorf("amount %s is out of range", value)
+	}
+	return result.Int64(), nil
+}
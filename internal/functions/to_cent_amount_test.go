@@ -0,0 +1,99 @@
+package functions
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToCentAmountFunction(t *testing.T) {
+	cases := []struct {
+		name     string
+		amount   string
+		currency string
+		expected function.RunResponse
+	}{
+		{
+			"two fraction digits",
+			"19.99",
+			"EUR",
+			function.RunResponse{Result: function.NewResultData(types.Int64Value(1999))},
+		},
+		{
+			"whole amount",
+			"10",
+			"USD",
+			function.RunResponse{Result: function.NewResultData(types.Int64Value(1000))},
+		},
+		{
+			"negative amount",
+			"-0.5",
+			"EUR",
+			function.RunResponse{Result: function.NewResultData(types.Int64Value(-50))},
+		},
+		{
+			"no fraction digits",
+			"1500",
+			"JPY",
+			function.RunResponse{Result: function.NewResultData(types.Int64Value(1500))},
+		},
+		{
+			"three fraction digits",
+			"1.234",
+			"KWD",
+			function.RunResponse{Result: function.NewResultData(types.Int64Value(1234))},
+		},
+		{
+			"too many fraction digits",
+			"19.999",
+			"EUR",
+			function.RunResponse{
+				Result: function.NewResultData(types.Int64Unknown()),
+				Error:  function.NewArgumentFuncError(0, "amount 19.999 has more than 2 fraction digits for currency EUR"),
+			},
+		},
+		{
+			"fraction for currency without fraction digits",
+			"15.5",
+			"JPY",
+			function.RunResponse{
+				Result: function.NewResultData(types.Int64Unknown()),
+				Error:  function.NewArgumentFuncError(0, "amount 15.5 has more than 0 fraction digits for currency JPY"),
+			},
+		},
+		{
+			"unknown currency",
+			"10",
+			"EURO",
+			function.RunResponse{
+				Result: function.NewResultData(types.Int64Unknown()),
+				Error:  function.NewArgumentFuncError(1, "unknown currency code \"EURO\""),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			amount, _, err := big.ParseFloat(c.amount, 10, 512, big.ToNearestEven)
+			assert.NoError(t, err)
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.NumberValue(amount),
+					types.StringValue(c.currency),
+				}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.Int64Unknown()),
+			}
+
+			NewToCentAmountFunction().Run(context.Background(), req, &resp)
+			assert.Equal(t, c.expected, resp)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/labd/terraform-provider-commercetools/internal/predicate"
+)
+
+var _ function.Function = &validatePredicateFunction{}
+
+func NewValidatePredicateFunction() function.Function {
+	return &validatePredicateFunction{}
+}
+
+type validatePredicateFunction struct{}
+
+func (f *validatePredicateFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_predicate"
+}
+
+func (f *validatePredicateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks the syntax of a predicate",
+		MarkdownDescription: "Checks the syntax of a [query](https://docs.commercetools.com/api/predicates/query) " +
+			"or [cart](https://docs.commercetools.com/api/predicates/predicate-operators) predicate and returns " +
+			"it unchanged, so it can be used inline. An error pointing at the offending column is returned when " +
+			"the predicate is invalid. Field names are not validated.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "expr",
+				MarkdownDescription: "The predicate to validate",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *validatePredicateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expr string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &expr))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := predicate.Validate(expr); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, expr))
+}
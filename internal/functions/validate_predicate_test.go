@@ -0,0 +1,53 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePredicateFunction(t *testing.T) {
+	cases := []struct {
+		name     string
+		expr     string
+		expected function.RunResponse
+	}{
+		{
+			"valid predicate",
+			`lineItemCount(1 = 1) > 2 and customer.email is defined`,
+			function.RunResponse{
+				Result: function.NewResultData(
+					types.StringValue(`lineItemCount(1 = 1) > 2 and customer.email is defined`),
+				),
+			},
+		},
+		{
+			"invalid predicate",
+			`sku = "abc" and`,
+			function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+				Error: function.NewArgumentFuncError(
+					0, "invalid predicate at column 16: expected a field, function or value but found end of expression",
+				),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(c.expr)}),
+			}
+			resp := function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewValidatePredicateFunction().Run(context.Background(), req, &resp)
+			assert.Equal(t, c.expected, resp)
+		})
+	}
+}
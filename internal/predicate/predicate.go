@@ -0,0 +1,243 @@
+// Package predicate implements a syntax checker for the commercetools
+// predicate languages, used for query predicates, cart predicates and API
+// extension conditions.
+//
+// See https://docs.commercetools.com/api/predicates/query and
+// https://docs.commercetools.com/api/predicates/predicate-operators
+package predicate
+
+import (
+	"fmt"
+)
+
+// SyntaxError is returned when a predicate can not be parsed.
+type SyntaxError struct {
+	// Pos is the (zero based) offset of the offending character in the
+	// predicate
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid predicate at column %d: %s", e.Pos+1, e.Msg)
+}
+
+// Validate checks the syntax of the given predicate. It does not check whether
+// the fields used in the predicate exist.
+func Validate(input string) error {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return &SyntaxError{Pos: 0, Msg: "predicate is empty"}
+	}
+	if err := p.parseExpr(); err != nil {
+		return err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return p.unexpected(t, "and, or or end of expression")
+	}
+	return nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, description string) error {
+	t := p.next()
+	if t.kind != kind {
+		return p.unexpected(t, description)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	return &SyntaxError{
+		Pos: t.pos,
+		Msg: fmt.Sprintf("expected %s but found %s", expected, t),
+	}
+}
+
+func (p *parser) parseExpr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for p.peek().is("or") {
+		p.next()
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseAnd() error {
+	if err := p.parseNot(); err != nil {
+		return err
+	}
+	for p.peek().is("and") {
+		p.next()
+		if err := p.parseNot(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseNot() error {
+	if p.peek().is("not") {
+		p.next()
+		return p.parseNot()
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() error {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		if err := p.parseExpr(); err != nil {
+			return err
+		}
+		return p.expect(tokenRParen, "\")\"")
+	}
+
+	if err := p.parseOperand(); err != nil {
+		return err
+	}
+	return p.parseOperator()
+}
+
+// parseOperand parses a literal, a field path or a function call like
+// `lineItemCount(sku = "abc")` or `masterData(current(name(en = "x")))`.
+func (p *parser) parseOperand() error {
+	t := p.next()
+	switch t.kind {
+	case tokenString, tokenNumber:
+		return nil
+	case tokenIdent:
+	default:
+		return p.unexpected(t, "a field, function or value")
+	}
+
+	for p.peek().kind == tokenDot {
+		p.next()
+		if err := p.expect(tokenIdent, "a field name"); err != nil {
+			return err
+		}
+	}
+
+	if p.peek().kind != tokenLParen {
+		return nil
+	}
+
+	p.next()
+	if p.peek().kind == tokenRParen {
+		p.next()
+		return nil
+	}
+	for {
+		if err := p.parseExpr(); err != nil {
+			return err
+		}
+		if p.peek().kind != tokenComma {
+			break
+		}
+		p.next()
+	}
+	return p.expect(tokenRParen, "\",\" or \")\"")
+}
+
+// parseOperator parses the (optional) operator following an operand
+func (p *parser) parseOperator() error {
+	t := p.peek()
+	switch {
+	case t.kind == tokenOperator:
+		p.next()
+		return p.parseValue()
+
+	case t.is("not") && p.peekAt(1).is("in"):
+		p.next()
+		p.next()
+		return p.parseList()
+
+	case t.is("in"):
+		p.next()
+		return p.parseList()
+
+	case t.is("contains"):
+		p.next()
+		if p.peek().is("any") || p.peek().is("all") {
+			p.next()
+			return p.parseList()
+		}
+		if p.peek().kind == tokenLParen {
+			return p.parseList()
+		}
+		return p.parseValue()
+
+	case t.is("is"):
+		p.next()
+		if p.peek().is("not") {
+			p.next()
+		}
+		if n := p.next(); !n.is("defined") && !n.is("empty") {
+			return p.unexpected(n, "defined or empty")
+		}
+		return nil
+
+	case t.is("within"):
+		p.next()
+		if n := p.peek(); !n.is("circle") {
+			return p.unexpected(n, "circle")
+		}
+		return p.parseOperand()
+	}
+	return nil
+}
+
+func (p *parser) parseValue() error {
+	if p.peek().kind == tokenLParen {
+		return p.unexpected(p.peek(), "a value")
+	}
+	return p.parseOperand()
+}
+
+func (p *parser) parseList() error {
+	if err := p.expect(tokenLParen, "\"(\""); err != nil {
+		return err
+	}
+	for {
+		if err := p.parseValue(); err != nil {
+			return err
+		}
+		if p.peek().kind != tokenComma {
+			break
+		}
+		p.next()
+	}
+	return p.expect(tokenRParen, "\",\" or \")\"")
+}
@@ -0,0 +1,58 @@
+package predicate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		`sku = "SKU-1"`,
+		`lineItemTotal(sku = "SKU-1") > "10.00 EUR"`,
+		`lineItemCount(1 = 1) >= 2 and customer.email is defined`,
+		`country = "DE" or (country = "NL" and not (customerGroup.key = "b2b"))`,
+		`custom.brand in ("abc", "def")`,
+		`attributes.color not in ("red")`,
+		`lineItemExists(attributes.size contains any ("S", "M")) = true`,
+		`categories.id contains "8f3c"`,
+		`shippingAddress.city is not empty`,
+		`masterData(current(name(en = "Shirt")))`,
+		`obj.totalPrice.centAmount > 1000 AND obj.country = "DE"`,
+		`geoLocation within circle(13.37770, 52.51627, 1000)`,
+		`taxedPrice.totalGross.centAmount <> -100`,
+		`createdAt > "2024-01-01T00:00:00.000Z"`,
+		`name(en = "a \"quoted\" name")`,
+		`true`,
+	}
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			assert.NoError(t, Validate(input))
+		})
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	cases := []struct {
+		input string
+		err   string
+	}{
+		{``, "invalid predicate at column 1: predicate is empty"},
+		{`sku = `, "invalid predicate at column 7: expected a field, function or value but found end of expression"},
+		{`sku = "abc`, "invalid predicate at column 7: unterminated string"},
+		{`sku == "abc"`, "invalid predicate at column 6: expected a field, function or value but found \"=\""},
+		{`sku = "a" and`, "invalid predicate at column 14: expected a field, function or value but found end of expression"},
+		{`(sku = "a"`, "invalid predicate at column 11: expected \")\" but found end of expression"},
+		{`sku in "a"`, "invalid predicate at column 8: expected \"(\" but found \"a\""},
+		{`sku is defind`, "invalid predicate at column 8: expected defined or empty but found \"defind\""},
+		{`sku = "a" "b"`, "invalid predicate at column 11: expected and, or or end of expression but found \"b\""},
+		{`sku ! "a"`, "invalid predicate at column 5: unexpected character '!'"},
+		{`lineItemCount(sku = "a"`, "invalid predicate at column 24: expected \",\" or \")\" but found end of expression"},
+		{`sku = "a" & id = "b"`, "invalid predicate at column 11: unexpected character '&'"},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			assert.EqualError(t, Validate(c.input), c.err)
+		})
+	}
+}
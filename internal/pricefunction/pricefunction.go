@@ -0,0 +1,206 @@
+// Package pricefunction implements a syntax checker for the price functions of
+// CartScore shipping rate tiers, like `(50 * x) + 4950`, where x is the score
+// of the cart.
+//
+// See https://docs.commercetools.com/api/projects/shippingMethods#pricefunction
+package pricefunction
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// SyntaxError is returned when a price function can not be parsed.
+type SyntaxError struct {
+	// Pos is the (zero based) offset of the offending character in the
+	// price function
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid price function at column %d: %s", e.Pos+1, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenVariable
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// Validate checks the syntax of the given price function. The function may
+// only use the variable x, numbers, parentheses and the operators +, -, *, /
+// and %.
+func Validate(input string) error {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return &SyntaxError{Pos: 0, Msg: "price function is empty"}
+	}
+	if err := p.parseExpr(); err != nil {
+		return err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return p.unexpected(t, "operator or end of expression")
+	}
+	return nil
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case r == '+' || r == '-' || r == '*' || r == '/' || r == '%':
+			tokens = append(tokens, token{tokenOperator, string(r), i})
+			i++
+		case r == 'x':
+			tokens = append(tokens, token{tokenVariable, "x", i})
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			if i < len(runes) && runes[i] == '.' {
+				i++
+				if i >= len(runes) || !unicode.IsDigit(runes[i]) {
+					return nil, &SyntaxError{Pos: i, Msg: "expected digit after decimal point"}
+				}
+				for i < len(runes) && unicode.IsDigit(runes[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i]), start})
+		default:
+			return nil, &SyntaxError{
+				Pos: i,
+				Msg: fmt.Sprintf("unexpected character %q, only x, numbers, parentheses and +, -, *, / and %% are allowed", r),
+			}
+		}
+	}
+
+	tokens = append(tokens, token{tokenEOF, "", len(runes)})
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	return &SyntaxError{
+		Pos: t.pos,
+		Msg: fmt.Sprintf("expected %s but found %s", expected, t),
+	}
+}
+
+func (p *parser) isOperator(operators ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, op := range operators {
+		if t.value == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseExpr() error {
+	if err := p.parseTerm(); err != nil {
+		return err
+	}
+	for p.isOperator("+", "-") {
+		p.next()
+		if err := p.parseTerm(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseTerm() error {
+	if err := p.parseUnary(); err != nil {
+		return err
+	}
+	for p.isOperator("*", "/", "%") {
+		p.next()
+		if err := p.parseUnary(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseUnary() error {
+	if p.isOperator("-") {
+		p.next()
+		return p.parseUnary()
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() error {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber, tokenVariable:
+		return nil
+	case tokenLParen:
+		if err := p.parseExpr(); err != nil {
+			return err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return p.unexpected(t, "\")\"")
+		}
+		return nil
+	default:
+		return p.unexpected(t, "number, x or \"(\"")
+	}
+}
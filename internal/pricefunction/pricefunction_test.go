@@ -0,0 +1,45 @@
+package pricefunction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		`(50 * x) + 4950`,
+		`x`,
+		`1000`,
+		`x * x / 2 - 10 % 3`,
+		`-(x - 10) * 2.5`,
+		`((x))`,
+		`100+x*20`,
+	}
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			assert.NoError(t, Validate(input))
+		})
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	cases := []struct {
+		input string
+		err   string
+	}{
+		{``, "invalid price function at column 1: price function is empty"},
+		{`(50 * x) +`, "invalid price function at column 11: expected number, x or \"(\" but found end of expression"},
+		{`(50 * x + 4950`, "invalid price function at column 15: expected \")\" but found end of expression"},
+		{`50 x`, "invalid price function at column 4: expected operator or end of expression but found \"x\""},
+		{`50 * y`, "invalid price function at column 6: unexpected character 'y', only x, numbers, parentheses and +, -, *, / and % are allowed"},
+		{`50 ** x`, "invalid price function at column 5: expected number, x or \"(\" but found \"*\""},
+		{`1. + x`, "invalid price function at column 3: expected digit after decimal point"},
+		{`()`, "invalid price function at column 2: expected number, x or \"(\" but found \")\""},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			assert.EqualError(t, Validate(c.input), c.err)
+		})
+	}
+}
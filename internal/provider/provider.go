@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,10 +20,14 @@ import (
 
 	datasourcestate "github.com/labd/terraform-provider-commercetools/internal/datasource/state"
 	datasourcetype "github.com/labd/terraform-provider-commercetools/internal/datasource/type"
+	"github.com/labd/terraform-provider-commercetools/internal/ephemeral/access_token"
+	"github.com/labd/terraform-provider-commercetools/internal/ephemeral/api_client_secret"
+	"github.com/labd/terraform-provider-commercetools/internal/functions"
 	"github.com/labd/terraform-provider-commercetools/internal/resources/associate_role"
 	"github.com/labd/terraform-provider-commercetools/internal/resources/attribute_group"
 	"github.com/labd/terraform-provider-commercetools/internal/resources/business_unit_company"
 	"github.com/labd/terraform-provider-commercetools/internal/resources/business_unit_division"
+	"github.com/labd/terraform-provider-commercetools/internal/resources/custom_object"
 	"github.com/labd/terraform-provider-commercetools/internal/resources/product_selection"
 	"github.com/labd/terraform-provider-commercetools/internal/resources/project"
 	"github.com/labd/terraform-provider-commercetools/internal/resources/state"
@@ -32,7 +38,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ provider.Provider = &ctProvider{}
+	_ provider.Provider                       = &ctProvider{}
+	_ provider.ProviderWithEphemeralResources = &ctProvider{}
+	_ provider.ProviderWithFunctions          = &ctProvider{}
 )
 
 func New(version string) provider.Provider {
@@ -53,6 +61,11 @@ type ctProviderModel struct {
 	Scopes       types.String `tfsdk:"scopes"`
 	ApiURL       types.String `tfsdk:"api_url"`
 	TokenURL     types.String `tfsdk:"token_url"`
+
+	ValidateLanguages types.Bool `tfsdk:"validate_languages"`
+	RequiredLanguages types.List `tfsdk:"required_languages"`
+
+	ValidateProjectSettings types.Bool `tfsdk:"validate_project_settings"`
 }
 
 // Metadata returns the provider type name.
@@ -91,6 +104,19 @@ func (p *ctProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *p
 				Optional:            true,
 				MarkdownDescription: "The authentication URL of the commercetools platform. https://docs.commercetools.com/api/authorization",
 			},
+			"validate_languages": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Validate the languages of localized strings, like names, descriptions, slugs and enum labels, against the languages of the project during the plan. The project settings are fetched once per run, which requires the `view_project_settings` scope. Languages added to the project in the same apply aren't known during the plan",
+			},
+			"required_languages": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Languages every localized string needs a value for, e.g. `[\"en\", \"de\"]`. Missing languages are reported during the plan. Localized strings which aren't set are not validated",
+			},
+			"validate_project_settings": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Validate currencies, countries and shipping rate tiers, like the currencies of shipping rates and the countries of stores, against the settings of the project during the plan. Defaults to `false`. The project settings are fetched once per run, which requires the `view_project_settings` scope. Leave this disabled when the currencies, countries or shipping rate input type of the project are changed in the same apply, e.g. when bootstrapping a project with `commercetools_project_settings`. The plan fails when the client isn't allowed to view the project settings",
+			},
 		},
 	}
 }
@@ -146,6 +172,14 @@ func (p *ctProvider) Configure(ctx context.Context, req provider.ConfigureReques
 		authURL = config.TokenURL.ValueString()
 	}
 
+	var requiredLanguages []string
+	if !config.RequiredLanguages.IsUnknown() && !config.RequiredLanguages.IsNull() {
+		resp.Diagnostics.Append(config.RequiredLanguages.ElementsAs(ctx, &requiredLanguages, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	oauthScopes := strings.Split(scopesRaw, " ")
 	oauth2Config := &clientcredentials.Config{
 		ClientID:     clientID,
@@ -172,12 +206,31 @@ func (p *ctProvider) Configure(ctx context.Context, req provider.ConfigureReques
 		return
 	}
 
+	projectClient := client.WithProjectKey(projectKey)
+	settings := utils.ProjectSettingsFor(apiURL, projectKey)
 	data := &utils.ProviderData{
-		Client: client.WithProjectKey(projectKey),
-		Mutex:  utils.NewMutexKV(),
+		Client:           projectClient,
+		Mutex:            utils.NewMutexKV(),
+		ProjectKey:       projectKey,
+		Credentials:      oauth2Config,
+		TypeCache:        utils.TypeCacheFor(apiURL, projectKey),
+		APIClientSecrets: utils.APIClientSecretsFor(apiURL, projectKey),
+		ProjectSettings:  settings,
+		Languages: utils.NewLanguageValidator(
+			projectClient,
+			settings,
+			config.ValidateLanguages.ValueBool(),
+			requiredLanguages,
+		),
+		ProjectValidator: utils.NewProjectValidator(
+			projectClient,
+			settings,
+			config.ValidateProjectSettings.ValueBool(),
+		),
 	}
 	resp.DataSourceData = data
 	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -188,6 +241,24 @@ func (p *ctProvider) DataSources(_ context.Context) []func() datasource.DataSour
 	}
 }
 
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *ctProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		access_token.NewEphemeralResource,
+		api_client_secret.NewEphemeralResource,
+	}
+}
+
+// Functions defines the provider functions implemented in the provider.
+func (p *ctProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewToCentAmountFunction,
+		functions.NewLocalizedFunction,
+		functions.NewSlugifyFunction,
+		functions.NewValidatePredicateFunction,
+	}
+}
+
 // Resources defines the resources implemented in the provider.
 func (p *ctProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
@@ -200,5 +271,6 @@ func (p *ctProvider) Resources(_ context.Context) []func() resource.Resource {
 		product_selection.NewResource,
 		business_unit_company.NewCompanyResource,
 		business_unit_division.NewDivisionResource,
+		custom_object.NewResource,
 	}
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/labd/terraform-provider-commercetools/commercetools"
 	"github.com/labd/terraform-provider-commercetools/internal/sharedtypes"
 	"regexp"
 	"sort"
@@ -28,10 +27,13 @@ var (
 	_ resource.Resource                = &associateRoleResource{}
 	_ resource.ResourceWithConfigure   = &associateRoleResource{}
 	_ resource.ResourceWithImportState = &associateRoleResource{}
+	_ resource.ResourceWithModifyPlan  = &associateRoleResource{}
 )
 
 type associateRoleResource struct {
-	client *platform.ByProjectKeyRequestBuilder
+	client    *platform.ByProjectKeyRequestBuilder
+	typeCache *utils.TypeCache
+	project   *utils.ProjectValidator
 }
 
 // NewResource is a helper function to simplify the provider implementation.
@@ -140,7 +142,7 @@ func (r *associateRoleResource) Create(ctx context.Context, req resource.CreateR
 	var customType *platform.Type
 	var err error
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(r.client), *plan.Custom.TypeID)
+		customType, err = r.typeCache.Get(ctx, r.client, *plan.Custom.TypeID)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error getting custom type",
@@ -184,6 +186,8 @@ func (r *associateRoleResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = resp.State.Set(ctx, current)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -249,7 +253,7 @@ func (r *associateRoleResource) Read(ctx context.Context, req resource.ReadReque
 
 	var customType *platform.Type
 	if state.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(r.client), *state.Custom.TypeID)
+		customType, err = r.typeCache.Get(ctx, r.client, *state.Custom.TypeID)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error getting custom type",
@@ -280,6 +284,8 @@ func (r *associateRoleResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	current.Custom.UsePriorRepresentation(state.Custom)
+
 	// Set current data as state.
 	diags = resp.State.Set(ctx, current)
 	resp.Diagnostics.Append(diags...)
@@ -307,7 +313,7 @@ func (r *associateRoleResource) Update(ctx context.Context, req resource.UpdateR
 
 	var customType *platform.Type
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(r.client), *plan.Custom.TypeID)
+		customType, err = r.typeCache.Get(ctx, r.client, *plan.Custom.TypeID)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error getting custom type",
@@ -363,6 +369,8 @@ func (r *associateRoleResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = resp.State.Set(ctx, current)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -370,6 +378,12 @@ func (r *associateRoleResource) Update(ctx context.Context, req resource.UpdateR
 	}
 }
 
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *associateRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(sharedtypes.ValidateCustomPlan(ctx, r.client, r.typeCache, req.State, req.Plan)...)
+	resp.Diagnostics.Append(sharedtypes.ValidateCustomCurrenciesPlan(ctx, r.project, req.Plan)...)
+}
+
 // Configure implements resource.ResourceWithConfigure.
 func (r *associateRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -378,6 +392,8 @@ func (r *associateRoleResource) Configure(_ context.Context, req resource.Config
 
 	data := req.ProviderData.(*utils.ProviderData)
 	r.client = data.Client
+	r.typeCache = data.TypeCache
+	r.project = data.ProjectValidator
 }
 
 // ImportState implements resource.ResourceWithImportState.
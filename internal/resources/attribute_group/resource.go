@@ -15,6 +15,7 @@ import (
 	"github.com/labd/commercetools-go-sdk/platform"
 
 	"github.com/labd/terraform-provider-commercetools/internal/customtypes"
+	"github.com/labd/terraform-provider-commercetools/internal/sharedtypes"
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
@@ -23,6 +24,7 @@ var (
 	_ resource.Resource                = &Resource{}
 	_ resource.ResourceWithConfigure   = &Resource{}
 	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
 )
 
 func NewResource() resource.Resource {
@@ -30,8 +32,9 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client *platform.ByProjectKeyRequestBuilder
-	mutex  *utils.MutexKV
+	client    *platform.ByProjectKeyRequestBuilder
+	mutex     *utils.MutexKV
+	languages *utils.LanguageValidator
 }
 
 // Metadata returns the data source type name.
@@ -108,6 +111,12 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _
 	data := req.ProviderData.(*utils.ProviderData)
 	r.client = data.Client
 	r.mutex = data.Mutex
+	r.languages = data.Languages
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(sharedtypes.ValidateLocalizedStringsPlan(ctx, r.languages, req.Plan)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
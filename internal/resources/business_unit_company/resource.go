@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/labd/terraform-provider-commercetools/commercetools"
 	"github.com/labd/terraform-provider-commercetools/internal/sharedtypes"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -27,10 +26,13 @@ var (
 	_ resource.Resource                = &companyResource{}
 	_ resource.ResourceWithConfigure   = &companyResource{}
 	_ resource.ResourceWithImportState = &companyResource{}
+	_ resource.ResourceWithModifyPlan  = &companyResource{}
 )
 
 type companyResource struct {
-	client *platform.ByProjectKeyRequestBuilder
+	client    *platform.ByProjectKeyRequestBuilder
+	typeCache *utils.TypeCache
+	project   *utils.ProjectValidator
 }
 
 func NewCompanyResource() resource.Resource {
@@ -128,6 +130,12 @@ func (b *companyResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
 }
 
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (b *companyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	res.Diagnostics.Append(sharedtypes.ValidateCustomPlan(ctx, b.client, b.typeCache, req.State, req.Plan)...)
+	res.Diagnostics.Append(sharedtypes.ValidateCustomCurrenciesPlan(ctx, b.project, req.Plan)...)
+}
+
 // Configure implements resource.ResourceWithConfigure.
 func (b *companyResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -140,6 +148,8 @@ func (b *companyResource) Configure(ctx context.Context, req resource.ConfigureR
 	}
 
 	b.client = data.Client
+	b.typeCache = data.TypeCache
+	b.project = data.ProjectValidator
 }
 
 // Create implements resource.Resource.
@@ -154,7 +164,7 @@ func (b *companyResource) Create(ctx context.Context, req resource.CreateRequest
 	var customType *platform.Type
 	var err error
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(b.client), *plan.Custom.TypeID)
+		customType, err = b.typeCache.Get(ctx, b.client, *plan.Custom.TypeID)
 		if err != nil {
 			res.Diagnostics.AddError(
 				"Error getting custom type",
@@ -199,6 +209,8 @@ func (b *companyResource) Create(ctx context.Context, req resource.CreateRequest
 
 	current.CustomerGroups = normalizeCustomerGroups(current.CustomerGroups, plan.CustomerGroups)
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = res.State.Set(ctx, &current)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
@@ -240,6 +252,8 @@ func (b *companyResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	current.CustomerGroups = normalizeCustomerGroups(current.CustomerGroups, state.CustomerGroups)
 
+	current.Custom.UsePriorRepresentation(state.Custom)
+
 	diags = res.State.Set(ctx, current)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
@@ -266,7 +280,7 @@ func (b *companyResource) Update(ctx context.Context, req resource.UpdateRequest
 	var customType *platform.Type
 	var err error
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(b.client), *plan.Custom.TypeID)
+		customType, err = b.typeCache.Get(ctx, b.client, *plan.Custom.TypeID)
 		if err != nil {
 			res.Diagnostics.AddError(
 				"Error getting custom type",
@@ -316,6 +330,8 @@ func (b *companyResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	current.CustomerGroups = normalizeCustomerGroups(current.CustomerGroups, plan.CustomerGroups)
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = res.State.Set(ctx, &current)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
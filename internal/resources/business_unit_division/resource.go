@@ -8,7 +8,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/labd/terraform-provider-commercetools/commercetools"
 	"github.com/labd/terraform-provider-commercetools/internal/sharedtypes"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -28,10 +27,13 @@ var (
 	_ resource.Resource                = &divisionResource{}
 	_ resource.ResourceWithConfigure   = &divisionResource{}
 	_ resource.ResourceWithImportState = &divisionResource{}
+	_ resource.ResourceWithModifyPlan  = &divisionResource{}
 )
 
 type divisionResource struct {
-	client *platform.ByProjectKeyRequestBuilder
+	client    *platform.ByProjectKeyRequestBuilder
+	typeCache *utils.TypeCache
+	project   *utils.ProjectValidator
 }
 
 // NewDivisionResource creates a new resource for the Division type.
@@ -191,6 +193,12 @@ func (b *divisionResource) ImportState(ctx context.Context, req resource.ImportS
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
 }
 
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (b *divisionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	res.Diagnostics.Append(sharedtypes.ValidateCustomPlan(ctx, b.client, b.typeCache, req.State, req.Plan)...)
+	res.Diagnostics.Append(sharedtypes.ValidateCustomCurrenciesPlan(ctx, b.project, req.Plan)...)
+}
+
 // Configure implements resource.ResourceWithConfigure.
 func (b *divisionResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -203,6 +211,8 @@ func (b *divisionResource) Configure(ctx context.Context, req resource.Configure
 	}
 
 	b.client = data.Client
+	b.typeCache = data.TypeCache
+	b.project = data.ProjectValidator
 }
 
 // Create implements resource.Resource.
@@ -217,7 +227,7 @@ func (b *divisionResource) Create(ctx context.Context, req resource.CreateReques
 	var customType *platform.Type
 	var err error
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(b.client), *plan.Custom.TypeID)
+		customType, err = b.typeCache.Get(ctx, b.client, *plan.Custom.TypeID)
 		if err != nil {
 			res.Diagnostics.AddError(
 				"Error getting custom type",
@@ -263,6 +273,8 @@ func (b *divisionResource) Create(ctx context.Context, req resource.CreateReques
 
 	current.CustomerGroups = normalizeCustomerGroups(current.CustomerGroups, plan.CustomerGroups)
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = res.State.Set(ctx, current)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
@@ -337,6 +349,8 @@ func (b *divisionResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	current.CustomerGroups = normalizeCustomerGroups(current.CustomerGroups, state.CustomerGroups)
 
+	current.Custom.UsePriorRepresentation(state.Custom)
+
 	diags = res.State.Set(ctx, current)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
@@ -363,7 +377,7 @@ func (b *divisionResource) Update(ctx context.Context, req resource.UpdateReques
 	var customType *platform.Type
 	var err error
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(b.client), *plan.Custom.TypeID)
+		customType, err = b.typeCache.Get(ctx, b.client, *plan.Custom.TypeID)
 		if err != nil {
 			res.Diagnostics.AddError(
 				"Error getting custom type",
@@ -413,6 +427,8 @@ func (b *divisionResource) Update(ctx context.Context, req resource.UpdateReques
 
 	current.CustomerGroups = normalizeCustomerGroups(current.CustomerGroups, plan.CustomerGroups)
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = res.State.Set(ctx, &current)
 	res.Diagnostics.Append(diags...)
 	if res.Diagnostics.HasError() {
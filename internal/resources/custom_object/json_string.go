@@ -0,0 +1,103 @@
+package custom_object
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+var (
+	_ basetypes.StringTypable                    = JSONStringType{}
+	_ basetypes.StringValuableWithSemanticEquals = JSONStringValue{}
+)
+
+// JSONStringType is a string containing a JSON document. Values which only
+// differ in whitespace, the order of keys or the formatting of numbers are
+// semantically equal, so the value read from commercetools doesn't replace the
+// value from the configuration.
+type JSONStringType struct {
+	basetypes.StringType
+}
+
+func NewJSONStringType() JSONStringType {
+	return JSONStringType{}
+}
+
+func (t JSONStringType) String() string {
+	return "custom_object.JSONStringType"
+}
+
+func (t JSONStringType) ValueType(_ context.Context) attr.Value {
+	return JSONStringValue{}
+}
+
+func (t JSONStringType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONStringType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t JSONStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONStringValue{StringValue: in}, nil
+}
+
+func (t JSONStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := val.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", val)
+	}
+	return JSONStringValue{StringValue: stringValue}, nil
+}
+
+type JSONStringValue struct {
+	basetypes.StringValue
+}
+
+func NewJSONStringNull() JSONStringValue {
+	return JSONStringValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewJSONStringValue(value string) JSONStringValue {
+	return JSONStringValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v JSONStringValue) Type(_ context.Context) attr.Type {
+	return JSONStringType{}
+}
+
+func (v JSONStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(JSONStringValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if both values decode to the same JSON
+// value.
+func (v JSONStringValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSONStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T.", v, newValuable),
+		)
+		return false, diags
+	}
+	return utils.JSONEqual(v.ValueString(), newValue.ValueString()), diags
+}
@@ -0,0 +1,322 @@
+package custom_object
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/labd/commercetools-go-sdk/platform"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+const (
+	MergeStrategyReplace    = "replace"
+	MergeStrategyMergePatch = "merge_patch"
+)
+
+// errUnknownValue is returned when the value is not known yet during the plan
+var errUnknownValue = errors.New("value is unknown")
+
+type CustomObject struct {
+	ID            types.String    `tfsdk:"id"`
+	Container     types.String    `tfsdk:"container"`
+	Key           types.String    `tfsdk:"key"`
+	Value         JSONStringValue `tfsdk:"value"`
+	ValueDynamic  types.Dynamic   `tfsdk:"value_dynamic"`
+	VersionGuard  types.Bool      `tfsdk:"version_guard"`
+	MergeStrategy types.String    `tfsdk:"merge_strategy"`
+	Version       types.Int64     `tfsdk:"version"`
+}
+
+func NewCustomObjectFromNative(n *platform.CustomObject) (CustomObject, error) {
+	value, err := json.Marshal(n.Value)
+	if err != nil {
+		return CustomObject{}, err
+	}
+
+	return CustomObject{
+		ID:            types.StringValue(n.ID),
+		Container:     types.StringValue(n.Container),
+		Key:           types.StringValue(n.Key),
+		Value:         NewJSONStringValue(string(value)),
+		ValueDynamic:  types.DynamicNull(),
+		VersionGuard:  types.BoolNull(),
+		MergeStrategy: types.StringNull(),
+		Version:       types.Int64Value(int64(n.Version)),
+	}, nil
+}
+
+// value returns the decoded JSON value of the custom object, from either the
+// value or the value_dynamic attribute.
+func (c CustomObject) value() (any, error) {
+	if !c.ValueDynamic.IsNull() {
+		return dynamicToJSON(c.ValueDynamic)
+	}
+	if c.Value.IsUnknown() {
+		return nil, errUnknownValue
+	}
+	if c.Value.IsNull() {
+		return nil, fmt.Errorf("one of value or value_dynamic needs to be set")
+	}
+
+	result, err := utils.DecodeJSON(c.Value.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("value is not valid JSON: %w", err)
+	}
+	return result, nil
+}
+
+func (c CustomObject) draft() (platform.CustomObjectDraft, error) {
+	value, err := c.value()
+	if err != nil {
+		return platform.CustomObjectDraft{}, err
+	}
+	return platform.CustomObjectDraft{
+		Container: c.Container.ValueString(),
+		Key:       c.Key.ValueString(),
+		Value:     value,
+	}, nil
+}
+
+// isMoved returns whether the container or the key changes, which creates a
+// new custom object in commercetools.
+func (c CustomObject) isMoved(plan CustomObject) bool {
+	return !c.Container.Equal(plan.Container) || !c.Key.Equal(plan.Key)
+}
+
+// isMergePatch returns whether the value is merged into the value in
+// commercetools instead of replacing it.
+func (c CustomObject) isMergePatch() bool {
+	return c.MergeStrategy.ValueString() == MergeStrategyMergePatch
+}
+
+// matchPrior sets the value in the same attribute as the prior plan or state.
+// Values which are semantically equal to the prior value are kept as is, so
+// re-serialised JSON doesn't cause a diff. When the value is merged only the
+// part of the value managed by the prior value is kept.
+func (c *CustomObject) matchPrior(prior CustomObject) error {
+	c.VersionGuard = prior.VersionGuard
+	c.MergeStrategy = prior.MergeStrategy
+
+	current, err := c.value()
+	if err != nil {
+		return err
+	}
+	previous, previousErr := prior.value()
+
+	if prior.isMergePatch() && previousErr == nil {
+		current = utils.JSONMergePatchProjection(current, previous)
+		data, err := json.Marshal(current)
+		if err != nil {
+			return err
+		}
+		c.Value = NewJSONStringValue(string(data))
+	}
+
+	if prior.ValueDynamic.IsNull() {
+		if previousErr == nil && utils.JSONValuesEqual(previous, current) {
+			c.Value = prior.Value
+		}
+		return nil
+	}
+
+	c.Value = NewJSONStringNull()
+	if previousErr == nil && utils.JSONValuesEqual(previous, current) {
+		c.ValueDynamic = prior.ValueDynamic
+		return nil
+	}
+
+	value, err := jsonToDynamic(current)
+	if err != nil {
+		return err
+	}
+	c.ValueDynamic = types.DynamicValue(value)
+	return nil
+}
+
+// removePatch returns the merge patch which removes the keys managed by the
+// custom object from the value in commercetools. It returns false when the
+// value isn't merged or isn't an object, in which case the whole custom object
+// is deleted.
+func (c CustomObject) removePatch() (map[string]any, bool) {
+	if !c.isMergePatch() {
+		return nil, false
+	}
+	value, err := c.value()
+	if err != nil {
+		return nil, false
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	result := make(map[string]any, len(object))
+	for key := range object {
+		result[key] = nil
+	}
+	return result, true
+}
+
+// checkVersionGuard returns an error when the custom object was modified
+// outside of Terraform since the last apply.
+func checkVersionGuard(container, key string, current, applied int64) error {
+	if current == applied {
+		return nil
+	}
+	return fmt.Errorf(
+		"custom object with container %s and key %s was modified outside of Terraform: the current version is "+
+			"%d, but the last version applied by Terraform is %d. Add the remote changes to the configuration "+
+			"or disable version_guard to overwrite them",
+		container, key, current, applied)
+}
+
+// valueChangesDetail describes the changed paths for the plan output
+func valueChangesDetail(changes []utils.JSONChange) string {
+	const maxChanges = 25
+
+	symbols := map[string]string{
+		"added":   "+",
+		"removed": "-",
+		"changed": "~",
+	}
+
+	lines := []string{"The following paths of the value change:"}
+	for i, change := range changes {
+		if i == maxChanges {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(changes)-maxChanges))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", symbols[change.Action], change.Path))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dynamicToJSON converts a dynamic value from the configuration to the value
+// as it is encoded to JSON.
+func dynamicToJSON(value attr.Value) (any, error) {
+	if value.IsUnknown() {
+		return nil, errUnknownValue
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return dynamicToJSON(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		return formatNumber(v.ValueBigFloat()), nil
+	case basetypes.Int64Value:
+		return json.Number(fmt.Sprint(v.ValueInt64())), nil
+	case basetypes.Float64Value:
+		return formatNumber(big.NewFloat(v.ValueFloat64())), nil
+	case basetypes.ObjectValue:
+		return dynamicMapToJSON(v.Attributes())
+	case basetypes.MapValue:
+		return dynamicMapToJSON(v.Elements())
+	case basetypes.ListValue:
+		return dynamicSliceToJSON(v.Elements())
+	case basetypes.SetValue:
+		return dynamicSliceToJSON(v.Elements())
+	case basetypes.TupleValue:
+		return dynamicSliceToJSON(v.Elements())
+	}
+	return nil, fmt.Errorf("unsupported value of type %s", value.Type(context.Background()))
+}
+
+func dynamicMapToJSON(elements map[string]attr.Value) (any, error) {
+	result := make(map[string]any, len(elements))
+	for key, elem := range elements {
+		value, err := dynamicToJSON(elem)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+func dynamicSliceToJSON(elements []attr.Value) (any, error) {
+	result := make([]any, 0, len(elements))
+	for _, elem := range elements {
+		value, err := dynamicToJSON(elem)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func formatNumber(value *big.Float) json.Number {
+	if value.IsInt() {
+		return json.Number(value.Text('f', 0))
+	}
+	return json.Number(value.Text('g', -1))
+}
+
+// jsonToDynamic converts a decoded JSON value to the value as it would be
+// written in HCL, using objects and tuples.
+func jsonToDynamic(value any) (attr.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		number, _, err := big.ParseFloat(string(v), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(number), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, elem := range v {
+			value, err := jsonToDynamic(elem)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, value.Type(context.Background()))
+			elems = append(elems, value)
+		}
+		result, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to convert array: %v", diags)
+		}
+		return result, nil
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for key, elem := range v {
+			value, err := jsonToDynamic(elem)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[key] = value.Type(context.Background())
+			attrs[key] = value
+		}
+		result, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to convert object: %v", diags)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unsupported JSON value of type %T", value)
+}
@@ -0,0 +1,203 @@
+package custom_object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func testDynamicObject(t *testing.T) types.Dynamic {
+	address, diags := types.ObjectValue(
+		map[string]attr.Type{"street": types.StringType, "number": types.NumberType},
+		map[string]attr.Value{"street": types.StringValue("foo"), "number": types.NumberValue(big.NewFloat(10))},
+	)
+	assert.False(t, diags.HasError())
+
+	tags, diags := types.TupleValue(
+		[]attr.Type{types.StringType, types.BoolType, types.NumberType},
+		[]attr.Value{types.StringValue("a"), types.BoolValue(true), types.NumberValue(big.NewFloat(1.5))},
+	)
+	assert.False(t, diags.HasError())
+
+	value, diags := types.ObjectValue(
+		map[string]attr.Type{"address": address.Type(context.Background()), "tags": tags.Type(context.Background())},
+		map[string]attr.Value{"address": address, "tags": tags},
+	)
+	assert.False(t, diags.HasError())
+	return types.DynamicValue(value)
+}
+
+func TestCustomObjectValue(t *testing.T) {
+	object := CustomObject{
+		Value:        NewJSONStringValue(`{"number": 10}`),
+		ValueDynamic: types.DynamicNull(),
+	}
+	value, err := object.value()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"number": json.Number("10")}, value)
+
+	object = CustomObject{
+		Value:        NewJSONStringNull(),
+		ValueDynamic: testDynamicObject(t),
+	}
+	value, err = object.value()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"address": map[string]any{"street": "foo", "number": json.Number("10")},
+		"tags":    []any{"a", true, json.Number("1.5")},
+	}, value)
+
+	object = CustomObject{
+		Value:        NewJSONStringValue(`{"number": 10`),
+		ValueDynamic: types.DynamicNull(),
+	}
+	_, err = object.value()
+	assert.ErrorContains(t, err, "value is not valid JSON")
+
+	object = CustomObject{
+		Value:        NewJSONStringNull(),
+		ValueDynamic: types.DynamicUnknown(),
+	}
+	_, err = object.value()
+	assert.ErrorIs(t, err, errUnknownValue)
+}
+
+func TestJSONToDynamic(t *testing.T) {
+	expected := testDynamicObject(t)
+	value, err := dynamicToJSON(expected)
+	assert.NoError(t, err)
+
+	result, err := jsonToDynamic(value)
+	assert.NoError(t, err)
+	assert.True(t, expected.UnderlyingValue().Equal(result), "expected %s, got %s", expected, result)
+
+	result, err = jsonToDynamic(map[string]any{"empty": nil})
+	assert.NoError(t, err)
+	value, err = dynamicToJSON(result)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"empty": nil}, value)
+}
+
+func TestNewCustomObjectFromNative(t *testing.T) {
+	native := &platform.CustomObject{
+		ID:        "1234",
+		Version:   2,
+		Container: "foobar",
+		Key:       "value",
+		Value:     map[string]any{"number": float64(10)},
+	}
+
+	t.Run("keeps the formatting of the value", func(t *testing.T) {
+		prior := CustomObject{
+			Value:        NewJSONStringValue("{\n  \"number\": 10.0\n}"),
+			ValueDynamic: types.DynamicNull(),
+		}
+		current, err := NewCustomObjectFromNative(native)
+		assert.NoError(t, err)
+		assert.NoError(t, current.matchPrior(prior))
+
+		assert.Equal(t, CustomObject{
+			ID:           types.StringValue("1234"),
+			Container:    types.StringValue("foobar"),
+			Key:          types.StringValue("value"),
+			Value:        prior.Value,
+			ValueDynamic: types.DynamicNull(),
+			Version:      types.Int64Value(2),
+		}, current)
+	})
+
+	t.Run("uses the changed value", func(t *testing.T) {
+		prior := CustomObject{
+			Value:        NewJSONStringValue(`{"number": 20}`),
+			ValueDynamic: types.DynamicNull(),
+		}
+		current, err := NewCustomObjectFromNative(native)
+		assert.NoError(t, err)
+		assert.NoError(t, current.matchPrior(prior))
+		assert.Equal(t, NewJSONStringValue(`{"number":10}`), current.Value)
+	})
+
+	t.Run("keeps the dynamic value", func(t *testing.T) {
+		value := types.DynamicValue(types.ObjectValueMust(
+			map[string]attr.Type{"number": types.NumberType},
+			map[string]attr.Value{"number": types.NumberValue(big.NewFloat(10))},
+		))
+		prior := CustomObject{
+			Value:        NewJSONStringNull(),
+			ValueDynamic: value,
+		}
+		current, err := NewCustomObjectFromNative(native)
+		assert.NoError(t, err)
+		assert.NoError(t, current.matchPrior(prior))
+		assert.Equal(t, NewJSONStringNull(), current.Value)
+		assert.Equal(t, value, current.ValueDynamic)
+	})
+
+	t.Run("converts the changed value to a dynamic value", func(t *testing.T) {
+		prior := CustomObject{
+			Value: NewJSONStringNull(),
+			ValueDynamic: types.DynamicValue(types.ObjectValueMust(
+				map[string]attr.Type{"number": types.NumberType},
+				map[string]attr.Value{"number": types.NumberValue(big.NewFloat(20))},
+			)),
+		}
+		current, err := NewCustomObjectFromNative(native)
+		assert.NoError(t, err)
+		assert.NoError(t, current.matchPrior(prior))
+		assert.Equal(t, NewJSONStringNull(), current.Value)
+
+		value, err := dynamicToJSON(current.ValueDynamic)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"number": json.Number("10")}, value)
+	})
+}
+
+func TestJSONStringSemanticEquals(t *testing.T) {
+	var cases = []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{`{"a": 1, "b": 2}`, `{"b":2,"a":1}`, true},
+		{`{"a": 1.0}`, `{"a": 1}`, true},
+		{`{"a": 1}`, `{"a": 2}`, false},
+		{`[1, 2]`, `[2, 1]`, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s %s", tc.old, tc.new), func(t *testing.T) {
+			equal, diags := NewJSONStringValue(tc.old).StringSemanticEquals(context.Background(), NewJSONStringValue(tc.new))
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tc.expected, equal)
+		})
+	}
+}
+
+func TestValueChangesDetail(t *testing.T) {
+	assert.Equal(t,
+		"The following paths of the value change:\n"+
+			"  + $.address.city\n"+
+			"  ~ $.address.street\n"+
+			"  - $.name",
+		valueChangesDetail([]utils.JSONChange{
+			{Path: "$.address.city", Action: "added"},
+			{Path: "$.address.street", Action: "changed"},
+			{Path: "$.name", Action: "removed"},
+		}),
+	)
+
+	var changes []utils.JSONChange
+	for i := 0; i < 30; i++ {
+		changes = append(changes, utils.JSONChange{Path: fmt.Sprintf("$.items[%d]", i), Action: "added"})
+	}
+	assert.Contains(t, valueChangesDetail(changes), "  + $.items[24]\n  ... and 5 more")
+}
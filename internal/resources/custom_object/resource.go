@@ -0,0 +1,576 @@
+package custom_object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk_resource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/labd/commercetools-go-sdk/platform"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &customObjectResource{}
+	_ resource.ResourceWithConfigure        = &customObjectResource{}
+	_ resource.ResourceWithImportState      = &customObjectResource{}
+	_ resource.ResourceWithConfigValidators = &customObjectResource{}
+	_ resource.ResourceWithModifyPlan       = &customObjectResource{}
+)
+
+// NewResource is a helper function to simplify the provider implementation.
+func NewResource() resource.Resource {
+	return &customObjectResource{}
+}
+
+// customObjectResource is the resource implementation.
+type customObjectResource struct {
+	client *platform.ByProjectKeyRequestBuilder
+	mutex  *utils.MutexKV
+}
+
+// Metadata returns the resource type name.
+func (r *customObjectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_object"
+}
+
+// Schema defines the schema for the resource.
+func (r *customObjectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Custom objects are a way to store arbitrary JSON-formatted data on the commercetools platform. " +
+			"It allows you to persist data that does not fit the standard data model. This frees your application " +
+			"completely from any third-party persistence solution and means that all your data stays on the " +
+			"commercetools platform.\n\n" +
+			"See also the [Custom Object API Documentation](https://docs.commercetools.com/api/projects/custom-objects)",
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"container": schema.StringAttribute{
+				Description: "A namespace to group custom objects matching the pattern '[-_~.a-zA-Z0-9]+'",
+				Required:    true,
+			},
+			"key": schema.StringAttribute{
+				Description: "String matching the pattern '[-_~.a-zA-Z0-9]+'",
+				Required:    true,
+			},
+			"value": schema.StringAttribute{
+				CustomType: NewJSONStringType(),
+				Description: "JSON types Number, String, Boolean, Array, Object. Differences in whitespace, the " +
+					"order of keys and the formatting of numbers don't cause drift and don't update the custom " +
+					"object. Conflicts with `value_dynamic`",
+				Optional: true,
+			},
+			"value_dynamic": schema.DynamicAttribute{
+				Description: "The value as a native Terraform value instead of a JSON encoded string, e.g. " +
+					"`{ number = 10 }`. Objects and maps are stored as JSON objects, lists, sets and tuples as " +
+					"JSON arrays. Conflicts with `value`",
+				Optional: true,
+			},
+			"version_guard": schema.BoolAttribute{
+				Description: "Fail the plan and the apply when the custom object was modified outside of " +
+					"Terraform since the last apply, instead of overwriting these changes. The first apply after " +
+					"enabling the guard records the current version. With the `merge_patch` merge strategy " +
+					"changes to keys which aren't managed by Terraform also trip the guard",
+				Optional: true,
+			},
+			"merge_strategy": schema.StringAttribute{
+				Description: "How the value is written to commercetools. `replace` (the default) replaces the " +
+					"value. `merge_patch` applies the value as a [JSON merge patch](https://www.rfc-editor.org/rfc/rfc7386) " +
+					"to the value in commercetools, so keys written by applications are kept. Only the keys of the " +
+					"value are compared to detect drift and only these keys are removed on destroy, unless no other " +
+					"keys remain",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(MergeStrategyReplace, MergeStrategyMergePatch),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators returns the validators for the resource configuration.
+func (r *customObjectResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("value"),
+			path.MatchRoot("value_dynamic"),
+		),
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *customObjectResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*utils.ProviderData)
+	r.client = data.Client
+	r.mutex = data.Mutex
+}
+
+// ModifyPlan keeps the id when the custom object isn't moved, reports which
+// paths of the JSON value change and checks the version guard.
+func (r *customObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state CustomObject
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	moved := state.isMoved(plan)
+	if !moved {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), state.ID)...)
+	}
+
+	newValue, err := plan.value()
+	if err != nil && !errors.Is(err, errUnknownValue) {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid custom object value", err.Error())
+		return
+	}
+	known := err == nil
+
+	var changes []utils.JSONChange
+	if oldValue, err := state.value(); known && err == nil {
+		changes = utils.JSONChanges(oldValue, newValue)
+
+		// The plan output of value_dynamic already shows the changed paths
+		if len(changes) > 0 && plan.ValueDynamic.IsNull() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("value"),
+				fmt.Sprintf("Value of custom object %s/%s changes", plan.Container.ValueString(), plan.Key.ValueString()),
+				valueChangesDetail(changes),
+			)
+		}
+	}
+
+	if !plan.VersionGuard.ValueBool() {
+		return
+	}
+
+	applied, ok, diags := appliedVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	current := state.Version.ValueInt64()
+
+	switch {
+	case ok && (moved || !known || len(changes) > 0):
+		err := checkVersionGuard(state.Container.ValueString(), state.Key.ValueString(), current, applied)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("version_guard"), "Custom object modified outside of Terraform", err.Error())
+		}
+	case !ok || applied != current:
+		// The configuration matches the value in commercetools, so the
+		// current version is recorded as the applied version on the next
+		// apply
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Unknown())...)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *customObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CustomObject
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.lockContainers(plan.Container.ValueString())
+	defer unlock()
+
+	res, err := r.write(ctx, plan, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating custom object", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setState(ctx, &resp.State, res, plan)...)
+	resp.Diagnostics.Append(setAppliedVersion(ctx, resp.Private, int64(res.Version))...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *customObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CustomObject
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := r.get(ctx, state.Container.ValueString(), state.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading custom object",
+			"Could not retrieve custom object, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(setState(ctx, &resp.State, res, state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *customObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CustomObject
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state CustomObject
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.lockContainers(state.Container.ValueString(), plan.Container.ValueString())
+	defer unlock()
+
+	// The version which was applied last is only checked when the guard is
+	// enabled
+	var guard *int
+	if plan.VersionGuard.ValueBool() {
+		applied, ok, diags := appliedVersion(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if ok {
+			guard = utils.IntRef(int(applied))
+		}
+	}
+
+	if state.isMoved(plan) {
+		// If the container or key has changed we need to delete the old object
+		// and create the new object. We first want to create the new value and
+		// then delete the old one
+		res, err := r.write(ctx, plan, nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating custom object", err.Error())
+			return
+		}
+
+		err = r.remove(ctx, state, guard, true)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating custom object",
+				fmt.Sprintf("Created custom object with container %s and key %s, but could not delete the "+
+					"previous custom object: %s", plan.Container.ValueString(), plan.Key.ValueString(), err),
+			)
+		}
+		resp.Diagnostics.Append(setState(ctx, &resp.State, res, plan)...)
+		resp.Diagnostics.Append(setAppliedVersion(ctx, resp.Private, int64(res.Version))...)
+		return
+	}
+
+	value, err := plan.value()
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating custom object", err.Error())
+		return
+	}
+
+	// Switching between value and value_dynamic, reformatting the JSON or
+	// changing the settings doesn't require an update of the custom object.
+	// Switching from merging to replacing the value does, since the value in
+	// commercetools can contain keys which aren't managed by Terraform.
+	previous, err := state.value()
+	replaceMerged := state.isMergePatch() && !plan.isMergePatch()
+	if err == nil && utils.JSONValuesEqual(previous, value) && !replaceMerged {
+		plan.ID = state.ID
+		plan.Version = state.Version
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		resp.Diagnostics.Append(setAppliedVersion(ctx, resp.Private, state.Version.ValueInt64())...)
+		return
+	}
+
+	// Without the guard the value is only written when it wasn't modified
+	// since it was read, unless it is merged
+	expected := guard
+	if expected == nil && !plan.isMergePatch() {
+		expected = utils.IntRef(int(state.Version.ValueInt64()))
+	}
+
+	res, err := r.write(ctx, plan, expected)
+	if err != nil && guard != nil && utils.IsConcurrentModificationError(err) {
+		err = r.versionGuardError(ctx, state, *guard, err)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating custom object",
+			"Could not update custom object, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setState(ctx, &resp.State, res, plan)...)
+	resp.Diagnostics.Append(setAppliedVersion(ctx, resp.Private, int64(res.Version))...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *customObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CustomObject
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.lockContainers(state.Container.ValueString())
+	defer unlock()
+
+	var guard *int
+	if state.VersionGuard.ValueBool() {
+		applied, ok, diags := appliedVersion(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if ok {
+			guard = utils.IntRef(int(applied))
+		}
+	}
+
+	if err := r.remove(ctx, state, guard, false); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting custom object",
+			fmt.Sprintf("Could not delete custom object with container %s and key %s: %s",
+				state.Container.ValueString(), state.Key.ValueString(), err),
+		)
+	}
+}
+
+func (r *customObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// lockContainers locks the containers of the custom object, so custom objects
+// in the same container are written one at a time. It returns the function to
+// unlock the containers again.
+func (r *customObjectResource) lockContainers(containers ...string) func() {
+	var keys []string
+	for _, container := range containers {
+		key := "custom-object-container/" + container
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	// Always lock in the same order to prevent deadlocks
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		r.mutex.Lock(key)
+	}
+	return func() {
+		for i := len(keys) - 1; i >= 0; i-- {
+			r.mutex.Unlock(keys[i])
+		}
+	}
+}
+
+// get returns the custom object, or nil if it doesn't exist
+func (r *customObjectResource) get(ctx context.Context, container, key string) (*platform.CustomObject, error) {
+	res, err := r.client.CustomObjects().WithContainerAndKey(container, key).Get().Execute(ctx)
+	if err != nil {
+		if utils.IsResourceNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// write creates or updates the custom object. When the version is set the
+// write fails if the custom object was modified since that version. With the
+// merge_patch strategy the value is merged into the current value, which is
+// retried when the custom object is modified concurrently.
+func (r *customObjectResource) write(ctx context.Context, plan CustomObject, version *int) (*platform.CustomObject, error) {
+	draft, err := plan.draft()
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.isMergePatch() {
+		draft.Version = version
+		return r.post(ctx, draft)
+	}
+
+	patch := draft.Value
+	var res *platform.CustomObject
+	err = sdk_resource.RetryContext(ctx, 20*time.Second, func() *sdk_resource.RetryError {
+		current, err := r.get(ctx, draft.Container, draft.Key)
+		if err != nil {
+			return utils.ProcessRemoteError(err)
+		}
+
+		var target any
+		draft.Version = nil
+		if current != nil {
+			if version != nil {
+				err := checkVersionGuard(draft.Container, draft.Key, int64(current.Version), int64(*version))
+				if err != nil {
+					return sdk_resource.NonRetryableError(err)
+				}
+			}
+			target = current.Value
+			draft.Version = &current.Version
+		}
+		draft.Value = utils.JSONMergePatch(target, patch)
+
+		res, err = r.client.CustomObjects().Post(draft).Execute(ctx)
+		if utils.IsConcurrentModificationError(err) {
+			return sdk_resource.RetryableError(err)
+		}
+		return utils.ProcessRemoteError(err)
+	})
+	return res, err
+}
+
+// remove deletes the custom object. With the merge_patch strategy only the
+// keys managed by Terraform are removed, unless no other keys remain. When the
+// version is set the removal fails if the custom object was modified since
+// that version.
+func (r *customObjectResource) remove(ctx context.Context, state CustomObject, version *int, dataErasure bool) error {
+	container := state.Container.ValueString()
+	key := state.Key.ValueString()
+	patch, merge := state.removePatch()
+
+	return sdk_resource.RetryContext(ctx, 20*time.Second, func() *sdk_resource.RetryError {
+		current, err := r.get(ctx, container, key)
+		if err != nil {
+			return utils.ProcessRemoteError(err)
+		}
+		if current == nil {
+			return nil
+		}
+		if version != nil {
+			if err := checkVersionGuard(container, key, int64(current.Version), int64(*version)); err != nil {
+				return sdk_resource.NonRetryableError(err)
+			}
+		}
+
+		if merge {
+			remaining, ok := utils.JSONMergePatch(current.Value, patch).(map[string]any)
+			if ok && len(remaining) > 0 {
+				_, err = r.client.CustomObjects().Post(platform.CustomObjectDraft{
+					Container: container,
+					Key:       key,
+					Value:     remaining,
+					Version:   &current.Version,
+				}).Execute(ctx)
+				if utils.IsConcurrentModificationError(err) {
+					return sdk_resource.RetryableError(err)
+				}
+				return utils.ProcessRemoteError(err)
+			}
+		}
+
+		_, err = r.client.
+			CustomObjects().
+			WithContainerAndKey(container, key).
+			Delete().
+			Version(current.Version).
+			DataErasure(dataErasure).
+			Execute(ctx)
+		if utils.IsConcurrentModificationError(err) {
+			return sdk_resource.RetryableError(err)
+		}
+		return utils.ProcessRemoteError(err)
+	})
+}
+
+// versionGuardError returns the error for a write which failed because the
+// custom object was modified since the applied version
+func (r *customObjectResource) versionGuardError(ctx context.Context, state CustomObject, applied int, err error) error {
+	current, getErr := r.get(ctx, state.Container.ValueString(), state.Key.ValueString())
+	if getErr != nil || current == nil {
+		return err
+	}
+	if guardErr := checkVersionGuard(current.Container, current.Key, int64(current.Version), int64(applied)); guardErr != nil {
+		return guardErr
+	}
+	return err
+}
+
+func (r *customObjectResource) post(ctx context.Context, draft platform.CustomObjectDraft) (*platform.CustomObject, error) {
+	var res *platform.CustomObject
+	err := sdk_resource.RetryContext(ctx, 20*time.Second, func() *sdk_resource.RetryError {
+		var err error
+		res, err = r.client.CustomObjects().Post(draft).Execute(ctx)
+		if utils.IsConcurrentModificationError(err) {
+			// Keep the error, so the caller can handle the conflict
+			return sdk_resource.NonRetryableError(fmt.Errorf("%w: %s", err, utils.ProcessRemoteError(err).Err))
+		}
+		return utils.ProcessRemoteError(err)
+	})
+	return res, err
+}
+
+// setState sets the state from the custom object in commercetools, using the
+// representation of the value of the prior plan or state
+func setState(ctx context.Context, state *tfsdk.State, res *platform.CustomObject, prior CustomObject) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	current, err := NewCustomObjectFromNative(res)
+	if err == nil {
+		err = current.matchPrior(prior)
+	}
+	if err != nil {
+		diags.AddError("Error reading custom object", err.Error())
+		return diags
+	}
+	return state.Set(ctx, current)
+}
+
+// appliedVersionKey is the key in the private state of the version of the
+// custom object after the last apply
+const appliedVersionKey = "applied_version"
+
+// privateState is implemented by the private state of the requests and
+// responses of the resource
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// appliedVersion returns the version of the custom object after the last
+// apply. It returns false when no version was recorded, e.g. after an import.
+func appliedVersion(ctx context.Context, private privateState) (int64, bool, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, appliedVersionKey)
+	if diags.HasError() || len(data) == 0 {
+		return 0, false, diags
+	}
+
+	version, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, false, diags
+	}
+	return version, true, diags
+}
+
+func setAppliedVersion(ctx context.Context, private privateState, version int64) diag.Diagnostics {
+	return private.SetKey(ctx, appliedVersionKey, []byte(strconv.FormatInt(version, 10)))
+}
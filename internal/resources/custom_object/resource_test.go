@@ -0,0 +1,352 @@
+package custom_object_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/labd/commercetools-go-sdk/platform"
+
+	"github.com/labd/terraform-provider-commercetools/internal/acctest"
+)
+
+func TestAccCustomObjectCreate_basic(t *testing.T) {
+	resourceName := "commercetools_custom_object.test_number"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCustomObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomObjectNumber("foobar", "value", `jsonencode({ number = 10 })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "container", "foobar"),
+					resource.TestCheckResourceAttr(resourceName, "key", "value"),
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"number\":10}"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			{
+				// Reformatting the JSON doesn't update the custom object
+				Config: testAccCustomObjectNumber("foobar", "value", `"{ \"number\": 10.0 }"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value", "{ \"number\": 10.0 }"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			{
+				Config: testAccCustomObjectNumber("foobar", "value", `jsonencode({ number = 20 })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "container", "foobar"),
+					resource.TestCheckResourceAttr(resourceName, "key", "value"),
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"number\":20}"),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+				),
+			},
+			{
+				Config: testAccCustomObjectNumber("foobar", "newvalue", `jsonencode({ number = 20 })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "container", "foobar"),
+					resource.TestCheckResourceAttr(resourceName, "key", "newvalue"),
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"number\":20}"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			{
+				Config: testAccCustomObjectNumber("newbar", "newvalue", `jsonencode({ number = 20 })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "container", "newbar"),
+					resource.TestCheckResourceAttr(resourceName, "key", "newvalue"),
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"number\":20}"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			{
+				Config: testAccCustomScalarNumber(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("commercetools_custom_object.scalar_value", "container", "foobar"),
+					resource.TestCheckResourceAttr("commercetools_custom_object.scalar_value", "key", "somekey1"),
+					resource.TestCheckResourceAttr("commercetools_custom_object.scalar_value", "value", "20"),
+					resource.TestCheckResourceAttr("commercetools_custom_object.scalar_value", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCustomObjectCreate_object(t *testing.T) {
+	resourceName := "commercetools_custom_object.test_nested"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCustomObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomObjectNestedData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "container", "foobar"),
+					resource.TestCheckResourceAttr(resourceName, "key", "nested"),
+					resource.TestCheckResourceAttr(
+						resourceName, "value", "{\"address\":{\"number\":10,\"street\":\"foo\"},\"user\":{\"last_name\":\"Smith\",\"name\":\"John\"}}",
+					),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCustomObjectCreate_dynamic(t *testing.T) {
+	resourceName := "commercetools_custom_object.test_dynamic"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCustomObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomObjectDynamic("foo"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "container", "foobar"),
+					resource.TestCheckResourceAttr(resourceName, "key", "dynamic"),
+					resource.TestCheckResourceAttr(resourceName, "value_dynamic.address.street", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "value_dynamic.tags.#", "2"),
+					resource.TestCheckNoResourceAttr(resourceName, "value"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			{
+				Config: testAccCustomObjectDynamic("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value_dynamic.address.street", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+				),
+			},
+			{
+				// Switching to the JSON encoded value doesn't update the
+				// custom object
+				Config: testAccCustomObjectNumber("foobar", "dynamic",
+					`jsonencode({ address = { street = "bar", number = 10 }, tags = ["a", "b"] })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "value_dynamic"),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCustomObjectCreate_versionGuard(t *testing.T) {
+	resourceName := "commercetools_custom_object.test_guard"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCustomObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomObjectGuard(`jsonencode({ number = 10 })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version_guard", "true"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+				),
+			},
+			{
+				// Another application modifies the custom object, so the
+				// update is rejected
+				PreConfig: func() {
+					testAccModifyCustomObject(t, "foobar", "guard", map[string]any{"number": 30})
+				},
+				Config:      testAccCustomObjectGuard(`jsonencode({ number = 20 })`),
+				ExpectError: regexp.MustCompile("modified outside of Terraform"),
+			},
+			{
+				// Adding the remote changes to the configuration accepts them
+				Config: testAccCustomObjectGuard(`jsonencode({ number = 30 })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"number\":30}"),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+				),
+			},
+			{
+				Config: testAccCustomObjectGuard(`jsonencode({ number = 20 })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"number\":20}"),
+					resource.TestCheckResourceAttr(resourceName, "version", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCustomObjectCreate_mergePatch(t *testing.T) {
+	resourceName := "commercetools_custom_object.test_merge"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCustomObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomObjectMerge(`jsonencode({ terraform = { enabled = true } })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "merge_strategy", "merge_patch"),
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"terraform\":{\"enabled\":true}}"),
+				),
+			},
+			{
+				// Keys written by other applications are kept
+				PreConfig: func() {
+					testAccModifyCustomObject(t, "foobar", "merge", map[string]any{
+						"terraform": map[string]any{"enabled": true},
+						"app":       "value",
+					})
+				},
+				Config: testAccCustomObjectMerge(`jsonencode({ terraform = { enabled = false } })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value", "{\"terraform\":{\"enabled\":false}}"),
+					testAccCheckCustomObjectValue("foobar", "merge", map[string]any{
+						"terraform": map[string]any{"enabled": false},
+						"app":       "value",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCustomObjectNumber(container, key, value string) string {
+	return fmt.Sprintf(`
+		resource "commercetools_custom_object" "test_number" {
+			container = %q
+			key = %q
+			value = %s
+		}`, container, key, value)
+}
+
+func testAccCustomObjectNestedData() string {
+	return `
+		resource "commercetools_custom_object" "test_nested" {
+			container = "foobar"
+			key = "nested"
+			value = jsonencode({
+				address = {
+					street = "foo"
+					number = 10
+				}
+				user = {
+					name = "John"
+					last_name = "Smith"
+				}
+			})
+		}`
+}
+
+func testAccCustomScalarNumber() string {
+	return `
+		resource "commercetools_custom_object" "scalar_value" {
+			container = "foobar"
+			key = "somekey1"
+			value = 20
+		}`
+}
+
+func testAccCustomObjectDynamic(street string) string {
+	return fmt.Sprintf(`
+		resource "commercetools_custom_object" "test_dynamic" {
+			container = "foobar"
+			key = "dynamic"
+			value_dynamic = {
+				address = {
+					street = %q
+					number = 10
+				}
+				tags = ["a", "b"]
+			}
+		}`, street)
+}
+
+func testAccCustomObjectGuard(value string) string {
+	return fmt.Sprintf(`
+		resource "commercetools_custom_object" "test_guard" {
+			container = "foobar"
+			key = "guard"
+			value = %s
+			version_guard = true
+		}`, value)
+}
+
+func testAccCustomObjectMerge(value string) string {
+	return fmt.Sprintf(`
+		resource "commercetools_custom_object" "test_merge" {
+			container = "foobar"
+			key = "merge"
+			value = %s
+			merge_strategy = "merge_patch"
+		}`, value)
+}
+
+// testAccModifyCustomObject updates the custom object as another application
+// would
+func testAccModifyCustomObject(t *testing.T, container, key string, value any) {
+	client, err := acctest.GetClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.CustomObjects().Post(platform.CustomObjectDraft{
+		Container: container,
+		Key:       key,
+		Value:     value,
+	}).Execute(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testAccCheckCustomObjectValue(container, key string, expected any) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := acctest.GetClient()
+		if err != nil {
+			return err
+		}
+		result, err := client.CustomObjects().WithContainerAndKey(container, key).Get().Execute(context.Background())
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(result.Value, expected) {
+			return fmt.Errorf("expected value %v, got %v", expected, result.Value)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCustomObjectDestroy(s *terraform.State) error {
+	client, err := acctest.GetClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "commercetools_custom_object" {
+			continue
+		}
+		container := rs.Primary.Attributes["container"]
+		response, err := client.CustomObjects().WithContainer(container).Get().Execute(context.Background())
+		if err == nil {
+			if response != nil && response.Count > 0 {
+				return fmt.Errorf("custom object container (%s) still exists", container)
+			}
+			return nil
+		}
+		if newErr := acctest.CheckApiResult(err); newErr != nil {
+			return newErr
+		}
+	}
+	return nil
+}
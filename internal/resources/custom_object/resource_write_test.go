@@ -0,0 +1,320 @@
+package custom_object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+// fakeCustomObjects is a minimal implementation of the custom objects API
+type fakeCustomObjects struct {
+	mutex   sync.Mutex
+	objects map[string]*platform.CustomObject
+	// beforeWrite is called before a write is processed, to simulate
+	// concurrent modifications
+	beforeWrite func(f *fakeCustomObjects)
+}
+
+func newTestResource(t *testing.T, objects ...platform.CustomObject) (*customObjectResource, *fakeCustomObjects) {
+	fake := &fakeCustomObjects{objects: map[string]*platform.CustomObject{}}
+	for i := range objects {
+		fake.put(objects[i])
+	}
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := platform.NewClient(&platform.ClientConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	return &customObjectResource{
+		client: client.WithProjectKey("test"),
+		mutex:  utils.NewMutexKV(),
+	}, fake
+}
+
+func (f *fakeCustomObjects) put(o platform.CustomObject) {
+	f.objects[o.Container+"/"+o.Key] = &o
+}
+
+// modify updates the value of the custom object as another application would
+func (f *fakeCustomObjects) modify(container, key string, value any) {
+	o := f.objects[container+"/"+key]
+	o.Value = value
+	o.Version++
+}
+
+func (f *fakeCustomObjects) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/test/custom-objects")
+	existing := f.objects[strings.TrimPrefix(path, "/")]
+
+	switch {
+	case r.Method == http.MethodGet && existing != nil:
+		writeJSON(w, http.StatusOK, existing)
+
+	case r.Method == http.MethodPost && path == "":
+		if f.beforeWrite != nil {
+			f.beforeWrite(f)
+			f.beforeWrite = nil
+		}
+
+		var draft platform.CustomObjectDraft
+		if err := json.NewDecoder(r.Body).Decode(&draft); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"statusCode": 400, "message": err.Error()})
+			return
+		}
+		existing = f.objects[draft.Container+"/"+draft.Key]
+		if draft.Version != nil && (existing == nil || existing.Version != *draft.Version) {
+			writeConflict(w)
+			return
+		}
+		if existing == nil {
+			f.put(platform.CustomObject{
+				ID:        fmt.Sprintf("id-%s-%s", draft.Container, draft.Key),
+				Container: draft.Container,
+				Key:       draft.Key,
+			})
+		}
+		f.modify(draft.Container, draft.Key, draft.Value)
+		writeJSON(w, http.StatusOK, f.objects[draft.Container+"/"+draft.Key])
+
+	case r.Method == http.MethodDelete && existing != nil:
+		if r.URL.Query().Get("version") != strconv.Itoa(existing.Version) {
+			writeConflict(w)
+			return
+		}
+		delete(f.objects, strings.TrimPrefix(path, "/"))
+		writeJSON(w, http.StatusOK, existing)
+
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]any{"statusCode": 404, "message": "not found"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeConflict(w http.ResponseWriter) {
+	writeJSON(w, http.StatusConflict, map[string]any{
+		"statusCode": 409,
+		"message":    "Object has a different version than expected.",
+		"errors": []map[string]any{
+			{"code": "ConcurrentModification", "message": "Object has a different version than expected."},
+		},
+	})
+}
+
+func testCustomObject(key, value, strategy string) CustomObject {
+	return CustomObject{
+		Container:     types.StringValue("container"),
+		Key:           types.StringValue(key),
+		Value:         NewJSONStringValue(value),
+		ValueDynamic:  types.DynamicNull(),
+		VersionGuard:  types.BoolNull(),
+		MergeStrategy: types.StringValue(strategy),
+	}
+}
+
+func TestWriteReplace(t *testing.T) {
+	r, fake := newTestResource(t, platform.CustomObject{
+		Container: "container",
+		Key:       "key",
+		Value:     map[string]any{"app": "value"},
+		Version:   2,
+	})
+	ctx := context.Background()
+	plan := testCustomObject("key", `{"a": 1}`, MergeStrategyReplace)
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := r.write(ctx, plan, utils.IntRef(1))
+		assert.True(t, utils.IsConcurrentModificationError(err))
+
+		err = r.versionGuardError(ctx, plan, 1, err)
+		assert.ErrorContains(t, err, "custom object with container container and key key was modified outside of "+
+			"Terraform: the current version is 2, but the last version applied by Terraform is 1")
+	})
+
+	t.Run("expected version", func(t *testing.T) {
+		res, err := r.write(ctx, plan, utils.IntRef(2))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res.Version)
+		assert.Equal(t, map[string]any{"a": float64(1)}, fake.objects["container/key"].Value)
+	})
+}
+
+func TestWriteMergePatch(t *testing.T) {
+	r, fake := newTestResource(t, platform.CustomObject{
+		Container: "container",
+		Key:       "key",
+		Value:     map[string]any{"app": "value", "managed": map[string]any{"a": 1, "b": 2}},
+		Version:   1,
+	})
+	ctx := context.Background()
+	plan := testCustomObject("key", `{"managed": {"b": null, "c": 3}, "removed": null}`, MergeStrategyMergePatch)
+
+	// Another application writes the value while it is merged, so the merge
+	// is retried with the new value
+	fake.beforeWrite = func(f *fakeCustomObjects) {
+		f.modify("container", "key", map[string]any{"app": "new value", "managed": map[string]any{"a": 1, "b": 2}})
+	}
+
+	res, err := r.write(ctx, plan, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res.Version)
+	assert.Equal(t, map[string]any{
+		"app":     "new value",
+		"managed": map[string]any{"a": float64(1), "c": float64(3)},
+	}, fake.objects["container/key"].Value)
+
+	// Only the managed part of the value is kept in the state
+	current, err := NewCustomObjectFromNative(res)
+	assert.NoError(t, err)
+	assert.NoError(t, current.matchPrior(plan))
+	assert.Equal(t, plan.Value, current.Value)
+
+	t.Run("version guard", func(t *testing.T) {
+		_, err := r.write(ctx, plan, utils.IntRef(1))
+		assert.ErrorContains(t, err, "the current version is 3, but the last version applied by Terraform is 1")
+	})
+
+	t.Run("create", func(t *testing.T) {
+		res, err := r.write(ctx, testCustomObject("new", `{"a": 1, "b": null}`, MergeStrategyMergePatch), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.Version)
+		assert.Equal(t, map[string]any{"a": float64(1)}, fake.objects["container/new"].Value)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replace", func(t *testing.T) {
+		r, fake := newTestResource(t, platform.CustomObject{
+			Container: "container",
+			Key:       "key",
+			Value:     map[string]any{"a": 1},
+			Version:   3,
+		})
+		state := testCustomObject("key", `{"a": 1}`, MergeStrategyReplace)
+
+		err := r.remove(ctx, state, utils.IntRef(2), false)
+		assert.ErrorContains(t, err, "the current version is 3, but the last version applied by Terraform is 2")
+		assert.Contains(t, fake.objects, "container/key")
+
+		assert.NoError(t, r.remove(ctx, state, utils.IntRef(3), false))
+		assert.NotContains(t, fake.objects, "container/key")
+
+		// Removing a custom object which doesn't exist succeeds
+		assert.NoError(t, r.remove(ctx, state, nil, false))
+	})
+
+	t.Run("merge patch", func(t *testing.T) {
+		r, fake := newTestResource(t, platform.CustomObject{
+			Container: "container",
+			Key:       "key",
+			Value:     map[string]any{"app": "value", "managed": 1},
+			Version:   1,
+		})
+		state := testCustomObject("key", `{"managed": 1}`, MergeStrategyMergePatch)
+
+		assert.NoError(t, r.remove(ctx, state, nil, false))
+		assert.Equal(t, map[string]any{"app": "value"}, fake.objects["container/key"].Value)
+
+		// The custom object is deleted when no other keys remain
+		state = testCustomObject("key", `{"app": "value"}`, MergeStrategyMergePatch)
+		assert.NoError(t, r.remove(ctx, state, nil, false))
+		assert.NotContains(t, fake.objects, "container/key")
+	})
+}
+
+func TestRename(t *testing.T) {
+	r, fake := newTestResource(t, platform.CustomObject{
+		Container: "container",
+		Key:       "old",
+		Value:     map[string]any{"a": 1},
+		Version:   1,
+	})
+	ctx := context.Background()
+	state := testCustomObject("old", `{"a": 1}`, MergeStrategyReplace)
+	plan := testCustomObject("new", `{"a": 1}`, MergeStrategyReplace)
+	assert.True(t, state.isMoved(plan))
+
+	// The old custom object was modified, so it isn't deleted when the
+	// version guard is enabled
+	fake.modify("container", "old", map[string]any{"a": 2})
+
+	res, err := r.write(ctx, plan, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", res.Key)
+
+	err = r.remove(ctx, state, utils.IntRef(1), true)
+	assert.ErrorContains(t, err, "custom object with container container and key old was modified outside of Terraform")
+	assert.Contains(t, fake.objects, "container/old")
+	assert.Contains(t, fake.objects, "container/new")
+
+	assert.NoError(t, r.remove(ctx, state, nil, true))
+	assert.NotContains(t, fake.objects, "container/old")
+}
+
+func TestLockContainers(t *testing.T) {
+	r := &customObjectResource{mutex: utils.NewMutexKV()}
+
+	// Locking the same container twice in one call doesn't deadlock
+	unlock := r.lockContainers("b", "a", "b")
+	unlock()
+
+	unlock = r.lockContainers("a")
+	locked := make(chan struct{})
+	go func() {
+		defer close(locked)
+		r.lockContainers("b", "a")()
+	}()
+	unlock()
+	<-locked
+}
+
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestAppliedVersion(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	_, ok, diags := appliedVersion(ctx, private)
+	assert.False(t, diags.HasError())
+	assert.False(t, ok)
+
+	assert.False(t, setAppliedVersion(ctx, private, 12).HasError())
+	version, ok, diags := appliedVersion(ctx, private)
+	assert.False(t, diags.HasError())
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), version)
+}
@@ -5,7 +5,6 @@ import (
 	"regexp"
 	"time"
 
-	"github.com/labd/terraform-provider-commercetools/commercetools"
 	"github.com/labd/terraform-provider-commercetools/internal/sharedtypes"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -27,10 +26,14 @@ var (
 	_ resource.Resource                = &productSelectionResource{}
 	_ resource.ResourceWithConfigure   = &productSelectionResource{}
 	_ resource.ResourceWithImportState = &productSelectionResource{}
+	_ resource.ResourceWithModifyPlan  = &productSelectionResource{}
 )
 
 type productSelectionResource struct {
-	client *platform.ByProjectKeyRequestBuilder
+	client    *platform.ByProjectKeyRequestBuilder
+	typeCache *utils.TypeCache
+	languages *utils.LanguageValidator
+	project   *utils.ProjectValidator
 }
 
 // NewResource is a helper function to simplify the provider implementation.
@@ -110,7 +113,7 @@ func (r *productSelectionResource) Create(ctx context.Context, req resource.Crea
 	var customType *platform.Type
 	var err error
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(r.client), *plan.Custom.TypeID)
+		customType, err = r.typeCache.Get(ctx, r.client, *plan.Custom.TypeID)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error getting custom type",
@@ -152,6 +155,8 @@ func (r *productSelectionResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = resp.State.Set(ctx, current)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -226,6 +231,8 @@ func (r *productSelectionResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	current.Custom.UsePriorRepresentation(state.Custom)
+
 	// Set current data as state.
 	diags = resp.State.Set(ctx, &current)
 	resp.Diagnostics.Append(diags...)
@@ -253,7 +260,7 @@ func (r *productSelectionResource) Update(ctx context.Context, req resource.Upda
 	var customType *platform.Type
 	var err error
 	if plan.Custom.IsSet() {
-		customType, err = commercetools.GetTypeResource(ctx, commercetools.CreateTypeFetcher(r.client), *plan.Custom.TypeID)
+		customType, err = r.typeCache.Get(ctx, r.client, *plan.Custom.TypeID)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error getting custom type",
@@ -299,6 +306,8 @@ func (r *productSelectionResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	current.Custom.UsePriorRepresentation(plan.Custom)
+
 	diags = resp.State.Set(ctx, current)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -306,6 +315,13 @@ func (r *productSelectionResource) Update(ctx context.Context, req resource.Upda
 	}
 }
 
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *productSelectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(sharedtypes.ValidateCustomPlan(ctx, r.client, r.typeCache, req.State, req.Plan)...)
+	resp.Diagnostics.Append(sharedtypes.ValidateCustomCurrenciesPlan(ctx, r.project, req.Plan)...)
+	resp.Diagnostics.Append(sharedtypes.ValidateLocalizedStringsPlan(ctx, r.languages, req.Plan)...)
+}
+
 // Configure implements resource.ResourceWithConfigure.
 func (r *productSelectionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -314,6 +330,9 @@ func (r *productSelectionResource) Configure(_ context.Context, req resource.Con
 
 	data := req.ProviderData.(*utils.ProviderData)
 	r.client = data.Client
+	r.typeCache = data.TypeCache
+	r.languages = data.Languages
+	r.project = data.ProjectValidator
 }
 
 // ImportState implements resource.ResourceWithImportState.
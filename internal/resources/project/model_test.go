@@ -1,6 +1,8 @@
 package project
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -590,3 +592,19 @@ func IsDefaultShoppingListsConfiguration_NonDefaultDeleteDays(t *testing.T) {
 	}
 	assert.False(t, IsDefaultShoppingListsConfiguration(c))
 }
+
+func TestProjectResourceSetSettings(t *testing.T) {
+	settings := &utils.ProjectSettings{}
+	r := &projectResource{settings: settings}
+	r.setSettings(&platform.Project{Key: "test", Currencies: []string{"EUR", "USD"}})
+
+	// The stored project is used without fetching the project
+	project, err := settings.GetWithFetcher(context.Background(), func(context.Context) (*platform.Project, error) {
+		return nil, errors.New("unexpected fetch")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"EUR", "USD"}, project.Currencies)
+
+	// Resources configured without provider data don't cache the settings
+	(&projectResource{}).setSettings(&platform.Project{})
+}
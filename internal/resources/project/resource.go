@@ -41,7 +41,8 @@ func NewResource() resource.Resource {
 
 // projectResource is the resource implementation.
 type projectResource struct {
-	client *platform.ByProjectKeyRequestBuilder
+	client   *platform.ByProjectKeyRequestBuilder
+	settings *utils.ProjectSettings
 }
 
 // Metadata returns the data source type name.
@@ -345,6 +346,7 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 	}
 	data := req.ProviderData.(*utils.ProviderData)
 	r.client = data.Client
+	r.settings = data.ProjectSettings
 }
 
 func (r *projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
@@ -392,6 +394,10 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	// Validate the values of other resources against the new settings,
+	// instead of the settings fetched before the project was changed
+	r.setSettings(res)
+
 	result := NewProjectFromNative(res)
 	result.setStateData(plan, false)
 
@@ -467,6 +473,11 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		resp.Diagnostics.AddError("Error updating project", err.Error())
 		return
 	}
+
+	// Validate the values of other resources against the new settings,
+	// instead of the settings fetched before the project was changed
+	r.setSettings(res)
+
 	result := NewProjectFromNative(res)
 	result.setStateData(plan, false)
 
@@ -477,6 +488,13 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 }
 
+// setSettings updates the cached project settings, see utils.ProjectSettings
+func (r *projectResource) setSettings(project *platform.Project) {
+	if r.settings != nil {
+		r.settings.Set(project)
+	}
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
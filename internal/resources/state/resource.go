@@ -18,6 +18,7 @@ import (
 	"github.com/labd/commercetools-go-sdk/platform"
 
 	"github.com/labd/terraform-provider-commercetools/internal/customtypes"
+	"github.com/labd/terraform-provider-commercetools/internal/sharedtypes"
 	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
@@ -26,6 +27,7 @@ var (
 	_ resource.Resource                = &stateResource{}
 	_ resource.ResourceWithConfigure   = &stateResource{}
 	_ resource.ResourceWithImportState = &stateResource{}
+	_ resource.ResourceWithModifyPlan  = &stateResource{}
 )
 
 // NewStateResource is a helper function to simplify the provider implementation.
@@ -35,8 +37,9 @@ func NewResource() resource.Resource {
 
 // stateResource is the resource implementation.
 type stateResource struct {
-	client *platform.ByProjectKeyRequestBuilder
-	mutex  *utils.MutexKV
+	client    *platform.ByProjectKeyRequestBuilder
+	mutex     *utils.MutexKV
+	languages *utils.LanguageValidator
 }
 
 // Metadata returns the data source type name.
@@ -127,6 +130,12 @@ func (r *stateResource) Configure(_ context.Context, req resource.ConfigureReque
 	data := req.ProviderData.(*utils.ProviderData)
 	r.client = data.Client
 	r.mutex = data.Mutex
+	r.languages = data.Languages
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan.
+func (r *stateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(sharedtypes.ValidateLocalizedStringsPlan(ctx, r.languages, req.Plan)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -0,0 +1,548 @@
+package subscription
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// changeResourceTypeIds are the resource types which can be subscribed to for
+// change notifications, see
+// https://docs.commercetools.com/api/projects/subscriptions#changesubscriptionresourcetypeid
+var changeResourceTypeIds = []string{
+	"approval-flow",
+	"approval-rule",
+	"associate-role",
+	"attribute-group",
+	"business-unit",
+	"cart",
+	"cart-discount",
+	"category",
+	"channel",
+	"customer",
+	"customer-email-token",
+	"customer-group",
+	"customer-password-token",
+	"discount-code",
+	"discount-group",
+	"extension",
+	"inventory-entry",
+	"key-value-document",
+	"order",
+	"order-edit",
+	"payment",
+	"product",
+	"product-discount",
+	"product-selection",
+	"product-tailoring",
+	"product-type",
+	"quote",
+	"quote-request",
+	"recurrence-policy",
+	"recurring-order",
+	"review",
+	"shipping-method",
+	"shopping-list",
+	"staged-quote",
+	"standalone-price",
+	"state",
+	"store",
+	"subscription",
+	"tax-category",
+	"type",
+	"zone",
+}
+
+// messageTypes are the message types of every resource type which can be
+// subscribed to for messages, see
+// https://docs.commercetools.com/api/projects/messages
+var messageTypes = map[string][]string{
+	"approval-flow": {
+		"ApprovalFlowApproved",
+		"ApprovalFlowCompleted",
+		"ApprovalFlowCreated",
+		"ApprovalFlowRejected",
+	},
+	"approval-rule": {
+		"ApprovalRuleApproversSet",
+		"ApprovalRuleCreated",
+		"ApprovalRuleDescriptionSet",
+		"ApprovalRuleKeySet",
+		"ApprovalRuleNameSet",
+		"ApprovalRulePredicateSet",
+		"ApprovalRuleRequestersSet",
+		"ApprovalRuleStatusSet",
+	},
+	"associate-role": {
+		"AssociateRoleBuyerAssignableChanged",
+		"AssociateRoleCreated",
+		"AssociateRoleDeleted",
+		"AssociateRoleNameSet",
+		"AssociateRolePermissionAdded",
+		"AssociateRolePermissionRemoved",
+		"AssociateRolePermissionsSet",
+	},
+	"business-unit": {
+		"BusinessUnitAddressAdded",
+		"BusinessUnitAddressChanged",
+		"BusinessUnitAddressCustomFieldAdded",
+		"BusinessUnitAddressCustomFieldChanged",
+		"BusinessUnitAddressCustomFieldRemoved",
+		"BusinessUnitAddressCustomTypeRemoved",
+		"BusinessUnitAddressCustomTypeSet",
+		"BusinessUnitAddressRemoved",
+		"BusinessUnitApprovalRuleModeChanged",
+		"BusinessUnitAssociateAdded",
+		"BusinessUnitAssociateChanged",
+		"BusinessUnitAssociateModeChanged",
+		"BusinessUnitAssociateRemoved",
+		"BusinessUnitAssociatesSet",
+		"BusinessUnitBillingAddressAdded",
+		"BusinessUnitBillingAddressRemoved",
+		"BusinessUnitContactEmailSet",
+		"BusinessUnitCreated",
+		"BusinessUnitCustomFieldAdded",
+		"BusinessUnitCustomFieldChanged",
+		"BusinessUnitCustomFieldRemoved",
+		"BusinessUnitCustomTypeRemoved",
+		"BusinessUnitCustomTypeSet",
+		"BusinessUnitCustomerGroupAssignmentAdded",
+		"BusinessUnitCustomerGroupAssignmentRemoved",
+		"BusinessUnitCustomerGroupAssignmentsSet",
+		"BusinessUnitDefaultBillingAddressSet",
+		"BusinessUnitDefaultShippingAddressSet",
+		"BusinessUnitDeleted",
+		"BusinessUnitNameChanged",
+		"BusinessUnitParentChanged",
+		"BusinessUnitShippingAddressAdded",
+		"BusinessUnitShippingAddressRemoved",
+		"BusinessUnitStatusChanged",
+		"BusinessUnitStoreAdded",
+		"BusinessUnitStoreModeChanged",
+		"BusinessUnitStoreRemoved",
+		"BusinessUnitStoresSet",
+		"BusinessUnitTopLevelUnitSet",
+		"BusinessUnitTypeSet",
+	},
+	"cart": {
+		"CartFrozen",
+		"CartPurchaseOrderNumberSet",
+		"CartUnfrozen",
+	},
+	"cart-discount": {
+		"CartDiscountCreated",
+		"CartDiscountDeleted",
+		"CartDiscountStoreAdded",
+		"CartDiscountStoreRemoved",
+		"CartDiscountStoresSet",
+	},
+	"category": {
+		"CategoryCreated",
+		"CategorySlugChanged",
+	},
+	"customer": {
+		"CustomerAddressAdded",
+		"CustomerAddressChanged",
+		"CustomerAddressCustomFieldAdded",
+		"CustomerAddressCustomFieldChanged",
+		"CustomerAddressCustomFieldRemoved",
+		"CustomerAddressCustomTypeRemoved",
+		"CustomerAddressCustomTypeSet",
+		"CustomerAddressRemoved",
+		"CustomerBillingAddressAdded",
+		"CustomerBillingAddressRemoved",
+		"CustomerCompanyNameSet",
+		"CustomerCreated",
+		"CustomerCustomFieldAdded",
+		"CustomerCustomFieldChanged",
+		"CustomerCustomFieldRemoved",
+		"CustomerCustomTypeRemoved",
+		"CustomerCustomTypeSet",
+		"CustomerDateOfBirthSet",
+		"CustomerDefaultBillingAddressSet",
+		"CustomerDefaultShippingAddressSet",
+		"CustomerDeleted",
+		"CustomerEmailChanged",
+		"CustomerEmailVerified",
+		"CustomerExternalIdSet",
+		"CustomerFirstNameSet",
+		"CustomerGroupAssignmentAdded",
+		"CustomerGroupAssignmentRemoved",
+		"CustomerGroupAssignmentsSet",
+		"CustomerGroupSet",
+		"CustomerLastNameSet",
+		"CustomerPasswordUpdated",
+		"CustomerShippingAddressAdded",
+		"CustomerShippingAddressRemoved",
+		"CustomerStoresSet",
+		"CustomerTitleSet",
+	},
+	"customer-email-token": {
+		"CustomerEmailTokenCreated",
+	},
+	"customer-group": {
+		"CustomerGroupCustomFieldAdded",
+		"CustomerGroupCustomFieldChanged",
+		"CustomerGroupCustomFieldRemoved",
+		"CustomerGroupCustomTypeRemoved",
+		"CustomerGroupCustomTypeSet",
+	},
+	"customer-password-token": {
+		"CustomerPasswordTokenCreated",
+	},
+	"discount-code": {
+		"DiscountCodeCreated",
+		"DiscountCodeDeleted",
+		"DiscountCodeKeySet",
+	},
+	"discount-group": {
+		"DiscountGroupCreated",
+		"DiscountGroupDeleted",
+		"DiscountGroupIsActiveSet",
+		"DiscountGroupKeySet",
+		"DiscountGroupSortOrderSet",
+	},
+	"inventory-entry": {
+		"InventoryEntryCreated",
+		"InventoryEntryDeleted",
+		"InventoryEntryQuantitySet",
+	},
+	"order": {
+		"CustomLineItemStateTransition",
+		"DeliveryAdded",
+		"DeliveryAddressSet",
+		"DeliveryCustomFieldAdded",
+		"DeliveryCustomFieldChanged",
+		"DeliveryCustomFieldRemoved",
+		"DeliveryCustomTypeRemoved",
+		"DeliveryCustomTypeSet",
+		"DeliveryItemsUpdated",
+		"DeliveryRemoved",
+		"LineItemStateTransition",
+		"OrderBillingAddressSet",
+		"OrderBusinessUnitSet",
+		"OrderCreated",
+		"OrderCreatedFromRecurringOrder",
+		"OrderCustomFieldAdded",
+		"OrderCustomFieldChanged",
+		"OrderCustomFieldRemoved",
+		"OrderCustomLineItemAdded",
+		"OrderCustomLineItemDiscountSet",
+		"OrderCustomLineItemQuantityChanged",
+		"OrderCustomLineItemRemoved",
+		"OrderCustomTypeRemoved",
+		"OrderCustomTypeSet",
+		"OrderCustomerEmailSet",
+		"OrderCustomerGroupSet",
+		"OrderCustomerSet",
+		"OrderDeleted",
+		"OrderDiscountCodeAdded",
+		"OrderDiscountCodeRemoved",
+		"OrderDiscountCodeStateSet",
+		"OrderEditApplied",
+		"OrderImported",
+		"OrderLineItemAdded",
+		"OrderLineItemDiscountSet",
+		"OrderLineItemDistributionChannelSet",
+		"OrderLineItemRemoved",
+		"OrderPaymentAdded",
+		"OrderPaymentRemoved",
+		"OrderPaymentStateChanged",
+		"OrderPurchaseOrderNumberSet",
+		"OrderReturnShipmentStateChanged",
+		"OrderShipmentStateChanged",
+		"OrderShippingAddressSet",
+		"OrderShippingInfoSet",
+		"OrderShippingRateInputSet",
+		"OrderStateChanged",
+		"OrderStateTransition",
+		"OrderStoreSet",
+		"ParcelAddedToDelivery",
+		"ParcelItemsUpdated",
+		"ParcelMeasurementsUpdated",
+		"ParcelRemovedFromDelivery",
+		"ParcelTrackingDataUpdated",
+		"ReturnInfoAdded",
+		"ReturnInfoSet",
+	},
+	"payment": {
+		"PaymentCreated",
+		"PaymentInteractionAdded",
+		"PaymentInterfaceIdSet",
+		"PaymentMethodInfoCustomFieldAdded",
+		"PaymentMethodInfoCustomFieldChanged",
+		"PaymentMethodInfoCustomFieldRemoved",
+		"PaymentMethodInfoCustomTypeRemoved",
+		"PaymentMethodInfoCustomTypeSet",
+		"PaymentMethodInfoInterfaceAccountSet",
+		"PaymentMethodInfoInterfaceSet",
+		"PaymentMethodInfoMethodSet",
+		"PaymentMethodInfoNameSet",
+		"PaymentMethodInfoTokenSet",
+		"PaymentStatusInterfaceCodeSet",
+		"PaymentStatusStateTransition",
+		"PaymentTransactionAdded",
+		"PaymentTransactionInterfaceIdSet",
+		"PaymentTransactionStateChanged",
+	},
+	"payment-method": {
+		"PaymentMethodCreated",
+		"PaymentMethodCustomFieldAdded",
+		"PaymentMethodCustomFieldChanged",
+		"PaymentMethodCustomFieldRemoved",
+		"PaymentMethodCustomTypeRemoved",
+		"PaymentMethodCustomTypeSet",
+		"PaymentMethodDefaultSet",
+		"PaymentMethodDeleted",
+		"PaymentMethodInterfaceAccountSet",
+		"PaymentMethodKeySet",
+		"PaymentMethodMethodSet",
+		"PaymentMethodNameSet",
+		"PaymentMethodPaymentInterfaceSet",
+		"PaymentMethodPaymentMethodStatusSet",
+	},
+	"product": {
+		"ProductAddedToCategory",
+		"ProductCreated",
+		"ProductDeleted",
+		"ProductImageAdded",
+		"ProductPriceAdded",
+		"ProductPriceChanged",
+		"ProductPriceCustomFieldAdded",
+		"ProductPriceCustomFieldChanged",
+		"ProductPriceCustomFieldRemoved",
+		"ProductPriceCustomFieldsRemoved",
+		"ProductPriceCustomFieldsSet",
+		"ProductPriceDiscountsSet",
+		"ProductPriceExternalDiscountSet",
+		"ProductPriceKeySet",
+		"ProductPriceModeSet",
+		"ProductPriceRemoved",
+		"ProductPricesSet",
+		"ProductPublished",
+		"ProductRemovedFromCategory",
+		"ProductRevertedStagedChanges",
+		"ProductSlugChanged",
+		"ProductStateTransition",
+		"ProductUnpublished",
+		"ProductVariantAdded",
+		"ProductVariantDeleted",
+	},
+	"product-selection": {
+		"ProductSelectionCreated",
+		"ProductSelectionDeleted",
+		"ProductSelectionProductAdded",
+		"ProductSelectionProductExcluded",
+		"ProductSelectionProductRemoved",
+		"ProductSelectionVariantExclusionChanged",
+		"ProductSelectionVariantSelectionChanged",
+	},
+	"product-tailoring": {
+		"ProductTailoringCreated",
+		"ProductTailoringDeleted",
+		"ProductTailoringDescriptionSet",
+		"ProductTailoringImageAdded",
+		"ProductTailoringImagesSet",
+		"ProductTailoringNameSet",
+		"ProductTailoringPublished",
+		"ProductTailoringSlugSet",
+		"ProductTailoringUnpublished",
+		"ProductVariantTailoringAdded",
+		"ProductVariantTailoringRemoved",
+	},
+	"quote": {
+		"QuoteCreated",
+		"QuoteCustomerChanged",
+		"QuoteDeleted",
+		"QuoteRenegotiationRequested",
+		"QuoteStateChanged",
+		"QuoteStateTransition",
+	},
+	"quote-request": {
+		"QuoteRequestCreated",
+		"QuoteRequestCustomerChanged",
+		"QuoteRequestDeleted",
+		"QuoteRequestStateChanged",
+		"QuoteRequestStateTransition",
+	},
+	"recurring-order": {
+		"RecurringOrderCreated",
+		"RecurringOrderCustomFieldAdded",
+		"RecurringOrderCustomFieldChanged",
+		"RecurringOrderCustomFieldRemoved",
+		"RecurringOrderCustomTypeRemoved",
+		"RecurringOrderCustomTypeSet",
+		"RecurringOrderDeleted",
+		"RecurringOrderExpiresAtSet",
+		"RecurringOrderFailed",
+		"RecurringOrderKeySet",
+		"RecurringOrderScheduleSet",
+		"RecurringOrderStartsAtSet",
+		"RecurringOrderStateChanged",
+		"RecurringOrderStateTransition",
+	},
+	"review": {
+		"ReviewCreated",
+		"ReviewRatingSet",
+		"ReviewStateTransition",
+	},
+	"shopping-list": {
+		"ShoppingListLineItemAdded",
+		"ShoppingListLineItemRemoved",
+	},
+	"staged-quote": {
+		"StagedQuoteCreated",
+		"StagedQuoteDeleted",
+		"StagedQuoteSellerCommentSet",
+		"StagedQuoteStateChanged",
+		"StagedQuoteStateTransition",
+		"StagedQuoteValidToSet",
+	},
+	"standalone-price": {
+		"StandalonePriceActiveChanged",
+		"StandalonePriceCreated",
+		"StandalonePriceDeleted",
+		"StandalonePriceDiscountSet",
+		"StandalonePriceExternalDiscountSet",
+		"StandalonePriceKeySet",
+		"StandalonePriceStagedChangesApplied",
+		"StandalonePriceStagedChangesRemoved",
+		"StandalonePriceTierAdded",
+		"StandalonePriceTierRemoved",
+		"StandalonePriceTiersSet",
+		"StandalonePriceValidFromAndUntilSet",
+		"StandalonePriceValidFromSet",
+		"StandalonePriceValidUntilSet",
+		"StandalonePriceValueChanged",
+	},
+	"store": {
+		"StoreCountriesChanged",
+		"StoreCreated",
+		"StoreDeleted",
+		"StoreDistributionChannelsChanged",
+		"StoreLanguagesChanged",
+		"StoreNameSet",
+		"StoreProductSelectionsChanged",
+		"StoreSupplyChannelsChanged",
+	},
+}
+
+// eventTypes are the event types of every resource type which can be
+// subscribed to for events, see
+// https://docs.commercetools.com/api/projects/subscriptions#eventsubscriptionresourcetypeid
+var eventTypes = map[string][]string{
+	"checkout": {
+		"CheckoutOrderCreationFailed",
+		"CheckoutPaymentAuthorizationCancelled",
+		"CheckoutPaymentAuthorizationFailed",
+		"CheckoutPaymentAuthorized",
+		"CheckoutPaymentCancelAuthorizationFailed",
+		"CheckoutPaymentCharged",
+		"CheckoutPaymentChargeFailed",
+		"CheckoutPaymentRefunded",
+		"CheckoutPaymentRefundFailed",
+	},
+	"import-api": {
+		"ImportContainerCreated",
+		"ImportContainerDeleted",
+		"ImportOperationRejected",
+		"ImportUnresolved",
+		"ImportValidationFailed",
+		"ImportWaitForMasterVariant",
+	},
+}
+
+// catalogueResourceTypeIds returns the sorted resource types of the catalogue
+func catalogueResourceTypeIds(catalogue map[string][]string) []string {
+	result := make([]string, 0, len(catalogue))
+	for resourceTypeID := range catalogue {
+		result = append(result, resourceTypeID)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// unknownNameError is returned by checkName and checkType for names which are
+// not in the catalogue. The catalogue can be behind on commercetools, so these
+// are reported as warnings instead of errors.
+type unknownNameError struct {
+	msg string
+}
+
+func (e *unknownNameError) Error() string {
+	return e.msg
+}
+
+// checkName returns an unknownNameError when the value is not one of the
+// names, with a suggestion for the closest name when there is one. The kind
+// describes the value in the error, e.g. resource type.
+func checkName(kind, value string, names []string) error {
+	if slices.Contains(names, value) {
+		return nil
+	}
+	if suggestion := closestName(value, names); suggestion != "" {
+		return &unknownNameError{fmt.Sprintf("%s %s is not known, did you mean %s?", kind, value, suggestion)}
+	}
+	return &unknownNameError{fmt.Sprintf("%s %s is not known, known values are %s", kind, value, strings.Join(names, ", "))}
+}
+
+// checkType returns an error when the value is one of the types of another
+// resource type in the catalogue, and an unknownNameError when the value is not
+// in the catalogue at all. Unknown resource types are not checked, since these
+// are reported by checkName.
+func checkType(kind string, catalogue map[string][]string, resourceTypeID, value string) error {
+	names, ok := catalogue[resourceTypeID]
+	if !ok || slices.Contains(names, value) {
+		return nil
+	}
+
+	for _, other := range catalogueResourceTypeIds(catalogue) {
+		if slices.Contains(catalogue[other], value) {
+			return fmt.Errorf("%s %s is not valid for resource type %s, it belongs to resource type %s",
+				kind, value, resourceTypeID, other)
+		}
+	}
+	if suggestion := closestName(value, names); suggestion != "" {
+		return &unknownNameError{fmt.Sprintf("%s %s is not known for resource type %s, did you mean %s?",
+			kind, value, resourceTypeID, suggestion)}
+	}
+	return &unknownNameError{fmt.Sprintf("%s %s is not known for resource type %s", kind, value, resourceTypeID)}
+}
+
+// closestName returns the name with the smallest edit distance to the value,
+// or an empty string when no name is close enough to be a likely typo
+func closestName(value string, names []string) string {
+	result := ""
+	best := max(2, len(value)/4) + 1
+	for _, name := range names {
+		distance := editDistance(strings.ToLower(value), strings.ToLower(name))
+		if distance < best {
+			result = name
+			best = distance
+		}
+	}
+	return result
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,112 @@
+package subscription
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckName(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		names    []string
+		expected string
+	}{
+		{"valid change resource type", "product", changeResourceTypeIds, ""},
+		{"typo in change resource type", "prodcut", changeResourceTypeIds, "resource type prodcut is not known, did you mean product?"},
+		{"wrong case", "Order", catalogueResourceTypeIds(messageTypes), "resource type Order is not known, did you mean order?"},
+		{"valid event resource type", "import-api", catalogueResourceTypeIds(eventTypes), ""},
+		{"unknown", "foo", []string{"checkout", "import-api"}, "resource type foo is not known, known values are checkout, import-api"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkName("resource type", c.value, c.names)
+			if c.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, c.expected)
+		})
+	}
+}
+
+func TestCheckType(t *testing.T) {
+	cases := []struct {
+		name           string
+		kind           string
+		catalogue      map[string][]string
+		resourceTypeID string
+		value          string
+		expected       string
+	}{
+		{"valid message", "message type", messageTypes, "order", "OrderCreated", ""},
+		{"valid order message without prefix", "message type", messageTypes, "order", "DeliveryAdded", ""},
+		{"typo in message", "message type", messageTypes, "order", "OrderCreatd",
+			"message type OrderCreatd is not known for resource type order, did you mean OrderCreated?"},
+		{"message of other resource type", "message type", messageTypes, "product", "OrderCreated",
+			"message type OrderCreated is not valid for resource type product, it belongs to resource type order"},
+		{"unknown message", "message type", messageTypes, "review", "Something",
+			"message type Something is not known for resource type review"},
+		{"unknown resource type is not checked", "message type", messageTypes, "foo", "OrderCreated", ""},
+		{"valid event", "event type", eventTypes, "import-api", "ImportContainerCreated", ""},
+		{"typo in event", "event type", eventTypes, "checkout", "CheckoutPaymentCharge",
+			"event type CheckoutPaymentCharge is not known for resource type checkout, did you mean CheckoutPaymentCharged?"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := checkType(c.kind, c.catalogue, c.resourceTypeID, c.value)
+			if c.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, c.expected)
+		})
+	}
+}
+
+func TestCatalogueMessageTypesAreUnique(t *testing.T) {
+	seen := map[string]string{}
+	for resourceTypeID, names := range messageTypes {
+		for _, name := range names {
+			other, ok := seen[name]
+			assert.False(t, ok, "message type %s belongs to both %s and %s", name, resourceTypeID, other)
+			seen[name] = resourceTypeID
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("order", "order"))
+	assert.Equal(t, 1, editDistance("OrderCreatd", "OrderCreated"))
+	assert.Equal(t, 2, editDistance("prodcut", "product"))
+	assert.Equal(t, 5, editDistance("", "order"))
+}
+
+func TestAddCatalogueDiagnostic(t *testing.T) {
+	p := path.Root("message")
+
+	// Names which are not in the catalogue are reported as a warning with the
+	// suggestion
+	var diags diag.Diagnostics
+	addCatalogueDiagnostic(&diags, p, "message type", checkType("message type", messageTypes, "order", "OrderCreatd"))
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+	assert.Equal(t, "Unknown message type", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "did you mean OrderCreated?")
+
+	// Names of another resource type are an error
+	diags = nil
+	addCatalogueDiagnostic(&diags, p, "message type", checkType("message type", messageTypes, "product", "OrderCreated"))
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityError, diags[0].Severity())
+
+	diags = nil
+	addCatalogueDiagnostic(&diags, p, "message type", nil)
+	assert.Empty(t, diags)
+}
@@ -0,0 +1,389 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ConfigValidator = destinationValidator{}
+
+var (
+	awsRegionPattern    = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-\d+$`)
+	awsAccountIDPattern = regexp.MustCompile(`^\d{12}$`)
+	sqsQueueNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,80}(\.fifo)?$`)
+	pubSubTopicPattern  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.~+%-]{2,254}$`)
+	pubSubTopicPath     = regexp.MustCompile(`^projects/([^/]+)/topics/([^/]+)$`)
+)
+
+// eventGridHostSuffixes are the host names of the EventGrid topics in the
+// public and the sovereign Azure clouds
+var eventGridHostSuffixes = []string{
+	".eventgrid.azure.net",
+	".eventgrid.azure.us",
+	".eventgrid.azure.cn",
+}
+
+// destinationValidator cross-checks the settings of the destination, e.g. that
+// the region of the SQS queue URL matches the region. The settings are only
+// checked offline, so a destination which passes can still be unreachable.
+type destinationValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (v destinationValidator) Description(_ context.Context) string {
+	return "the settings of the destination must be valid for the type of the destination"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v destinationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v destinationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var destinations []Destination
+	p := path.Root("destination")
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &destinations)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, d := range destinations {
+		resp.Diagnostics.Append(d.validate(p.AtListIndex(i))...)
+	}
+}
+
+// validate returns the diagnostics for the settings of the destination, with
+// the attribute paths relative to p
+func (d *Destination) validate(p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !isKnown(d.Type) {
+		return diags
+	}
+
+	addError := func(attribute string, err error) {
+		diags.AddAttributeError(p.AtName(attribute), "Invalid destination", err.Error())
+	}
+
+	switch d.Type.ValueString() {
+	case SQS:
+		// The region of the queue URL is only compared with a valid region
+		validRegion := isKnown(d.Region)
+		if validRegion {
+			if err := validateAWSRegion(d.Region.ValueString()); err != nil {
+				addError("region", err)
+				validRegion = false
+			}
+		}
+		if isKnown(d.QueueURL) {
+			queue, err := parseSQSQueueURL(d.QueueURL.ValueString())
+			if err != nil {
+				addError("queue_url", err)
+				break
+			}
+			if validRegion && queue.Region != d.Region.ValueString() {
+				addError("queue_url", fmt.Errorf("the region of the queue URL is %s, but region is %s",
+					queue.Region, d.Region.ValueString()))
+			}
+			if isKnown(d.AccountID) && queue.AccountID != d.AccountID.ValueString() {
+				addError("queue_url", fmt.Errorf("the account ID of the queue URL is %s, but account_id is %s",
+					queue.AccountID, d.AccountID.ValueString()))
+			}
+		}
+
+	case SNS:
+		if isKnown(d.TopicARN) {
+			arn, err := parseARN(d.TopicARN.ValueString(), "sns")
+			if err != nil {
+				addError("topic_arn", err)
+				break
+			}
+			if isKnown(d.Region) && arn.Region != d.Region.ValueString() {
+				addError("topic_arn", fmt.Errorf("the region of the topic ARN is %s, but region is %s",
+					arn.Region, d.Region.ValueString()))
+			}
+			if isKnown(d.AccountID) && arn.AccountID != d.AccountID.ValueString() {
+				addError("topic_arn", fmt.Errorf("the account ID of the topic ARN is %s, but account_id is %s",
+					arn.AccountID, d.AccountID.ValueString()))
+			}
+		}
+
+	case EventBridge:
+		if isKnown(d.Region) {
+			if err := validateAWSRegion(d.Region.ValueString()); err != nil {
+				addError("region", err)
+			}
+		}
+		if isKnown(d.AccountID) {
+			if err := validateAWSAccountID(d.AccountID.ValueString()); err != nil {
+				addError("account_id", err)
+			}
+		}
+
+	case EventGrid:
+		if isKnown(d.URI) {
+			if err := validateEventGridURI(d.URI.ValueString()); err != nil {
+				addError("uri", err)
+			}
+		}
+
+	case AzureServiceBus:
+		// The connection string contains a secret, so the errors never
+		// contain the value
+		if isKnown(d.ConnectionString) {
+			if err := validateAzureConnectionString(d.ConnectionString.ValueString()); err != nil {
+				addError("connection_string", err)
+			}
+		}
+		if isKnown(d.ConnectionStringWO) {
+			if err := validateAzureConnectionString(d.ConnectionStringWO.ValueString()); err != nil {
+				addError("connection_string_wo", err)
+			}
+		}
+
+	case GoogleCloudPubSub:
+		if isKnown(d.Topic) {
+			if err := validatePubSubTopic(d.Topic.ValueString(), d.ProjectID); err != nil {
+				addError("topic", err)
+			}
+		}
+
+	case ConfluentCloud:
+		if isKnown(d.BootstrapServer) {
+			if err := validateBootstrapServer(d.BootstrapServer.ValueString()); err != nil {
+				addError("bootstrap_server", err)
+			}
+		}
+	}
+	return diags
+}
+
+func isKnown(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// awsARN is a parsed Amazon Resource Name, see
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference-arns.html
+type awsARN struct {
+	Partition string
+	Service   string
+	Region    string
+	AccountID string
+	Resource  string
+}
+
+// parseARN parses the ARN of a resource of the given service, and checks that
+// the region belongs to the partition
+func parseARN(value, service string) (*awsARN, error) {
+	parts := strings.SplitN(value, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return nil, fmt.Errorf("%s is not a valid ARN, expected arn:aws:%s:<region>:<account-id>:<name>", value, service)
+	}
+	arn := &awsARN{
+		Partition: parts[1],
+		Service:   parts[2],
+		Region:    parts[3],
+		AccountID: parts[4],
+		Resource:  parts[5],
+	}
+
+	if arn.Service != service {
+		return nil, fmt.Errorf("%s is not the ARN of a %s resource, the service is %s", value, service, arn.Service)
+	}
+	if err := validateAWSRegion(arn.Region); err != nil {
+		return nil, fmt.Errorf("%s is not a valid ARN: %w", value, err)
+	}
+	if err := validateAWSAccountID(arn.AccountID); err != nil {
+		return nil, fmt.Errorf("%s is not a valid ARN: %w", value, err)
+	}
+	if arn.Resource == "" {
+		return nil, fmt.Errorf("%s is not a valid ARN, the resource name is empty", value)
+	}
+
+	expected := awsPartition(arn.Region)
+	if arn.Partition != expected {
+		return nil, fmt.Errorf("%s is not a valid ARN, the partition of region %s is %s, not %s",
+			value, arn.Region, expected, arn.Partition)
+	}
+	return arn, nil
+}
+
+// awsPartition returns the partition of the region
+func awsPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "us-iso-"):
+		return "aws-iso"
+	case strings.HasPrefix(region, "us-isob-"):
+		return "aws-iso-b"
+	}
+	return "aws"
+}
+
+func validateAWSRegion(region string) error {
+	if !awsRegionPattern.MatchString(region) {
+		return fmt.Errorf("%s is not a valid AWS region, for example eu-west-1", region)
+	}
+	return nil
+}
+
+func validateAWSAccountID(accountID string) error {
+	if !awsAccountIDPattern.MatchString(accountID) {
+		return fmt.Errorf("%s is not a valid AWS account ID, which consists of 12 digits", accountID)
+	}
+	return nil
+}
+
+// sqsQueueURL is a parsed SQS queue URL, see
+// https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/sqs-queue-message-identifiers.html
+type sqsQueueURL struct {
+	Region    string
+	AccountID string
+	Name      string
+}
+
+// parseSQSQueueURL parses queue URLs in the format
+// https://sqs.<region>.amazonaws.com/<account-id>/<name> and the legacy format
+// https://<region>.queue.amazonaws.com/<account-id>/<name>
+func parseSQSQueueURL(value string) (*sqsQueueURL, error) {
+	const format = "https://sqs.<region>.amazonaws.com/<account-id>/<queue-name>"
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("%s is not a valid SQS queue URL, expected %s", value, format)
+	}
+
+	host := strings.TrimSuffix(strings.TrimSuffix(u.Hostname(), ".cn"), ".amazonaws.com")
+	var region string
+	switch {
+	case strings.HasPrefix(host, "sqs."):
+		region = strings.TrimPrefix(host, "sqs.")
+	case strings.HasSuffix(host, ".queue"):
+		region = strings.TrimSuffix(host, ".queue")
+	default:
+		return nil, fmt.Errorf("%s is not a valid SQS queue URL, expected %s", value, format)
+	}
+	if err := validateAWSRegion(region); err != nil {
+		return nil, fmt.Errorf("%s is not a valid SQS queue URL: %w", value, err)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%s is not a valid SQS queue URL, expected %s", value, format)
+	}
+	if err := validateAWSAccountID(parts[0]); err != nil {
+		return nil, fmt.Errorf("%s is not a valid SQS queue URL: %w", value, err)
+	}
+	if !sqsQueueNamePattern.MatchString(parts[1]) {
+		return nil, fmt.Errorf("%s is not a valid SQS queue URL, %s is not a valid queue name", value, parts[1])
+	}
+
+	return &sqsQueueURL{
+		Region:    region,
+		AccountID: parts[0],
+		Name:      parts[1],
+	}, nil
+}
+
+// validateEventGridURI validates the URI of an EventGrid topic, in the format
+// https://<topic>.<region>.eventgrid.azure.net/api/events
+func validateEventGridURI(value string) error {
+	const format = "https://<topic>.<region>.eventgrid.azure.net/api/events"
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%s is not a valid EventGrid topic URI, expected %s", value, format)
+	}
+
+	validHost := false
+	for _, suffix := range eventGridHostSuffixes {
+		if strings.HasSuffix(u.Hostname(), suffix) {
+			validHost = true
+		}
+	}
+	if !validHost {
+		return fmt.Errorf("%s is not a valid EventGrid topic URI, the host must end with %s",
+			value, strings.Join(eventGridHostSuffixes, ", "))
+	}
+	if u.Path != "/api/events" {
+		return fmt.Errorf("%s is not a valid EventGrid topic URI, the path must be /api/events", value)
+	}
+	return nil
+}
+
+// validateAzureConnectionString validates that the connection string of the
+// Azure Service Bus contains the keys required by commercetools. The value
+// is a secret, so it is never part of the error.
+func validateAzureConnectionString(value string) error {
+	keys := map[string]string{}
+	for _, part := range strings.Split(value, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, v, found := strings.Cut(part, "=")
+		if !found {
+			return fmt.Errorf("the connection string must consist of key=value pairs separated by ;")
+		}
+		keys[strings.TrimSpace(key)] = v
+	}
+
+	if !strings.HasPrefix(keys["Endpoint"], "sb://") {
+		return fmt.Errorf("the Endpoint of the connection string must start with sb://")
+	}
+	if keys["EntityPath"] == "" {
+		return fmt.Errorf("the connection string must contain the EntityPath of the queue or topic, use the " +
+			"connection string of a shared access policy of the queue or topic instead of the namespace")
+	}
+	if keys["SharedAccessSignature"] == "" && (keys["SharedAccessKeyName"] == "" || keys["SharedAccessKey"] == "") {
+		return fmt.Errorf("the connection string must contain the SharedAccessKeyName and SharedAccessKey")
+	}
+	return nil
+}
+
+// validatePubSubTopic validates that the topic is the ID of the topic instead
+// of the full resource name, which commercetools doesn't accept
+func validatePubSubTopic(value string, projectID types.String) error {
+	if match := pubSubTopicPath.FindStringSubmatch(value); match != nil {
+		if isKnown(projectID) && projectID.ValueString() != match[1] {
+			return fmt.Errorf("%s is the full resource name of a topic in project %s, but project_id is %s. "+
+				"Set topic to the ID of the topic, %s", value, match[1], projectID.ValueString(), match[2])
+		}
+		return fmt.Errorf("%s is the full resource name of the topic, set topic to the ID of the topic, %s, and "+
+			"project_id to %s", value, match[2], match[1])
+	}
+	if !pubSubTopicPattern.MatchString(value) || strings.HasPrefix(value, "goog") {
+		return fmt.Errorf("%s is not a valid Pub/Sub topic ID, which starts with a letter and consists of 3 to "+
+			"255 letters, digits and the characters -_.~+%%", value)
+	}
+	return nil
+}
+
+// validateBootstrapServer validates that the bootstrap server is a host with a
+// port, e.g. pkc-12345.europe-west1.gcp.confluent.cloud:9092
+func validateBootstrapServer(value string) error {
+	const format = "<host>:<port>, for example pkc-12345.europe-west1.gcp.confluent.cloud:9092"
+
+	if strings.Contains(value, "://") {
+		return fmt.Errorf("%s is not a valid bootstrap server, remove the scheme. Expected %s", value, format)
+	}
+	host, port, err := net.SplitHostPort(value)
+	if err != nil || host == "" {
+		return fmt.Errorf("%s is not a valid bootstrap server, expected %s", value, format)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("%s is not a valid bootstrap server, %s is not a valid port", value, port)
+	}
+	return nil
+}
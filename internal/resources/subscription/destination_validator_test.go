@@ -0,0 +1,297 @@
+package subscription
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestinationValidate(t *testing.T) {
+	cases := []struct {
+		name        string
+		destination Destination
+		attribute   string
+		error       string
+	}{
+		{
+			name: "valid SQS",
+			destination: Destination{
+				Type:      types.StringValue(SQS),
+				QueueURL:  types.StringValue("https://sqs.eu-west-1.amazonaws.com/123456789012/my-queue"),
+				Region:    types.StringValue("eu-west-1"),
+				AccountID: types.StringValue("123456789012"),
+			},
+		},
+		{
+			name: "valid legacy SQS queue URL",
+			destination: Destination{
+				Type:     types.StringValue(SQS),
+				QueueURL: types.StringValue("https://eu-west-1.queue.amazonaws.com/123456789012/my-queue.fifo"),
+				Region:   types.StringValue("eu-west-1"),
+			},
+		},
+		{
+			name: "SQS region mismatch",
+			destination: Destination{
+				Type:     types.StringValue(SQS),
+				QueueURL: types.StringValue("https://sqs.eu-west-1.amazonaws.com/123456789012/my-queue"),
+				Region:   types.StringValue("us-east-1"),
+			},
+			attribute: "queue_url",
+			error:     "the region of the queue URL is eu-west-1, but region is us-east-1",
+		},
+		{
+			name: "SQS account mismatch",
+			destination: Destination{
+				Type:      types.StringValue(SQS),
+				QueueURL:  types.StringValue("https://sqs.eu-west-1.amazonaws.com/123456789012/my-queue"),
+				Region:    types.StringValue("eu-west-1"),
+				AccountID: types.StringValue("210987654321"),
+			},
+			attribute: "queue_url",
+			error:     "the account ID of the queue URL is 123456789012, but account_id is 210987654321",
+		},
+		{
+			name: "SQS queue URL without account",
+			destination: Destination{
+				Type:     types.StringValue(SQS),
+				QueueURL: types.StringValue("https://sqs.eu-west-1.amazonaws.com/my-queue"),
+				Region:   types.StringValue("eu-west-1"),
+			},
+			attribute: "queue_url",
+			error: "https://sqs.eu-west-1.amazonaws.com/my-queue is not a valid SQS queue URL, expected " +
+				"https://sqs.<region>.amazonaws.com/<account-id>/<queue-name>",
+		},
+		{
+			name: "SQS invalid region",
+			destination: Destination{
+				Type:     types.StringValue(SQS),
+				QueueURL: types.StringValue("https://sqs.eu-west-1.amazonaws.com/123456789012/my-queue"),
+				Region:   types.StringValue("Ireland"),
+			},
+			attribute: "region",
+			error:     "Ireland is not a valid AWS region, for example eu-west-1",
+		},
+		{
+			name: "valid SNS",
+			destination: Destination{
+				Type:     types.StringValue(SNS),
+				TopicARN: types.StringValue("arn:aws:sns:eu-west-1:123456789012:my-topic"),
+			},
+		},
+		{
+			name: "valid SNS in China",
+			destination: Destination{
+				Type:     types.StringValue(SNS),
+				TopicARN: types.StringValue("arn:aws-cn:sns:cn-north-1:123456789012:my-topic"),
+			},
+		},
+		{
+			name: "SNS topic is not an ARN",
+			destination: Destination{
+				Type:     types.StringValue(SNS),
+				TopicARN: types.StringValue("my-topic"),
+			},
+			attribute: "topic_arn",
+			error:     "my-topic is not a valid ARN, expected arn:aws:sns:<region>:<account-id>:<name>",
+		},
+		{
+			name: "SNS ARN of other service",
+			destination: Destination{
+				Type:     types.StringValue(SNS),
+				TopicARN: types.StringValue("arn:aws:sqs:eu-west-1:123456789012:my-queue"),
+			},
+			attribute: "topic_arn",
+			error:     "arn:aws:sqs:eu-west-1:123456789012:my-queue is not the ARN of a sns resource, the service is sqs",
+		},
+		{
+			name: "SNS ARN partition mismatch",
+			destination: Destination{
+				Type:     types.StringValue(SNS),
+				TopicARN: types.StringValue("arn:aws:sns:cn-north-1:123456789012:my-topic"),
+			},
+			attribute: "topic_arn",
+			error: "arn:aws:sns:cn-north-1:123456789012:my-topic is not a valid ARN, the partition of region " +
+				"cn-north-1 is aws-cn, not aws",
+		},
+		{
+			name: "SNS ARN invalid account",
+			destination: Destination{
+				Type:     types.StringValue(SNS),
+				TopicARN: types.StringValue("arn:aws:sns:eu-west-1:1234:my-topic"),
+			},
+			attribute: "topic_arn",
+			error: "arn:aws:sns:eu-west-1:1234:my-topic is not a valid ARN: 1234 is not a valid AWS account ID, " +
+				"which consists of 12 digits",
+		},
+		{
+			name: "SNS region mismatch",
+			destination: Destination{
+				Type:     types.StringValue(SNS),
+				TopicARN: types.StringValue("arn:aws:sns:eu-west-1:123456789012:my-topic"),
+				Region:   types.StringValue("eu-central-1"),
+			},
+			attribute: "topic_arn",
+			error:     "the region of the topic ARN is eu-west-1, but region is eu-central-1",
+		},
+		{
+			name: "EventBridge invalid account",
+			destination: Destination{
+				Type:      types.StringValue(EventBridge),
+				Region:    types.StringValue("eu-west-1"),
+				AccountID: types.StringValue("my-account"),
+			},
+			attribute: "account_id",
+			error:     "my-account is not a valid AWS account ID, which consists of 12 digits",
+		},
+		{
+			name: "valid EventGrid",
+			destination: Destination{
+				Type: types.StringValue(EventGrid),
+				URI:  types.StringValue("https://my-topic.westeurope-1.eventgrid.azure.net/api/events"),
+			},
+		},
+		{
+			name: "EventGrid URI without path",
+			destination: Destination{
+				Type: types.StringValue(EventGrid),
+				URI:  types.StringValue("https://my-topic.westeurope-1.eventgrid.azure.net"),
+			},
+			attribute: "uri",
+			error: "https://my-topic.westeurope-1.eventgrid.azure.net is not a valid EventGrid topic URI, the " +
+				"path must be /api/events",
+		},
+		{
+			name: "EventGrid URI of other host",
+			destination: Destination{
+				Type: types.StringValue(EventGrid),
+				URI:  types.StringValue("https://example.com/api/events"),
+			},
+			attribute: "uri",
+			error: "https://example.com/api/events is not a valid EventGrid topic URI, the host must end with " +
+				".eventgrid.azure.net, .eventgrid.azure.us, .eventgrid.azure.cn",
+		},
+		{
+			name: "valid AzureServiceBus",
+			destination: Destination{
+				Type: types.StringValue(AzureServiceBus),
+				ConnectionString: types.StringValue("Endpoint=sb://my-bus.servicebus.windows.net/;" +
+					"SharedAccessKeyName=test;SharedAccessKey=secret=;EntityPath=my-queue"),
+			},
+		},
+		{
+			name: "AzureServiceBus without EntityPath",
+			destination: Destination{
+				Type: types.StringValue(AzureServiceBus),
+				ConnectionString: types.StringValue("Endpoint=sb://my-bus.servicebus.windows.net/;" +
+					"SharedAccessKeyName=test;SharedAccessKey=secret="),
+			},
+			attribute: "connection_string",
+			error: "the connection string must contain the EntityPath of the queue or topic, use the connection " +
+				"string of a shared access policy of the queue or topic instead of the namespace",
+		},
+		{
+			name: "AzureServiceBus write-only without key",
+			destination: Destination{
+				Type: types.StringValue(AzureServiceBus),
+				ConnectionStringWO: types.StringValue("Endpoint=sb://my-bus.servicebus.windows.net/;" +
+					"EntityPath=my-queue"),
+			},
+			attribute: "connection_string_wo",
+			error:     "the connection string must contain the SharedAccessKeyName and SharedAccessKey",
+		},
+		{
+			name: "valid GoogleCloudPubSub",
+			destination: Destination{
+				Type:      types.StringValue(GoogleCloudPubSub),
+				ProjectID: types.StringValue("my-project"),
+				Topic:     types.StringValue("my-topic"),
+			},
+		},
+		{
+			name: "GoogleCloudPubSub topic resource name",
+			destination: Destination{
+				Type:      types.StringValue(GoogleCloudPubSub),
+				ProjectID: types.StringValue("my-project"),
+				Topic:     types.StringValue("projects/my-project/topics/my-topic"),
+			},
+			attribute: "topic",
+			error: "projects/my-project/topics/my-topic is the full resource name of the topic, set topic to " +
+				"the ID of the topic, my-topic, and project_id to my-project",
+		},
+		{
+			name: "GoogleCloudPubSub topic of other project",
+			destination: Destination{
+				Type:      types.StringValue(GoogleCloudPubSub),
+				ProjectID: types.StringValue("my-project"),
+				Topic:     types.StringValue("projects/other-project/topics/my-topic"),
+			},
+			attribute: "topic",
+			error: "projects/other-project/topics/my-topic is the full resource name of a topic in project " +
+				"other-project, but project_id is my-project. Set topic to the ID of the topic, my-topic",
+		},
+		{
+			name: "valid ConfluentCloud",
+			destination: Destination{
+				Type:            types.StringValue(ConfluentCloud),
+				BootstrapServer: types.StringValue("pkc-12345.europe-west1.gcp.confluent.cloud:9092"),
+			},
+		},
+		{
+			name: "ConfluentCloud bootstrap server without port",
+			destination: Destination{
+				Type:            types.StringValue(ConfluentCloud),
+				BootstrapServer: types.StringValue("pkc-12345.europe-west1.gcp.confluent.cloud"),
+			},
+			attribute: "bootstrap_server",
+			error: "pkc-12345.europe-west1.gcp.confluent.cloud is not a valid bootstrap server, expected " +
+				"<host>:<port>, for example pkc-12345.europe-west1.gcp.confluent.cloud:9092",
+		},
+		{
+			name: "ConfluentCloud bootstrap server with scheme",
+			destination: Destination{
+				Type:            types.StringValue(ConfluentCloud),
+				BootstrapServer: types.StringValue("https://pkc-12345.europe-west1.gcp.confluent.cloud:9092"),
+			},
+			attribute: "bootstrap_server",
+			error: "https://pkc-12345.europe-west1.gcp.confluent.cloud:9092 is not a valid bootstrap server, " +
+				"remove the scheme. Expected <host>:<port>, for example pkc-12345.europe-west1.gcp.confluent.cloud:9092",
+		},
+		{
+			name: "unknown values are not validated",
+			destination: Destination{
+				Type:     types.StringValue(SQS),
+				QueueURL: types.StringUnknown(),
+				Region:   types.StringValue("eu-west-1"),
+			},
+		},
+	}
+
+	p := path.Root("destination").AtListIndex(0)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := c.destination.validate(p)
+			if c.error == "" {
+				assert.False(t, diags.HasError(), diags)
+				return
+			}
+
+			require.Len(t, diags, 1)
+			assert.Equal(t, c.error, diags[0].Detail())
+			assert.Equal(t, "Invalid destination", diags[0].Summary())
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			assert.Equal(t, p.AtName(c.attribute), withPath.Path())
+		})
+	}
+}
+
+func TestValidateAzureConnectionStringHidesSecret(t *testing.T) {
+	err := validateAzureConnectionString("Endpoint=https://my-bus.servicebus.windows.net/;SharedAccessKey=secret")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
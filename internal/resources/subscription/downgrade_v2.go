@@ -108,6 +108,9 @@ func downgradeStateV2(ctx context.Context, req resource.UpgradeStateRequest, res
 			"format":      valueToFormatV1(rawState, "format"),
 			"message":     rawState["message"],
 			"event":       eventVal,
+
+			"status":           tftypes.NewValue(tftypes.String, nil),
+			"wait_for_healthy": tftypes.NewValue(tftypes.String, nil),
 		}),
 	)
 	if err != nil {
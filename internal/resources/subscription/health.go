@@ -0,0 +1,72 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+// healthCheckInterval is the time between two checks of the health status of
+// a subscription
+var healthCheckInterval = 5 * time.Second
+
+// waitForHealthy polls the subscription until its health status is Healthy.
+// An error is returned as soon as the status shows that commercetools can't
+// deliver to the destination, or when the subscription isn't healthy in time.
+// The last retrieved subscription is returned as well, so the state contains
+// the latest status.
+func waitForHealthy(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, id string, timeout time.Duration) (*platform.Subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var subscription *platform.Subscription
+	for {
+		result, err := client.Subscriptions().WithId(id).Get().Execute(ctx)
+		switch {
+		case err == nil:
+			subscription = result
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			// The timeout is reported below
+		default:
+			return subscription, err
+		}
+
+		if subscription != nil {
+			if err := healthError(subscription.Status); err != nil || subscription.Status == platform.SubscriptionHealthStatusHealthy {
+				return subscription, err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			status := "unknown"
+			if subscription != nil {
+				status = string(subscription.Status)
+			}
+			return subscription, fmt.Errorf("the subscription is not healthy after %s, the status is %s", timeout, status)
+		case <-time.After(healthCheckInterval):
+		}
+	}
+}
+
+// healthError returns an error for the statuses in which commercetools doesn't
+// deliver to the destination until the configuration is fixed. A
+// TemporaryError is expected to resolve itself.
+func healthError(status platform.SubscriptionHealthStatus) error {
+	switch status {
+	case platform.SubscriptionHealthStatusConfigurationError:
+		return fmt.Errorf("the status of the subscription is %s, commercetools can't deliver to the destination. "+
+			"Check the configuration of the destination and the permissions of commercetools", status)
+	case platform.SubscriptionHealthStatusConfigurationErrorDeliveryStopped:
+		return fmt.Errorf("the status of the subscription is %s, commercetools can't deliver to the destination "+
+			"and stopped the delivery. Check the configuration of the destination and the permissions of "+
+			"commercetools", status)
+	case platform.SubscriptionHealthStatusManuallySuspended:
+		return fmt.Errorf("the status of the subscription is %s, the delivery was suspended by the commercetools "+
+			"support", status)
+	}
+	return nil
+}
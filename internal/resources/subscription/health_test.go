@@ -0,0 +1,106 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHealthTestClient returns a client for a fake commercetools API which
+// returns the subscription with the given statuses, repeating the last status
+func newHealthTestClient(t *testing.T, statuses ...platform.SubscriptionHealthStatus) (*platform.ByProjectKeyRequestBuilder, func() int) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(platform.Subscription{
+			ID:      "subscription-id",
+			Version: calls,
+			Status:  status,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := platform.NewClient(&platform.ClientConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	return client.WithProjectKey("test"), func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+}
+
+func TestWaitForHealthy(t *testing.T) {
+	interval := healthCheckInterval
+	healthCheckInterval = time.Millisecond
+	t.Cleanup(func() { healthCheckInterval = interval })
+
+	t.Run("healthy after temporary error", func(t *testing.T) {
+		client, calls := newHealthTestClient(t,
+			platform.SubscriptionHealthStatusTemporaryError,
+			platform.SubscriptionHealthStatusTemporaryError,
+			platform.SubscriptionHealthStatusHealthy,
+		)
+
+		subscription, err := waitForHealthy(context.Background(), client, "subscription-id", time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, platform.SubscriptionHealthStatusHealthy, subscription.Status)
+		assert.Equal(t, 3, calls())
+	})
+
+	t.Run("configuration error", func(t *testing.T) {
+		client, calls := newHealthTestClient(t,
+			platform.SubscriptionHealthStatusTemporaryError,
+			platform.SubscriptionHealthStatusConfigurationError,
+		)
+
+		subscription, err := waitForHealthy(context.Background(), client, "subscription-id", time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ConfigurationError")
+		assert.Equal(t, platform.SubscriptionHealthStatusConfigurationError, subscription.Status)
+		assert.Equal(t, 2, calls())
+	})
+
+	t.Run("manually suspended", func(t *testing.T) {
+		client, _ := newHealthTestClient(t, platform.SubscriptionHealthStatusManuallySuspended)
+
+		subscription, err := waitForHealthy(context.Background(), client, "subscription-id", time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ManuallySuspended")
+		assert.Equal(t, platform.SubscriptionHealthStatusManuallySuspended, subscription.Status)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client, _ := newHealthTestClient(t, platform.SubscriptionHealthStatusTemporaryError)
+
+		subscription, err := waitForHealthy(context.Background(), client, "subscription-id", 20*time.Millisecond)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not healthy after 20ms, the status is TemporaryError")
+		assert.Equal(t, platform.SubscriptionHealthStatusTemporaryError, subscription.Status)
+	})
+}
+
+func TestSubscriptionHealthTimeout(t *testing.T) {
+	timeout, ok := (&Subscription{WaitForHealthy: types.StringValue("5m")}).healthTimeout()
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Minute, timeout)
+
+	_, ok = (&Subscription{WaitForHealthy: types.StringNull()}).healthTimeout()
+	assert.False(t, ok)
+}
@@ -3,6 +3,7 @@ package subscription
 import (
 	"reflect"
 	"regexp"
+	"time"
 
 	"github.com/elliotchance/pie/v2"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -31,6 +32,9 @@ type Subscription struct {
 	Messages    []Message     `tfsdk:"message"`
 	Changes     []Changes     `tfsdk:"changes"`
 	Events      []Event       `tfsdk:"event"`
+
+	Status         types.String `tfsdk:"status"`
+	WaitForHealthy types.String `tfsdk:"wait_for_healthy"`
 }
 
 func NewSubscriptionFromNative(n *platform.Subscription) Subscription {
@@ -43,6 +47,9 @@ func NewSubscriptionFromNative(n *platform.Subscription) Subscription {
 		Messages:    make([]Message, len(n.Messages)),
 		Changes:     []Changes{},
 		Events:      make([]Event, len(n.Events)),
+
+		Status:         types.StringValue(string(n.Status)),
+		WaitForHealthy: types.StringNull(),
 	}
 
 	format := NewFormatFromNative(n.Format)
@@ -80,6 +87,9 @@ func NewSubscriptionFromNative(n *platform.Subscription) Subscription {
 }
 
 func (s *Subscription) matchDefaults(state Subscription) {
+	// wait_for_healthy is only known by terraform
+	s.WaitForHealthy = state.WaitForHealthy
+
 	if len(state.Format) == 0 {
 		if len(s.Format) == 1 && s.Format[0].Type.ValueString() == "Platform" {
 			s.Format = []Format{}
@@ -87,10 +97,30 @@ func (s *Subscription) matchDefaults(state Subscription) {
 	}
 }
 
+// healthTimeout returns the time to wait until the subscription is healthy, or
+// false when wait_for_healthy isn't set
+func (s *Subscription) healthTimeout() (time.Duration, bool) {
+	if s.WaitForHealthy.IsNull() || s.WaitForHealthy.IsUnknown() {
+		return 0, false
+	}
+	timeout, err := time.ParseDuration(s.WaitForHealthy.ValueString())
+	if err != nil || timeout <= 0 {
+		return 0, false
+	}
+	return timeout, true
+}
+
 func (s *Subscription) setSecretValues(state Subscription) {
 	s.Destination[0].setSecretValues(&state.Destination[0])
 }
 
+func (s *Subscription) setWriteOnlyValues(config Subscription) {
+	if len(s.Destination) == 0 || len(config.Destination) == 0 {
+		return
+	}
+	s.Destination[0].setWriteOnlyValues(&config.Destination[0])
+}
+
 func (s *Subscription) draft() platform.SubscriptionDraft {
 	var changes []platform.ChangeSubscription
 	for _, c := range s.Changes {
@@ -172,7 +202,7 @@ func (s *Subscription) updateActions(plan Subscription) platform.SubscriptionUpd
 	}
 
 	// changeDestination
-	if !reflect.DeepEqual(s.Destination, plan.Destination) {
+	if !reflect.DeepEqual(withoutWriteOnlyValues(s.Destination), withoutWriteOnlyValues(plan.Destination)) {
 		result.Actions = append(
 			result.Actions,
 			platform.SubscriptionChangeDestinationAction{
@@ -248,6 +278,52 @@ type Destination struct {
 	ApiSecret       types.String `tfsdk:"api_secret"`
 	Acks            types.String `tfsdk:"acks"`
 	Key             types.String `tfsdk:"key"`
+
+	// Write-only variants of the secrets, these are only available in the
+	// configuration. The versions are stored to detect rotations.
+	AccessKeyWO               types.String `tfsdk:"access_key_wo"`
+	AccessKeyWOVersion        types.Int64  `tfsdk:"access_key_wo_version"`
+	AccessSecretWO            types.String `tfsdk:"access_secret_wo"`
+	AccessSecretWOVersion     types.Int64  `tfsdk:"access_secret_wo_version"`
+	ConnectionStringWO        types.String `tfsdk:"connection_string_wo"`
+	ConnectionStringWOVersion types.Int64  `tfsdk:"connection_string_wo_version"`
+	ApiKeyWO                  types.String `tfsdk:"api_key_wo"`
+	ApiKeyWOVersion           types.Int64  `tfsdk:"api_key_wo_version"`
+	ApiSecretWO               types.String `tfsdk:"api_secret_wo"`
+	ApiSecretWOVersion        types.Int64  `tfsdk:"api_secret_wo_version"`
+}
+
+// setWriteOnlyValues copies the write-only secrets from the configuration,
+// since these are always null in the plan
+func (d *Destination) setWriteOnlyValues(config *Destination) {
+	if config == nil {
+		return
+	}
+	d.AccessKeyWO = config.AccessKeyWO
+	d.AccessSecretWO = config.AccessSecretWO
+	d.ConnectionStringWO = config.ConnectionStringWO
+	d.ApiKeyWO = config.ApiKeyWO
+	d.ApiSecretWO = config.ApiSecretWO
+}
+
+// withoutWriteOnlyValues returns the destination without the write-only
+// secrets, to compare it with the state. Rotations are detected with the
+// versions instead.
+func (d Destination) withoutWriteOnlyValues() Destination {
+	d.AccessKeyWO = types.StringNull()
+	d.AccessSecretWO = types.StringNull()
+	d.ConnectionStringWO = types.StringNull()
+	d.ApiKeyWO = types.StringNull()
+	d.ApiSecretWO = types.StringNull()
+	return d
+}
+
+// secretValue returns the write-only variant of the secret when it is set
+func secretValue(value, writeOnly types.String) types.String {
+	if !writeOnly.IsNull() && !writeOnly.IsUnknown() {
+		return writeOnly
+	}
+	return value
 }
 
 func (d *Destination) setSecretValues(state *Destination) {
@@ -255,8 +331,21 @@ func (d *Destination) setSecretValues(state *Destination) {
 		return
 	}
 
+	d.AccessKeyWOVersion = state.AccessKeyWOVersion
+	d.AccessSecretWOVersion = state.AccessSecretWOVersion
+	d.ConnectionStringWOVersion = state.ConnectionStringWOVersion
+	d.ApiKeyWOVersion = state.ApiKeyWOVersion
+	d.ApiSecretWOVersion = state.ApiSecretWOVersion
+
 	switch d.Type.ValueString() {
 	case AzureServiceBus:
+		// The connection string is never stored when the write-only variant
+		// is used
+		if state.ConnectionString.IsNull() {
+			d.ConnectionString = state.ConnectionString
+			break
+		}
+
 		// Quick hack. Filter out the shared access key since that value is
 		// masked by commercetools. If the strings are equal then copy the val
 		// from the state. Otherwise, we use the value from the plan
@@ -331,7 +420,7 @@ func (d *Destination) ToNative() platform.Destination {
 	switch val {
 	case AzureServiceBus:
 		return platform.AzureServiceBusDestination{
-			ConnectionString: d.ConnectionString.ValueString(),
+			ConnectionString: secretValue(d.ConnectionString, d.ConnectionStringWO).ValueString(),
 		}
 	case EventBridge:
 		return platform.EventBridgeDestination{
@@ -340,7 +429,7 @@ func (d *Destination) ToNative() platform.Destination {
 		}
 	case EventGrid:
 		return platform.AzureEventGridDestination{
-			AccessKey: d.AccessKey.ValueString(),
+			AccessKey: secretValue(d.AccessKey, d.AccessKeyWO).ValueString(),
 			Uri:       d.URI.ValueString(),
 		}
 	case GoogleCloudPubSub:
@@ -351,7 +440,7 @@ func (d *Destination) ToNative() platform.Destination {
 	case SQS:
 		result := platform.SqsDestination{
 			AccessKey:    utils.OptionalString(d.AccessKey),
-			AccessSecret: utils.OptionalString(d.AccessSecret),
+			AccessSecret: utils.OptionalString(secretValue(d.AccessSecret, d.AccessSecretWO)),
 			QueueUrl:     d.QueueURL.ValueString(),
 			Region:       d.Region.ValueString(),
 		}
@@ -363,7 +452,7 @@ func (d *Destination) ToNative() platform.Destination {
 	case SNS:
 		result := platform.SnsDestination{
 			AccessKey:    utils.OptionalString(d.AccessKey),
-			AccessSecret: utils.OptionalString(d.AccessSecret),
+			AccessSecret: utils.OptionalString(secretValue(d.AccessSecret, d.AccessSecretWO)),
 			TopicArn:     d.TopicARN.ValueString(),
 		}
 		if result.AccessKey == nil {
@@ -374,8 +463,8 @@ func (d *Destination) ToNative() platform.Destination {
 	case ConfluentCloud:
 		result := platform.ConfluentCloudDestination{
 			BootstrapServer: d.BootstrapServer.ValueString(),
-			ApiKey:          d.ApiKey.ValueString(),
-			ApiSecret:       d.ApiSecret.ValueString(),
+			ApiKey:          secretValue(d.ApiKey, d.ApiKeyWO).ValueString(),
+			ApiSecret:       secretValue(d.ApiSecret, d.ApiSecretWO).ValueString(),
 			Acks:            d.Acks.ValueString(),
 			Topic:           d.Topic.ValueString(),
 			Key:             utils.OptionalString(d.Key),
@@ -385,6 +474,10 @@ func (d *Destination) ToNative() platform.Destination {
 	return nil
 }
 
+func withoutWriteOnlyValues(destinations []Destination) []Destination {
+	return pie.Map(destinations, Destination.withoutWriteOnlyValues)
+}
+
 type Changes struct {
 	ResourceTypeIds []types.String `tfsdk:"resource_type_ids"`
 }
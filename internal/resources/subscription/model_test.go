@@ -198,6 +198,125 @@ func TestImport(t *testing.T) {
 	}
 }
 
+func TestDestinationWriteOnlySecrets(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dest     Destination
+		expected platform.Destination
+	}{
+		{
+			name: "SQS",
+			dest: Destination{
+				Type:           types.StringValue(SQS),
+				QueueURL:       types.StringValue("queue-url"),
+				Region:         types.StringValue("eu-west-1"),
+				AccessKey:      types.StringValue("key"),
+				AccessSecretWO: types.StringValue("secret"),
+			},
+			expected: platform.SqsDestination{
+				QueueUrl:     "queue-url",
+				Region:       "eu-west-1",
+				AccessKey:    utils.StringRef("key"),
+				AccessSecret: utils.StringRef("secret"),
+			},
+		},
+		{
+			name: "EventGrid",
+			dest: Destination{
+				Type:        types.StringValue(EventGrid),
+				URI:         types.StringValue("uri"),
+				AccessKeyWO: types.StringValue("key"),
+			},
+			expected: platform.AzureEventGridDestination{
+				Uri:       "uri",
+				AccessKey: "key",
+			},
+		},
+		{
+			name: "AzureServiceBus",
+			dest: Destination{
+				Type:               types.StringValue(AzureServiceBus),
+				ConnectionStringWO: types.StringValue("Endpoint=sb://test"),
+			},
+			expected: platform.AzureServiceBusDestination{
+				ConnectionString: "Endpoint=sb://test",
+			},
+		},
+		{
+			name: "ConfluentCloud",
+			dest: Destination{
+				Type:            types.StringValue(ConfluentCloud),
+				BootstrapServer: types.StringValue("server"),
+				ApiKeyWO:        types.StringValue("key"),
+				ApiSecretWO:     types.StringValue("secret"),
+				Acks:            types.StringValue("all"),
+				Topic:           types.StringValue("topic"),
+			},
+			expected: platform.ConfluentCloudDestination{
+				BootstrapServer: "server",
+				ApiKey:          "key",
+				ApiSecret:       "secret",
+				Acks:            "all",
+				Topic:           "topic",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualValues(t, tc.expected, tc.dest.ToNative())
+		})
+	}
+}
+
+func TestDestinationSetSecretValuesWriteOnly(t *testing.T) {
+	d := NewDestinationFromNative(platform.AzureServiceBusDestination{
+		ConnectionString: "Endpoint=sb://test;SharedAccessKey=****abc",
+	})
+	d.setSecretValues(&Destination{
+		ConnectionStringWO:        types.StringValue("Endpoint=sb://test;SharedAccessKey=secret"),
+		ConnectionStringWOVersion: types.Int64Value(2),
+	})
+
+	// The secrets are never stored, only the version is
+	assert.EqualValues(t, Destination{
+		Type:                      types.StringValue(AzureServiceBus),
+		ConnectionString:          types.StringNull(),
+		ConnectionStringWOVersion: types.Int64Value(2),
+	}, *d)
+}
+
+func TestUpdateActionsWriteOnly(t *testing.T) {
+	state := Subscription{
+		Version: types.Int64Value(10),
+		Destination: []Destination{{
+			Type:                  types.StringValue(SNS),
+			TopicARN:              types.StringValue("arn"),
+			AccessKey:             types.StringValue("key"),
+			AccessSecretWOVersion: types.Int64Value(1),
+		}},
+	}
+
+	// The write-only secret is never in the state, so it is not compared
+	plan := Subscription{
+		Version:     types.Int64Value(10),
+		Destination: []Destination{state.Destination[0]},
+	}
+	plan.Destination[0].AccessSecretWO = types.StringValue("secret")
+	assert.Empty(t, state.updateActions(plan).Actions)
+
+	// A new version of the secret updates the destination
+	plan.Destination[0].AccessSecretWOVersion = types.Int64Value(2)
+	assert.EqualValues(t, []platform.SubscriptionUpdateAction{
+		platform.SubscriptionChangeDestinationAction{
+			Destination: platform.SnsDestination{
+				TopicArn:     "arn",
+				AccessKey:    utils.StringRef("key"),
+				AccessSecret: utils.StringRef("secret"),
+			},
+		},
+	}, state.updateActions(plan).Actions)
+}
+
 func TestUpdateActions(t *testing.T) {
 	testCases := []struct {
 		name     string
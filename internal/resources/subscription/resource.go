@@ -26,6 +26,8 @@ var (
 	_ resource.Resource                = &subscriptionResource{}
 	_ resource.ResourceWithConfigure   = &subscriptionResource{}
 	_ resource.ResourceWithImportState = &subscriptionResource{}
+
+	_ resource.ResourceWithConfigValidators = &subscriptionResource{}
 )
 
 // NewSubscriptionResource is a helper function to simplify the provider implementation.
@@ -64,6 +66,21 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 			"version": schema.Int64Attribute{
 				Computed: true,
 			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The [health status](https://docs.commercetools.com/api/projects/subscriptions#subscriptionhealthstatus) " +
+					"of the subscription, for example `Healthy` or `ConfigurationError`",
+				Computed: true,
+			},
+			"wait_for_healthy": schema.StringAttribute{
+				MarkdownDescription: "Time to wait after creating or updating the subscription until its status is " +
+					"`Healthy`, for example `5m`. An error is returned when the status is `ConfigurationError`, " +
+					"`ConfigurationErrorDeliveryStopped` or `ManuallySuspended`, or when the subscription is not " +
+					"healthy in time",
+				Optional: true,
+				Validators: []validator.String{
+					customvalidator.DurationValidator(),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"changes": schema.SetNestedBlock{
@@ -74,6 +91,9 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 							MarkdownDescription: "[Resource Type ID](https://docs.commercetools.com/api/projects/subscriptions#changesubscription)",
 							ElementType:         types.StringType,
 							Required:            true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(newResourceTypeIDValidator(changeResourceTypeIds)),
+							},
 						},
 					},
 				},
@@ -110,12 +130,17 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 								),
 								customvalidator.DependencyValidator(
 									EventGrid,
-									path.MatchRelative().AtParent().AtName("access_key"),
 									path.MatchRelative().AtParent().AtName("uri"),
 								),
-								customvalidator.DependencyValidator(
+								customvalidator.DependencyAnyValidator(
+									EventGrid,
+									path.MatchRelative().AtParent().AtName("access_key"),
+									path.MatchRelative().AtParent().AtName("access_key_wo"),
+								),
+								customvalidator.DependencyAnyValidator(
 									AzureServiceBus,
 									path.MatchRelative().AtParent().AtName("connection_string"),
+									path.MatchRelative().AtParent().AtName("connection_string_wo"),
 								),
 								customvalidator.DependencyValidator(
 									GoogleCloudPubSub,
@@ -125,9 +150,17 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 								customvalidator.DependencyValidator(
 									ConfluentCloud,
 									path.MatchRelative().AtParent().AtName("bootstrap_server"),
+									path.MatchRelative().AtParent().AtName("acks"),
+								),
+								customvalidator.DependencyAnyValidator(
+									ConfluentCloud,
 									path.MatchRelative().AtParent().AtName("api_key"),
+									path.MatchRelative().AtParent().AtName("api_key_wo"),
+								),
+								customvalidator.DependencyAnyValidator(
+									ConfluentCloud,
 									path.MatchRelative().AtParent().AtName("api_secret"),
-									path.MatchRelative().AtParent().AtName("acks"),
+									path.MatchRelative().AtParent().AtName("api_secret_wo"),
 								),
 							},
 						},
@@ -187,6 +220,7 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 						"connection_string": schema.StringAttribute{
 							Description: "The connection string of the Azure Service Bus",
 							Optional:    true,
+							Sensitive:   true,
 							Validators: []validator.String{
 								stringvalidator.RegexMatches(
 									regexp.MustCompilePOSIX("^Endpoint=sb://"),
@@ -218,6 +252,7 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 						"api_key": schema.StringAttribute{
 							Description: "The API key of the Confluent Cloud topic",
 							Optional:    true,
+							Sensitive:   true,
 							Validators: []validator.String{
 								stringvalidator.LengthAtLeast(1),
 							},
@@ -225,15 +260,16 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 						"api_secret": schema.StringAttribute{
 							Description: "The API secret of the Confluent Cloud topic",
 							Optional:    true,
+							Sensitive:   true,
 							Validators: []validator.String{
 								stringvalidator.LengthAtLeast(1),
 							},
 						},
 						"acks": schema.StringAttribute{
-							Description: "The acks value of the Confluent Cloud topic",
+							Description: "The acks value of the Confluent Cloud topic, one of 0, 1, -1 or all",
 							Optional:    true,
 							Validators: []validator.String{
-								stringvalidator.LengthAtLeast(1),
+								stringvalidator.OneOf("0", "1", "-1", "all"),
 							},
 						},
 						"key": schema.StringAttribute{
@@ -243,6 +279,84 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 								stringvalidator.LengthAtLeast(1),
 							},
 						},
+						"access_key_wo": schema.StringAttribute{
+							Description: "Write-only variant of access_key for the EventGrid topic, which is never " +
+								"stored in the state. Requires Terraform 1.11 or later",
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("access_key")),
+								customvalidator.RequireValueStringValidator(
+									EventGrid,
+									path.MatchRelative().AtParent().AtName("type"),
+								),
+							},
+						},
+						"access_key_wo_version": schema.Int64Attribute{
+							Description: "The version of access_key_wo, change it to update the access key",
+							Optional:    true,
+						},
+						"access_secret_wo": schema.StringAttribute{
+							Description: "Write-only variant of access_secret, which is never stored in the state. " +
+								"Requires Terraform 1.11 or later",
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("access_secret")),
+							},
+						},
+						"access_secret_wo_version": schema.Int64Attribute{
+							Description: "The version of access_secret_wo, change it to update the access secret",
+							Optional:    true,
+						},
+						"connection_string_wo": schema.StringAttribute{
+							Description: "Write-only variant of connection_string, which is never stored in the " +
+								"state. Requires Terraform 1.11 or later",
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("connection_string")),
+								stringvalidator.RegexMatches(
+									regexp.MustCompilePOSIX("^Endpoint=sb://"),
+									"Connection String should start with Endpoint=sb://",
+								),
+							},
+						},
+						"connection_string_wo_version": schema.Int64Attribute{
+							Description: "The version of connection_string_wo, change it to update the connection string",
+							Optional:    true,
+						},
+						"api_key_wo": schema.StringAttribute{
+							Description: "Write-only variant of api_key, which is never stored in the state. " +
+								"Requires Terraform 1.11 or later",
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("api_key")),
+							},
+						},
+						"api_key_wo_version": schema.Int64Attribute{
+							Description: "The version of api_key_wo, change it to update the API key",
+							Optional:    true,
+						},
+						"api_secret_wo": schema.StringAttribute{
+							Description: "Write-only variant of api_secret, which is never stored in the state. " +
+								"Requires Terraform 1.11 or later",
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("api_secret")),
+							},
+						},
+						"api_secret_wo_version": schema.Int64Attribute{
+							Description: "The version of api_secret_wo, change it to update the API secret",
+							Optional:    true,
+						},
 					},
 				},
 				Validators: []validator.List{
@@ -291,6 +405,9 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 						"resource_type_id": schema.StringAttribute{
 							MarkdownDescription: "[Resource Type ID](https://docs.commercetools.com/api/projects/subscriptions#changesubscription)",
 							Required:            true,
+							Validators: []validator.String{
+								newResourceTypeIDValidator(catalogueResourceTypeIds(messageTypes)),
+							},
 						},
 						"types": schema.ListAttribute{
 							MarkdownDescription: "types must contain valid message types for this resource, for example for " +
@@ -298,6 +415,9 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 								"messages are given, the subscription is valid for all messages of this resource",
 							ElementType: types.StringType,
 							Required:    true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(newTypeValidator("message type", messageTypes)),
+							},
 						},
 					},
 				},
@@ -309,6 +429,9 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 						"resource_type_id": schema.StringAttribute{
 							MarkdownDescription: "[Resource Type ID](https://docs.commercetools.com/api/projects/subscriptions#ctp:api:type:EventSubscriptionResourceTypeId)",
 							Required:            true,
+							Validators: []validator.String{
+								newResourceTypeIDValidator(catalogueResourceTypeIds(eventTypes)),
+							},
 						},
 						"types": schema.ListAttribute{
 							MarkdownDescription: "Must contain valid event types for the resource. For example, for " +
@@ -316,6 +439,9 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 								"are given, the Subscription will receive all events for the defined resource type.",
 							ElementType: types.StringType,
 							Required:    true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(newTypeValidator("event type", eventTypes)),
+							},
 						},
 					},
 				},
@@ -324,6 +450,13 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 	}
 }
 
+// ConfigValidators returns the validators for the resource configuration.
+func (r *subscriptionResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		destinationValidator{},
+	}
+}
+
 // Configure adds the provider configured client to the data source.
 func (r *subscriptionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -354,6 +487,15 @@ func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	// The write-only secrets are only available in the configuration
+	var config Subscription
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.setWriteOnlyValues(config)
+
 	draft := plan.draft()
 	var subscription *platform.Subscription
 	err := retry.RetryContext(ctx, 20*time.Second, func() *retry.RetryError {
@@ -369,6 +511,8 @@ func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	subscription, healthErr := r.waitForHealthy(ctx, plan, subscription)
+
 	current := NewSubscriptionFromNative(subscription)
 	current.matchDefaults(plan)
 	current.setSecretValues(plan)
@@ -379,6 +523,10 @@ func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if healthErr != nil {
+		resp.Diagnostics.AddError("Subscription is not healthy", healthErr.Error())
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -434,10 +582,25 @@ func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	// The write-only secrets are only available in the configuration
+	var config Subscription
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.setWriteOnlyValues(config)
+
 	input := state.updateActions(plan)
 	var subscription *platform.Subscription
 	err := retry.RetryContext(ctx, 5*time.Second, func() *retry.RetryError {
 		var err error
+		// Nothing changes in commercetools when only wait_for_healthy
+		// changed
+		if len(input.Actions) == 0 {
+			subscription, err = r.client.Subscriptions().WithId(state.ID.ValueString()).Get().Execute(ctx)
+			return utils.ProcessRemoteError(err)
+		}
 		subscription, err = r.client.Subscriptions().WithId(state.ID.ValueString()).Post(input).Execute(ctx)
 		return utils.ProcessRemoteError(err)
 	})
@@ -452,6 +615,8 @@ func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRe
 	// Transform response to terraform value and call `setPlanData` with the
 	// plan to copy the secrets from the plan since those are returned by
 	// commercetools as masked values.
+	subscription, healthErr := r.waitForHealthy(ctx, plan, subscription)
+
 	current := NewSubscriptionFromNative(subscription)
 	current.matchDefaults(plan)
 	current.setSecretValues(plan)
@@ -461,6 +626,26 @@ func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if healthErr != nil {
+		resp.Diagnostics.AddError("Subscription is not healthy", healthErr.Error())
+	}
+}
+
+// waitForHealthy waits until the subscription is healthy when wait_for_healthy
+// is set. The last retrieved subscription is returned, so the state contains
+// the latest health status, also when an error is returned.
+func (r *subscriptionResource) waitForHealthy(ctx context.Context, plan Subscription, subscription *platform.Subscription) (*platform.Subscription, error) {
+	timeout, ok := plan.healthTimeout()
+	if !ok {
+		return subscription, nil
+	}
+
+	result, err := waitForHealthy(ctx, r.client, subscription.ID, timeout)
+	if result == nil {
+		return subscription, err
+	}
+	return result, err
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
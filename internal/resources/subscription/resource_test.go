@@ -23,7 +23,7 @@ func TestAccSubscription_basic(t *testing.T) {
 	name := "foobar"
 	key := "my-key"
 	resourceName := fmt.Sprintf("commercetools_subscription.%s", name)
-	queueUrl := "https://sqs.eu-west-1.amazonaws.com/0000000001/some-queue"
+	queueUrl := "https://sqs.eu-west-1.amazonaws.com/000000000001/some-queue"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
@@ -122,7 +122,7 @@ func TestAccSubscription_sqs_failure(t *testing.T) {
 }
 
 func testAccSubscriptionConfigSQSFailure(identifier, key string) string {
-	queueURL := "https://sqs.eu-west-1.amazonaws.com/0000000000/some-queue"
+	queueURL := "https://sqs.eu-west-1.amazonaws.com/000000000000/some-queue"
 	accessKey := "some-access-key"
 	secretKey := "some-secret-key"
 
@@ -54,6 +54,9 @@ func upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp
 			"format":      valueToFormatV1(rawState, "format"),
 			"message":     rawState["message"],
 			"event":       eventVal,
+
+			"status":           tftypes.NewValue(tftypes.String, nil),
+			"wait_for_healthy": tftypes.NewValue(tftypes.String, nil),
 		}),
 	)
 
@@ -11,6 +11,9 @@ var SubscriptionResourceV1 = tftypes.Object{
 		"key":     tftypes.String,
 		"version": tftypes.Number,
 
+		"status":           tftypes.String,
+		"wait_for_healthy": tftypes.String,
+
 		"changes": tftypes.Set{
 			ElementType: tftypes.Object{
 				AttributeTypes: map[string]tftypes.Type{
@@ -74,6 +77,17 @@ var destinationType = tftypes.Object{
 		"api_secret":        tftypes.String,
 		"acks":              tftypes.String,
 		"key":               tftypes.String,
+
+		"access_key_wo":                tftypes.String,
+		"access_key_wo_version":        tftypes.Number,
+		"access_secret_wo":             tftypes.String,
+		"access_secret_wo_version":     tftypes.Number,
+		"connection_string_wo":         tftypes.String,
+		"connection_string_wo_version": tftypes.Number,
+		"api_key_wo":                   tftypes.String,
+		"api_key_wo_version":           tftypes.Number,
+		"api_secret_wo":                tftypes.String,
+		"api_secret_wo_version":        tftypes.Number,
 	},
 }
 
@@ -117,6 +131,13 @@ func valueDestinationV1(state map[string]tftypes.Value, key string) tftypes.Valu
 		newVal["acks"] = tftypes.NewValue(tftypes.String, nil)
 		newVal["key"] = tftypes.NewValue(tftypes.String, nil)
 
+		// The write-only secrets didn't exist in the older versions
+		for name, t := range destinationType.AttributeTypes {
+			if _, ok := newVal[name]; !ok {
+				newVal[name] = tftypes.NewValue(t, nil)
+			}
+		}
+
 		val = tftypes.NewValue(destinationType, newVal)
 
 		return tftypes.NewValue(
@@ -0,0 +1,101 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.String = resourceTypeIDValidator{}
+	_ validator.String = typeValidator{}
+)
+
+// resourceTypeIDValidator validates that the value is one of the resource
+// types which can be subscribed to
+type resourceTypeIDValidator struct {
+	resourceTypeIds []string
+}
+
+func newResourceTypeIDValidator(resourceTypeIds []string) resourceTypeIDValidator {
+	return resourceTypeIDValidator{resourceTypeIds: resourceTypeIds}
+}
+
+// Description describes the validation in plain text formatting.
+func (v resourceTypeIDValidator) Description(_ context.Context) string {
+	return "value must be a resource type which can be subscribed to"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v resourceTypeIDValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v resourceTypeIDValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	err := checkName("resource type", req.ConfigValue.ValueString(), v.resourceTypeIds)
+	addCatalogueDiagnostic(&resp.Diagnostics, req.Path, "resource type", err)
+}
+
+// typeValidator validates that the value is one of the message or event types
+// of the resource_type_id of the block. The value is an element of the types
+// list of the block.
+type typeValidator struct {
+	kind      string
+	catalogue map[string][]string
+}
+
+func newTypeValidator(kind string, catalogue map[string][]string) typeValidator {
+	return typeValidator{kind: kind, catalogue: catalogue}
+}
+
+// Description describes the validation in plain text formatting.
+func (v typeValidator) Description(_ context.Context) string {
+	return "value must be a " + v.kind + " of the resource type"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v typeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v typeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var resourceTypeID types.String
+	p := req.Path.ParentPath().ParentPath().AtName("resource_type_id")
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &resourceTypeID)...)
+	if resp.Diagnostics.HasError() || resourceTypeID.IsNull() || resourceTypeID.IsUnknown() {
+		return
+	}
+
+	err := checkType(v.kind, v.catalogue, resourceTypeID.ValueString(), req.ConfigValue.ValueString())
+	addCatalogueDiagnostic(&resp.Diagnostics, req.Path, v.kind, err)
+}
+
+// addCatalogueDiagnostic adds the error of checkName or checkType. Names which
+// are not in the catalogue are reported as a warning, since commercetools may
+// support names the catalogue doesn't know yet.
+func addCatalogueDiagnostic(diags *diag.Diagnostics, p path.Path, kind string, err error) {
+	if err == nil {
+		return
+	}
+	var unknown *unknownNameError
+	if errors.As(err, &unknown) {
+		diags.AddAttributeWarning(p, "Unknown "+kind, err.Error()+
+			". The value is passed to commercetools as is, since the list of known values may be outdated.")
+		return
+	}
+	diags.AddAttributeError(p, "Invalid "+kind, err.Error())
+}
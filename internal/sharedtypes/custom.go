@@ -1,14 +1,21 @@
 package sharedtypes
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/labd/terraform-provider-commercetools/commercetools"
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
 )
 
 var (
@@ -23,10 +30,16 @@ var (
 				ElementType: types.StringType,
 				MarkdownDescription: "CustomValue fields for this resource. Note that " +
 					"the values need to be provided as JSON encoded " +
-					"strings: `my-value = jsonencode({\"key\": \"value\"})`",
+					"strings: `my-value = jsonencode({\"key\": \"value\"})`. " +
+					"The values are validated against the type during the plan when the id of the " +
+					"type is known. Fields and enum values the type doesn't have yet aren't rejected, " +
+					"since they may be added to the type in the same apply",
 				Optional: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"field": customFieldSchema,
+		},
 		Validators: []validator.Object{
 			// Ensure that a type_id is set if the custom block is set
 			objectvalidator.AlsoRequires(path.MatchRelative().AtName("type_id")),
@@ -34,24 +47,157 @@ var (
 	}
 )
 
+// ValidateCustomPlan validates the planned custom fields against the type when
+// the custom fields are changed and the type id is known. This reports invalid
+// values during the plan instead of during the apply. Fields and enum keys the
+// type doesn't define yet are reported as warnings.
+func ValidateCustomPlan(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, typeCache *utils.TypeCache, state tfsdk.State, plan tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client == nil || plan.Raw.IsNull() {
+		return diags
+	}
+
+	var planned types.Object
+	diags.Append(plan.GetAttribute(ctx, path.Root("custom"), &planned)...)
+	if diags.HasError() {
+		return diags
+	}
+	if !state.Raw.IsNull() {
+		var current types.Object
+		diags.Append(state.GetAttribute(ctx, path.Root("custom"), &current)...)
+		if diags.HasError() || current.Equal(planned) {
+			return diags
+		}
+	}
+
+	return validateCustom(ctx, planned, func(ctx context.Context, id string) (*platform.Type, error) {
+		return typeCache.Get(ctx, client, id)
+	})
+}
+
+// validateCustom validates the custom object against the type returned by the
+// fetcher.
+func validateCustom(ctx context.Context, custom types.Object, fetch utils.TypeFetcher) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if custom.IsNull() || custom.IsUnknown() {
+		return diags
+	}
+
+	typeID, ok := custom.Attributes()["type_id"].(types.String)
+	if !ok || typeID.IsNull() || typeID.IsUnknown() {
+		return diags
+	}
+	values, ok := custom.Attributes()["fields"].(types.Map)
+	if !ok || values.IsUnknown() {
+		return diags
+	}
+
+	blocks, ok := custom.Attributes()["field"].(types.Set)
+	if !ok || blocks.IsUnknown() {
+		return diags
+	}
+
+	fields := map[string]any{}
+	paths := map[string]path.Path{}
+	for name, value := range values.Elements() {
+		paths[name] = path.Root("custom").AtName("fields").AtMapKey(name)
+		v, ok := value.(types.String)
+		if !ok || v.IsNull() || v.IsUnknown() {
+			fields[name] = nil
+			continue
+		}
+		fields[name] = v.ValueString()
+	}
+
+	typed := map[string]any{}
+	for _, element := range blocks.Elements() {
+		block, ok := element.(types.Object)
+		if !ok || block.IsUnknown() {
+			return diags
+		}
+		name, ok := block.Attributes()["name"].(types.String)
+		if !ok || name.IsUnknown() {
+			return diags
+		}
+		p := path.Root("custom").AtName("field").AtSetValue(block)
+		if _, ok := typed[name.ValueString()]; ok {
+			diags.AddAttributeError(p, "Invalid custom field",
+				fmt.Sprintf("custom field '%s' is set in multiple field blocks", name.ValueString()))
+			return diags
+		}
+		paths[name.ValueString()] = p
+
+		// Blocks with values which are not known yet can't be converted
+		var field CustomField
+		if d := block.As(ctx, &field, basetypes.ObjectAsOptions{}); d.HasError() {
+			typed[name.ValueString()] = nil
+			continue
+		}
+		typed[name.ValueString()] = field.value()
+	}
+
+	fields, err := commercetools.MergeCustomFieldValues(fields, typed)
+	if err != nil {
+		diags.AddAttributeError(path.Root("custom"), "Invalid custom field", err.Error())
+		return diags
+	}
+
+	t, err := fetch(ctx, typeID.ValueString())
+	if err != nil {
+		if utils.IsResourceNotFoundError(err) {
+			diags.AddAttributeError(path.Root("custom").AtName("type_id"), "Invalid custom type",
+				fmt.Sprintf("Type %s does not exist", typeID.ValueString()))
+			return diags
+		}
+		diags.AddError("Failed to validate custom fields",
+			fmt.Sprintf("Failed to fetch type %s: %s", typeID.ValueString(), err))
+		return diags
+	}
+
+	for _, fieldErr := range commercetools.ValidateCustomFields(t, fields) {
+		p, ok := paths[fieldErr.Field]
+		if !ok {
+			p = path.Root("custom").AtName("fields")
+		}
+		if fieldErr.Undefined {
+			// The field or enum key may be added to the type in the same apply
+			diags.AddAttributeWarning(p, "Unknown custom field value", fieldErr.Error()+
+				". This fails when the type doesn't define it when the change is applied.")
+			continue
+		}
+		diags.AddAttributeError(p, "Invalid custom field", fieldErr.Error())
+	}
+	return diags
+}
+
 type CustomFieldTypeEncoder func(t *platform.Type, name string, value any) (any, error)
 
 type Custom struct {
 	TypeID *string           `tfsdk:"type_id"`
 	Fields map[string]string `tfsdk:"fields"`
+	Field  []CustomField     `tfsdk:"field"`
 }
 
 func (c *Custom) IsSet() bool {
 	return c != nil && c.TypeID != nil
 }
 
-func (c *Custom) fieldsInterface() map[string]any {
-	var result = make(map[string]any, len((*c).Fields))
-	for key, value := range (*c).Fields {
-		result[key] = value
+// fieldValues returns the values of the JSON encoded fields and the typed
+// field blocks
+func (c *Custom) fieldValues() (map[string]any, error) {
+	var fields = make(map[string]any, len(c.Fields))
+	for key, value := range c.Fields {
+		fields[key] = value
 	}
 
-	return result
+	typed := make(map[string]any, len(c.Field))
+	for _, field := range c.Field {
+		if _, ok := typed[field.Name]; ok {
+			return nil, fmt.Errorf("custom field '%s' is set in multiple field blocks", field.Name)
+		}
+		typed[field.Name] = field.value()
+	}
+	return commercetools.MergeCustomFieldValues(fields, typed)
 }
 
 // Draft generates a custom fields draft. It uses the default custom field encoder.
@@ -71,13 +217,13 @@ func (c *Custom) draftWithEncoder(t *platform.Type, encoder CustomFieldTypeEncod
 		},
 	}
 
-	fieldTypes := map[string]platform.FieldType{}
-	for _, field := range t.FieldDefinitions {
-		fieldTypes[field.Name] = field.Type
+	fields, err := c.fieldValues()
+	if err != nil {
+		return nil, err
 	}
 
 	container := platform.FieldContainer{}
-	for key, value := range c.Fields {
+	for key, value := range fields {
 		enc, err := encoder(t, key, value)
 		if err != nil {
 			return nil, err
@@ -104,7 +250,7 @@ func customFieldUpdateActionsWithEncoder[T commercetools.SetCustomTypeAction, F
 		return []any{action}, nil
 	}
 
-	if current == nil || (current.TypeID != plan.TypeID) {
+	if current == nil || current.TypeID == nil || plan.TypeID == nil || *current.TypeID != *plan.TypeID {
 		value, err := plan.draftWithEncoder(t, encoder)
 		if err != nil {
 			return nil, err
@@ -116,25 +262,25 @@ func customFieldUpdateActionsWithEncoder[T commercetools.SetCustomTypeAction, F
 		return []any{action}, nil
 	}
 
-	changes := commercetools.DiffSlices(current.fieldsInterface(), plan.fieldsInterface())
+	currentFields, err := current.fieldValues()
+	if err != nil {
+		return nil, err
+	}
+	planFields, err := plan.fieldValues()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := commercetools.CustomFieldChanges(t, encoder, currentFields, planFields)
+	if err != nil {
+		return nil, err
+	}
 
 	var result []any
 	for key := range changes {
-		if changes[key] == nil {
-			result = append(result, F{
-				Name:  key,
-				Value: nil,
-			})
-		} else {
-			val, err := encoder(t, key, changes[key])
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, F{
-				Name:  key,
-				Value: val,
-			})
-		}
+		result = append(result, F{
+			Name:  key,
+			Value: changes[key],
+		})
 	}
 	return result, nil
 }
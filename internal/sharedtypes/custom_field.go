@@ -0,0 +1,205 @@
+package sharedtypes
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/terraform-provider-commercetools/commercetools"
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+var (
+	customFieldMoneyAttributes = map[string]schema.Attribute{
+		"currency_code": schema.StringAttribute{
+			MarkdownDescription: "Currency code compliant to ISO 4217.",
+			Required:            true,
+		},
+		"cent_amount": schema.Int64Attribute{
+			MarkdownDescription: "Amount in the smallest indivisible unit of the currency.",
+			Required:            true,
+		},
+	}
+
+	customFieldReferenceAttributes = map[string]schema.Attribute{
+		"type_id": schema.StringAttribute{
+			MarkdownDescription: "The type of the referenced resource, e.g. `product-type`.",
+			Required:            true,
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the referenced resource.",
+			Required:            true,
+		},
+	}
+
+	customFieldSchema = schema.SetNestedBlock{
+		MarkdownDescription: "Custom field with a typed value. Set the value matching the type of the " +
+			"field. Can be combined with `fields`, but every field can only be set once.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "The name of the field.",
+					Required:            true,
+				},
+				"string": schema.StringAttribute{
+					MarkdownDescription: "Value of String, Enum, LocalizedEnum, Date (YYYY-MM-DD), " +
+						"Time (hh:mm:ss.sss) and DateTime fields.",
+					Optional: true,
+				},
+				"number": schema.Float64Attribute{
+					MarkdownDescription: "Value of Number fields.",
+					Optional:            true,
+				},
+				"boolean": schema.BoolAttribute{
+					MarkdownDescription: "Value of Boolean fields.",
+					Optional:            true,
+				},
+				"localized_string": schema.MapAttribute{
+					MarkdownDescription: "Value of LocalizedString fields.",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
+				"set": schema.ListAttribute{
+					MarkdownDescription: "Value of Set fields of strings, enums, numbers, booleans, " +
+						"dates and times. Elements of other types are JSON encoded.",
+					ElementType: types.StringType,
+					Optional:    true,
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"money": schema.SingleNestedBlock{
+					MarkdownDescription: "Value of Money fields.",
+					Attributes:          customFieldMoneyAttributes,
+				},
+				"reference": schema.SingleNestedBlock{
+					MarkdownDescription: "Value of Reference fields.",
+					Attributes:          customFieldReferenceAttributes,
+				},
+				"set_of_money": schema.ListNestedBlock{
+					MarkdownDescription: "Value of Set fields of Money.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: customFieldMoneyAttributes,
+					},
+				},
+				"set_of_references": schema.ListNestedBlock{
+					MarkdownDescription: "Value of Set fields of References.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: customFieldReferenceAttributes,
+					},
+				},
+			},
+		},
+	}
+)
+
+// CustomField is a custom field with a typed value
+type CustomField struct {
+	Name            string                 `tfsdk:"name"`
+	String          *string                `tfsdk:"string"`
+	Number          *float64               `tfsdk:"number"`
+	Boolean         *bool                  `tfsdk:"boolean"`
+	LocalizedString map[string]string      `tfsdk:"localized_string"`
+	Set             []string               `tfsdk:"set"`
+	Money           *CustomFieldMoney      `tfsdk:"money"`
+	Reference       *CustomFieldReference  `tfsdk:"reference"`
+	SetOfMoney      []CustomFieldMoney     `tfsdk:"set_of_money"`
+	SetOfReferences []CustomFieldReference `tfsdk:"set_of_references"`
+}
+
+type CustomFieldMoney struct {
+	CurrencyCode string `tfsdk:"currency_code"`
+	CentAmount   int64  `tfsdk:"cent_amount"`
+}
+
+type CustomFieldReference struct {
+	TypeID string `tfsdk:"type_id"`
+	ID     string `tfsdk:"id"`
+}
+
+func newCustomField(name string, v commercetools.CustomFieldValue) CustomField {
+	result := CustomField{
+		Name:            name,
+		String:          v.String,
+		Number:          v.Number,
+		Boolean:         v.Boolean,
+		LocalizedString: v.LocalizedString,
+		Set:             v.Set,
+	}
+	if v.Money != nil {
+		result.Money = &CustomFieldMoney{CurrencyCode: v.Money.CurrencyCode, CentAmount: v.Money.CentAmount}
+	}
+	if v.Reference != nil {
+		result.Reference = &CustomFieldReference{TypeID: v.Reference.TypeID, ID: v.Reference.ID}
+	}
+	for _, money := range v.SetOfMoney {
+		result.SetOfMoney = append(result.SetOfMoney, CustomFieldMoney{CurrencyCode: money.CurrencyCode, CentAmount: money.CentAmount})
+	}
+	for _, reference := range v.SetOfReferences {
+		result.SetOfReferences = append(result.SetOfReferences, CustomFieldReference{TypeID: reference.TypeID, ID: reference.ID})
+	}
+	return result
+}
+
+func (f CustomField) value() commercetools.CustomFieldValue {
+	result := commercetools.CustomFieldValue{
+		String:          f.String,
+		Number:          f.Number,
+		Boolean:         f.Boolean,
+		LocalizedString: f.LocalizedString,
+		Set:             f.Set,
+	}
+	if f.Money != nil {
+		result.Money = &commercetools.CustomFieldMoney{CurrencyCode: f.Money.CurrencyCode, CentAmount: f.Money.CentAmount}
+	}
+	if f.Reference != nil {
+		result.Reference = &commercetools.CustomFieldReference{TypeID: f.Reference.TypeID, ID: f.Reference.ID}
+	}
+	for _, money := range f.SetOfMoney {
+		result.SetOfMoney = append(result.SetOfMoney, commercetools.CustomFieldMoney{CurrencyCode: money.CurrencyCode, CentAmount: money.CentAmount})
+	}
+	for _, reference := range f.SetOfReferences {
+		result.SetOfReferences = append(result.SetOfReferences, commercetools.CustomFieldReference{TypeID: reference.TypeID, ID: reference.ID})
+	}
+	return result
+}
+
+// UsePriorRepresentation changes the representation of the custom fields read
+// from commercetools to match the prior plan or state. Fields which are set
+// with a typed field block in the prior value are moved from the JSON encoded
+// fields to field blocks, and JSON encoded values which are semantically equal
+// to the prior value are kept as is.
+func (c *Custom) UsePriorRepresentation(prior *Custom) {
+	if c == nil || prior == nil {
+		return
+	}
+
+	for _, field := range prior.Field {
+		raw, ok := c.Fields[field.Name]
+		if !ok {
+			continue
+		}
+
+		// String values are not JSON encoded by NewCustomFromNative
+		var native any = raw
+		if field.String == nil {
+			if err := json.Unmarshal([]byte(raw), &native); err != nil {
+				continue
+			}
+		}
+		value, err := commercetools.NewCustomFieldValue(native)
+		if err != nil {
+			continue
+		}
+		c.Field = append(c.Field, newCustomField(field.Name, value))
+		delete(c.Fields, field.Name)
+	}
+
+	for name, value := range c.Fields {
+		if previous, ok := prior.Fields[name]; ok && utils.JSONEqual(previous, value) {
+			c.Fields[name] = previous
+		}
+	}
+	if len(c.Fields) == 0 {
+		c.Fields = nil
+	}
+}
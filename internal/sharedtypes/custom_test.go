@@ -1,10 +1,17 @@
 package sharedtypes
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/labd/terraform-provider-commercetools/commercetools"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,10 +27,31 @@ func TestCustomIsSet(t *testing.T) {
 	assert.True(t, c.IsSet())
 }
 
-func TestCustomFieldsInterface(t *testing.T) {
-	c := &Custom{Fields: map[string]string{"key1": "value1", "key2": "value2"}}
-	expected := map[string]any{"key1": "value1", "key2": "value2"}
-	assert.Equal(t, expected, c.fieldsInterface())
+func TestCustomFieldValues(t *testing.T) {
+	c := &Custom{
+		Fields: map[string]string{"key1": "value1", "key2": "value2"},
+		Field:  []CustomField{{Name: "key3", Number: ref(1.5)}},
+	}
+	expected := map[string]any{
+		"key1": "value1",
+		"key2": "value2",
+		"key3": commercetools.CustomFieldValue{Number: ref(1.5)},
+	}
+	values, err := c.fieldValues()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, values)
+
+	c.Field = append(c.Field, CustomField{Name: "key1", String: ref("value1")})
+	_, err = c.fieldValues()
+	assert.EqualError(t, err, "custom field 'key1' is set in both fields and a field block")
+
+	c.Field = []CustomField{{Name: "key3"}, {Name: "key3"}}
+	_, err = c.fieldValues()
+	assert.EqualError(t, err, "custom field 'key3' is set in multiple field blocks")
+}
+
+func ref[T any](value T) *T {
+	return &value
 }
 
 func TestCustomDraftWithEncoder(t *testing.T) {
@@ -85,6 +113,25 @@ func TestCustomFieldUpdateActionsWithEncoder(t *testing.T) {
 		assert.Len(t, u, 0)
 	})
 
+	t.Run("without semantic changes", func(t *testing.T) {
+		tp := &platform.Type{FieldDefinitions: []platform.FieldDefinition{
+			{Name: "name", Type: platform.CustomFieldLocalizedStringType{}},
+			{Name: "count", Type: platform.CustomFieldNumberType{}},
+		}}
+		current := &Custom{TypeID: ref("type-id"), Fields: map[string]string{
+			"name":  `{"en": "foo", "nl": "bar"}`,
+			"count": "10",
+		}}
+		plan := &Custom{
+			TypeID: ref("type-id"),
+			Fields: map[string]string{"name": `{"nl":"bar","en":"foo"}`},
+			Field:  []CustomField{{Name: "count", Number: ref(10.0)}},
+		}
+		u, err := CustomFieldUpdateActions[platform.ChannelSetCustomTypeAction, platform.ChannelSetCustomFieldAction](tp, current, plan)
+		assert.NoError(t, err)
+		assert.Len(t, u, 0)
+	})
+
 	t.Run("error encoding", func(t *testing.T) {
 		typeID := "type-id"
 		plan := &Custom{TypeID: &typeID, Fields: map[string]string{"key": "value"}}
@@ -134,3 +181,150 @@ func TestNewCustomFromNative(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestUsePriorRepresentation(t *testing.T) {
+	current, err := NewCustomFromNative(&platform.CustomFields{
+		Type: platform.TypeReference{ID: "type-id"},
+		Fields: map[string]any{
+			"name":      "foo",
+			"count":     float64(10),
+			"price":     map[string]any{"currencyCode": "EUR", "centAmount": float64(100), "fractionDigits": float64(2), "type": "centPrecision"},
+			"label":     map[string]any{"en": "foo", "nl": "bar"},
+			"statuses":  []any{"active"},
+			"reference": map[string]any{"typeId": "zone", "id": "1234"},
+		},
+	})
+	assert.NoError(t, err)
+
+	current.UsePriorRepresentation(&Custom{
+		TypeID: ref("type-id"),
+		Fields: map[string]string{
+			"label":     `{"nl": "bar", "en": "foo"}`,
+			"reference": `{"typeId": "zone", "id": "other"}`,
+		},
+		Field: []CustomField{
+			{Name: "name", String: ref("bar")},
+			{Name: "count", Number: ref(1.0)},
+			{Name: "price", Money: &CustomFieldMoney{CurrencyCode: "EUR", CentAmount: 1}},
+			{Name: "statuses", Set: []string{}},
+		},
+	})
+
+	assert.Equal(t, map[string]string{
+		// Semantically equal values keep their formatting
+		"label":     `{"nl": "bar", "en": "foo"}`,
+		"reference": `{"id":"1234","typeId":"zone"}`,
+	}, current.Fields)
+	assert.ElementsMatch(t, []CustomField{
+		{Name: "name", String: ref("foo")},
+		{Name: "count", Number: ref(10.0)},
+		{Name: "price", Money: &CustomFieldMoney{CurrencyCode: "EUR", CentAmount: 100}},
+		{Name: "statuses", Set: []string{"active"}},
+	}, current.Field)
+}
+
+func TestValidateCustom(t *testing.T) {
+	ct := &platform.Type{
+		ID:  "type-id",
+		Key: "my-type",
+		FieldDefinitions: []platform.FieldDefinition{
+			{Name: "name", Type: platform.CustomFieldStringType{}, Required: true},
+			{Name: "count", Type: platform.CustomFieldNumberType{}},
+		},
+	}
+	fetch := func(_ context.Context, id string) (*platform.Type, error) {
+		if id != ct.ID {
+			return nil, platform.ErrNotFound
+		}
+		return ct, nil
+	}
+	fieldType := customFieldSchema.NestedObject.Type()
+	attrTypes := map[string]attr.Type{
+		"type_id": types.StringType,
+		"fields":  types.MapType{ElemType: types.StringType},
+		"field":   types.SetType{ElemType: fieldType},
+	}
+	customWithBlocks := func(typeID types.String, fields map[string]attr.Value, blocks ...attr.Value) types.Object {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"type_id": typeID,
+			"fields":  types.MapValueMust(types.StringType, fields),
+			"field":   types.SetValueMust(fieldType, blocks),
+		})
+	}
+	custom := func(typeID types.String, fields map[string]attr.Value) types.Object {
+		return customWithBlocks(typeID, fields)
+	}
+	block := func(values map[string]attr.Value) attr.Value {
+		objectType := fieldType.(types.ObjectType)
+		attrs := map[string]attr.Value{}
+		for name, attrType := range objectType.AttrTypes {
+			v, err := attrType.ValueFromTerraform(context.Background(), tftypes.NewValue(attrType.TerraformType(context.Background()), nil))
+			assert.NoError(t, err)
+			attrs[name] = v
+		}
+		for name, value := range values {
+			attrs[name] = value
+		}
+		return types.ObjectValueMust(objectType.AttrTypes, attrs)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		diags := validateCustom(context.Background(), custom(types.StringValue("type-id"), map[string]attr.Value{
+			"name":  types.StringValue("foo"),
+			"count": types.StringUnknown(),
+		}), fetch)
+		assert.False(t, diags.HasError())
+	})
+
+	t.Run("unknown type id", func(t *testing.T) {
+		diags := validateCustom(context.Background(), custom(types.StringUnknown(), map[string]attr.Value{
+			"unknown": types.StringValue("foo"),
+		}), fetch)
+		assert.False(t, diags.HasError())
+	})
+
+	t.Run("type does not exist", func(t *testing.T) {
+		diags := validateCustom(context.Background(), custom(types.StringValue("other-id"), nil), fetch)
+		assert.Len(t, diags, 1)
+		assert.Equal(t, path.Root("custom").AtName("type_id"), diags[0].(diag.DiagnosticWithPath).Path())
+	})
+
+	t.Run("typed fields", func(t *testing.T) {
+		invalid := block(map[string]attr.Value{
+			"name":   types.StringValue("count"),
+			"string": types.StringValue("10"),
+		})
+		diags := validateCustom(context.Background(), customWithBlocks(types.StringValue("type-id"), nil,
+			block(map[string]attr.Value{
+				"name":   types.StringValue("name"),
+				"string": types.StringUnknown(),
+			}),
+			invalid,
+		), fetch)
+		assert.Len(t, diags, 1)
+		assert.Equal(t, path.Root("custom").AtName("field").AtSetValue(invalid), diags[0].(diag.DiagnosticWithPath).Path())
+		assert.Equal(t, "value for field 'count' needs to be set as number", diags[0].Detail())
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		diags := validateCustom(context.Background(), custom(types.StringValue("type-id"), map[string]attr.Value{
+			"count": types.StringValue("ten"),
+		}), fetch)
+		assert.Len(t, diags, 2)
+		assert.Equal(t, path.Root("custom").AtName("fields").AtMapKey("count"), diags[0].(diag.DiagnosticWithPath).Path())
+		assert.Equal(t, "value for field 'count' needs to be a number: 'ten'", diags[0].Detail())
+		assert.Equal(t, path.Root("custom").AtName("fields"), diags[1].(diag.DiagnosticWithPath).Path())
+		assert.Equal(t, "field 'name' is required by type my-type (type-id)", diags[1].Detail())
+	})
+
+	t.Run("fields the type doesn't define yet", func(t *testing.T) {
+		diags := validateCustom(context.Background(), custom(types.StringValue("type-id"), map[string]attr.Value{
+			"name":   types.StringValue("foo"),
+			"colour": types.StringValue("red"),
+		}), fetch)
+		assert.False(t, diags.HasError())
+		assert.Len(t, diags, 1)
+		assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+		assert.Equal(t, path.Root("custom").AtName("fields").AtMapKey("colour"), diags[0].(diag.DiagnosticWithPath).Path())
+	})
+}
@@ -0,0 +1,86 @@
+package sharedtypes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/labd/terraform-provider-commercetools/internal/customtypes"
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+// ValidateLocalizedStringsPlan validates all localized strings in the plan
+// with the language validator of the provider. Localized strings are found by
+// their type, so nested localized strings are validated as well.
+func ValidateLocalizedStringsPlan(ctx context.Context, languages *utils.LanguageValidator, plan tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !languages.Enabled() || plan.Raw.IsNull() {
+		return diags
+	}
+
+	err := tftypes.Walk(plan.Raw, func(p *tftypes.AttributePath, value tftypes.Value) (bool, error) {
+		if len(p.Steps()) == 0 {
+			return true, nil
+		}
+		t, err := plan.Schema.TypeAtTerraformPath(ctx, p)
+		if err != nil {
+			return false, nil
+		}
+		if _, ok := t.(customtypes.LocalizedStringType); !ok {
+			return true, nil
+		}
+		if value.IsNull() || !value.IsFullyKnown() {
+			return false, nil
+		}
+
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return false, err
+		}
+		keys := make([]string, 0, len(elements))
+		for key := range elements {
+			keys = append(keys, key)
+		}
+
+		errs, err := languages.Validate(ctx, keys)
+		if err != nil {
+			return false, err
+		}
+		attrPath := attributePath(p)
+		for _, e := range errs {
+			if e.Key != "" {
+				diags.AddAttributeError(attrPath.AtMapKey(e.Key), "Invalid localized string", e.Err.Error())
+				continue
+			}
+			diags.AddAttributeError(attrPath, "Invalid localized string", e.Err.Error())
+		}
+		return false, nil
+	})
+	if err != nil {
+		diags.AddError("Failed to validate localized strings", err.Error())
+	}
+	return diags
+}
+
+// attributePath converts the terraform path to a framework path. Elements of
+// sets can't be addressed without their value, so the path of the set is used
+// for these.
+func attributePath(p *tftypes.AttributePath) path.Path {
+	result := path.Empty()
+	for _, step := range p.Steps() {
+		switch s := step.(type) {
+		case tftypes.AttributeName:
+			result = result.AtName(string(s))
+		case tftypes.ElementKeyString:
+			result = result.AtMapKey(string(s))
+		case tftypes.ElementKeyInt:
+			result = result.AtListIndex(int(s))
+		default:
+			return result
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package sharedtypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/labd/terraform-provider-commercetools/internal/customtypes"
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func TestValidateLocalizedStringsPlan(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.MapAttribute{
+				CustomType: customtypes.NewLocalizedStringType(),
+				Required:   true,
+			},
+			"description": schema.MapAttribute{
+				CustomType: customtypes.NewLocalizedStringType(),
+				Optional:   true,
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"value": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.MapAttribute{
+							CustomType: customtypes.NewLocalizedStringType(),
+							Optional:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	localized := tftypes.Map{ElementType: tftypes.String}
+	labelType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"label": localized}}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":        localized,
+		"description": localized,
+		"tags":        localized,
+		"value":       tftypes.List{ElementType: labelType},
+	}}
+	localizedValue := func(values map[string]string) tftypes.Value {
+		result := map[string]tftypes.Value{}
+		for key, value := range values {
+			result[key] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(localized, result)
+	}
+
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"name":        localizedValue(map[string]string{"en": "name", "de": "Name"}),
+			"description": tftypes.NewValue(localized, nil),
+			"tags":        localizedValue(map[string]string{"foo": "bar"}),
+			"value": tftypes.NewValue(tftypes.List{ElementType: labelType}, []tftypes.Value{
+				tftypes.NewValue(labelType, map[string]tftypes.Value{
+					"label": localizedValue(map[string]string{"en": "label", "de": "Label"}),
+				}),
+				tftypes.NewValue(labelType, map[string]tftypes.Value{
+					"label": localizedValue(map[string]string{"de": "Label"}),
+				}),
+				tftypes.NewValue(labelType, map[string]tftypes.Value{
+					"label": tftypes.NewValue(localized, tftypes.UnknownValue),
+				}),
+			}),
+		}),
+	}
+
+	languages := utils.NewLanguageValidator(nil, &utils.ProjectSettings{}, false, []string{"en"})
+	diags := ValidateLocalizedStringsPlan(ctx, languages, plan)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "missing a value for the required languages en", diags[0].Detail())
+	assert.Equal(t, path.Root("value").AtListIndex(1).AtName("label"), diags[0].(diag.DiagnosticWithPath).Path())
+
+	// Nothing is validated when the validation is disabled
+	languages = utils.NewLanguageValidator(nil, &utils.ProjectSettings{}, false, nil)
+	assert.Empty(t, ValidateLocalizedStringsPlan(ctx, languages, plan))
+}
@@ -0,0 +1,63 @@
+package sharedtypes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+// customCurrencyPaths are the currency codes of the money values of custom
+// fields, see CustomSchema
+var customCurrencyPaths = []path.Expression{
+	path.MatchRoot("custom").AtName("field").AtAnySetValue().AtName("money").AtName("currency_code"),
+	path.MatchRoot("custom").AtName("field").AtAnySetValue().AtName("set_of_money").AtAnyListIndex().AtName("currency_code"),
+}
+
+// ValidateCustomCurrenciesPlan validates the currencies of the money values of
+// the planned custom fields against the currencies of the project.
+func ValidateCustomCurrenciesPlan(ctx context.Context, validator *utils.ProjectValidator, plan tfsdk.Plan) diag.Diagnostics {
+	return ValidateCurrenciesPlan(ctx, validator, plan, customCurrencyPaths...)
+}
+
+// ValidateCurrenciesPlan validates the planned currency codes matching the
+// expressions against the currencies of the project.
+func ValidateCurrenciesPlan(ctx context.Context, validator *utils.ProjectValidator, plan tfsdk.Plan, expressions ...path.Expression) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !validator.Enabled() || plan.Raw.IsNull() {
+		return diags
+	}
+
+	for _, expression := range expressions {
+		paths, d := plan.PathMatches(ctx, expression)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+
+		for _, p := range paths {
+			// Parents which are null or unknown are matched as well
+			if !expression.Matches(p) {
+				continue
+			}
+
+			var value types.String
+			diags.Append(plan.GetAttribute(ctx, p, &value)...)
+			if diags.HasError() {
+				return diags
+			}
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+
+			if err := validator.ValidateCurrency(ctx, value.ValueString()); err != nil {
+				diags.AddAttributeError(p, "Invalid currency", err.Error())
+			}
+		}
+	}
+	return diags
+}
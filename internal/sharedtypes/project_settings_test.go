@@ -0,0 +1,56 @@
+package sharedtypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/labd/terraform-provider-commercetools/internal/utils"
+)
+
+func TestValidateCustomCurrenciesPlan(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Blocks: map[string]schema.Block{
+			"custom": CustomSchema,
+		},
+	}
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}
+	diags := plan.Set(ctx, &struct {
+		Custom *Custom `tfsdk:"custom"`
+	}{
+		Custom: &Custom{
+			TypeID: ref("type-id"),
+			Field: []CustomField{
+				{Name: "price", Money: &CustomFieldMoney{CurrencyCode: "EUR", CentAmount: 100}},
+				{Name: "prices", SetOfMoney: []CustomFieldMoney{
+					{CurrencyCode: "USD", CentAmount: 100},
+					{CurrencyCode: "GBP", CentAmount: 100},
+				}},
+			},
+		},
+	})
+	assert.False(t, diags.HasError(), diags)
+
+	settings := &utils.ProjectSettings{}
+	settings.Set(&platform.Project{Currencies: []string{"EUR", "USD"}})
+
+	diags = ValidateCustomCurrenciesPlan(ctx, utils.NewProjectValidator(nil, settings, true), plan)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "currency GBP is not enabled in the project, the currencies of the project are EUR, USD", diags[0].Detail())
+	assert.True(t, path.MatchRoot("custom").AtName("field").AtAnySetValue().AtName("set_of_money").AtListIndex(1).AtName("currency_code").
+		Matches(diags[0].(diag.DiagnosticWithPath).Path()))
+
+	diags = ValidateCustomCurrenciesPlan(ctx, utils.NewProjectValidator(nil, settings, false), plan)
+	assert.Empty(t, diags)
+}
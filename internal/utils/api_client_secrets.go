@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// APIClientSecrets holds the secrets of the API clients which were created or
+// rotated by this provider process. commercetools only returns the secret of a
+// client when it is created, the commercetools_api_client_secret ephemeral
+// resource delivers it from here during the same apply, so it doesn't have to
+// be stored in the state. The secrets are only kept in memory.
+type APIClientSecrets struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+var (
+	apiClientSecrets     = map[string]*APIClientSecrets{}
+	apiClientSecretsLock sync.Mutex
+)
+
+// APIClientSecretsFor returns the API client secrets for the given project,
+// creating them when needed, so the SDK resource and the framework ephemeral
+// resource share the secrets. See TypeCacheFor.
+func APIClientSecretsFor(apiURL, projectKey string) *APIClientSecrets {
+	key := fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), projectKey)
+
+	apiClientSecretsLock.Lock()
+	defer apiClientSecretsLock.Unlock()
+
+	if s, ok := apiClientSecrets[key]; ok {
+		return s
+	}
+	s := &APIClientSecrets{secrets: map[string]string{}}
+	apiClientSecrets[key] = s
+	return s
+}
+
+// Set stores the secret of the API client with the given ID
+func (s *APIClientSecrets) Set(id, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets[id] = secret
+}
+
+// Get returns the secret of the API client with the given ID, and whether the
+// client was created or rotated by this provider process
+func (s *APIClientSecrets) Get(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secret, ok := s.secrets[id]
+	return secret, ok
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIClientSecrets(t *testing.T) {
+	a := APIClientSecretsFor("https://api.europe-west1.gcp.commercetools.com/", "secrets-a")
+	b := APIClientSecretsFor("https://api.europe-west1.gcp.commercetools.com", "secrets-a")
+	c := APIClientSecretsFor("https://api.europe-west1.gcp.commercetools.com", "secrets-b")
+	assert.Same(t, a, b)
+	assert.NotSame(t, a, c)
+
+	_, ok := a.Get("client-1")
+	assert.False(t, ok)
+
+	a.Set("client-1", "secret")
+	secret, ok := b.Get("client-1")
+	assert.True(t, ok)
+	assert.Equal(t, "secret", secret)
+
+	_, ok = c.Get("client-1")
+	assert.False(t, ok)
+}
@@ -2,9 +2,34 @@ package utils
 
 import (
 	"github.com/labd/commercetools-go-sdk/platform"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 type ProviderData struct {
 	Client *platform.ByProjectKeyRequestBuilder
 	Mutex  *MutexKV
+
+	// ProjectKey and Credentials are the settings the client was created with.
+	// They are used by resources that need to run their own OAuth flow.
+	ProjectKey  string
+	Credentials *clientcredentials.Config
+
+	// TypeCache holds the custom types used by resources, see TypeCacheFor
+	TypeCache *TypeCache
+
+	// ProjectSettings caches the settings of the project used by Languages
+	// and ProjectValidator, see ProjectSettingsFor
+	ProjectSettings *ProjectSettings
+
+	// Languages validates localized strings during the plan, based on the
+	// validate_languages and required_languages settings
+	Languages *LanguageValidator
+
+	// ProjectValidator validates currencies and countries during the plan,
+	// based on the validate_project_settings setting
+	ProjectValidator *ProjectValidator
+
+	// APIClientSecrets holds the secrets of the API clients created during
+	// this run, see APIClientSecretsFor
+	APIClientSecrets *APIClientSecrets
 }
@@ -107,3 +107,23 @@ func IsResourceNotFoundError(err error) bool {
 	}
 	return false
 }
+
+// IsConcurrentModificationError returns whether the request failed because the
+// version of the resource didn't match the current version.
+func IsConcurrentModificationError(err error) bool {
+	var concurrentModification platform.ConcurrentModificationError
+	if errors.As(err, &concurrentModification) {
+		return true
+	}
+
+	var errorResponse platform.ErrorResponse
+	if errors.As(err, &errorResponse) {
+		return errorResponse.StatusCode == 409
+	}
+
+	var requestError platform.GenericRequestError
+	if errors.As(err, &requestError) {
+		return requestError.StatusCode == 409
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"github.com/labd/commercetools-go-sdk/platform"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -23,3 +24,21 @@ func TestIsResourceNotFoundError(t *testing.T) {
 		assert.Equal(t, tt.expected, IsResourceNotFoundError(tt.err))
 	}
 }
+
+func TestIsConcurrentModificationError(t *testing.T) {
+	var cases = []struct {
+		err      error
+		expected bool
+	}{
+		{platform.ConcurrentModificationError{}, true},
+		{platform.ErrorResponse{StatusCode: 409}, true},
+		{platform.GenericRequestError{StatusCode: 409}, true},
+		{fmt.Errorf("wrapped: %w", platform.GenericRequestError{StatusCode: 409}), true},
+		{platform.GenericRequestError{StatusCode: 404}, false},
+		{platform.ErrNotFound, false},
+	}
+
+	for _, tt := range cases {
+		assert.Equal(t, tt.expected, IsConcurrentModificationError(tt.err))
+	}
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var reJSONPathIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// DecodeJSON decodes the JSON document. Numbers are decoded as json.Number so
+// they keep their precision.
+func DecodeJSON(value string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	var result any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the value")
+	}
+	return result, nil
+}
+
+// JSONEqual returns whether the strings are equal when decoded as JSON. This
+// ignores differences in whitespace, the order of keys and the formatting of
+// numbers. Strings which are not valid JSON are compared as is.
+func JSONEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	aValue, aErr := DecodeJSON(a)
+	bValue, bErr := DecodeJSON(b)
+	if aErr != nil || bErr != nil {
+		return false
+	}
+	return JSONValuesEqual(aValue, bValue)
+}
+
+// JSONValuesEqual returns whether the decoded JSON values are equal. Numbers
+// are compared by value, so 10, 10.0 and 1e1 are equal.
+func JSONValuesEqual(a, b any) bool {
+	return len(JSONChanges(a, b)) == 0
+}
+
+// JSONChange is a change between two JSON values
+type JSONChange struct {
+	// Path is the JSONPath of the changed value, e.g. $.address.street
+	Path string
+	// Action is one of added, removed or changed
+	Action string
+}
+
+func (c JSONChange) String() string {
+	return fmt.Sprintf("%s (%s)", c.Path, c.Action)
+}
+
+// JSONChanges returns the paths which differ between the decoded JSON values.
+// Changes are reported at the deepest path where the values differ, ordered
+// by path.
+func JSONChanges(old, new any) []JSONChange {
+	return jsonChanges("$", old, new, nil)
+}
+
+func jsonChanges(path string, old, new any, result []JSONChange) []JSONChange {
+	switch oldValue := old.(type) {
+	case map[string]any:
+		newValue, ok := new.(map[string]any)
+		if !ok {
+			break
+		}
+		var keys []string
+		for key := range oldValue {
+			keys = append(keys, key)
+		}
+		for key := range newValue {
+			if _, ok := oldValue[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			oldElem, oldOk := oldValue[key]
+			newElem, newOk := newValue[key]
+			switch {
+			case !newOk:
+				result = append(result, JSONChange{Path: jsonPathKey(path, key), Action: "removed"})
+			case !oldOk:
+				result = append(result, JSONChange{Path: jsonPathKey(path, key), Action: "added"})
+			default:
+				result = jsonChanges(jsonPathKey(path, key), oldElem, newElem, result)
+			}
+		}
+		return result
+
+	case []any:
+		newValue, ok := new.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < max(len(oldValue), len(newValue)); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(newValue):
+				result = append(result, JSONChange{Path: elemPath, Action: "removed"})
+			case i >= len(oldValue):
+				result = append(result, JSONChange{Path: elemPath, Action: "added"})
+			default:
+				result = jsonChanges(elemPath, oldValue[i], newValue[i], result)
+			}
+		}
+		return result
+	}
+
+	if !jsonScalarEqual(old, new) {
+		result = append(result, JSONChange{Path: path, Action: "changed"})
+	}
+	return result
+}
+
+func jsonPathKey(path, key string) string {
+	if reJSONPathIdentifier.MatchString(key) {
+		return path + "." + key
+	}
+	quoted, _ := json.Marshal(key)
+	return fmt.Sprintf("%s[%s]", path, quoted)
+}
+
+func jsonScalarEqual(a, b any) bool {
+	aNumber, aOk := jsonNumber(a)
+	bNumber, bOk := jsonNumber(b)
+	if aOk || bOk {
+		return aOk && bOk && aNumber.Cmp(bNumber) == 0
+	}
+
+	switch a.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	switch b.(type) {
+	case map[string]any, []any:
+		return false
+	}
+
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aData, bData)
+}
+
+func jsonNumber(value any) (*big.Float, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		result, _, err := big.ParseFloat(string(v), 10, 256, big.ToNearestEven)
+		return result, err == nil
+	case float64:
+		return new(big.Float).SetFloat64(v), true
+	case int:
+		return new(big.Float).SetInt64(int64(v)), true
+	case int64:
+		return new(big.Float).SetInt64(v), true
+	}
+	return nil, false
+}
+
+// JSONMergePatch applies the patch to the target as described in RFC 7386.
+// Objects are merged recursively, null values remove the key from the target
+// and all other values replace the value of the target.
+func JSONMergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	result := map[string]any{}
+	if targetObject, ok := target.(map[string]any); ok {
+		for key, value := range targetObject {
+			result[key] = value
+		}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = JSONMergePatch(result[key], value)
+	}
+	return result
+}
+
+// JSONMergePatchProjection returns the part of the target which is managed by
+// the patch. The projection is equal to the patch when applying the patch to
+// the target doesn't change the target.
+func JSONMergePatchProjection(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return target
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		return target
+	}
+
+	result := map[string]any{}
+	for key, value := range patchObject {
+		targetValue, exists := targetObject[key]
+		switch {
+		case value == nil && !exists:
+			result[key] = nil
+		case exists:
+			result[key] = JSONMergePatchProjection(targetValue, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONEqual(t *testing.T) {
+	assert.True(t, JSONEqual("foo", "foo"))
+	assert.True(t, JSONEqual(`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`))
+	assert.True(t, JSONEqual(`{"a": 10}`, `{"a": 1e1}`))
+	assert.True(t, JSONEqual(`{"a": 10.0}`, `{"a": 10}`))
+	assert.True(t, JSONEqual(`12345678901234567890`, `12345678901234567890.0`))
+	assert.False(t, JSONEqual(`12345678901234567890`, `12345678901234567891`))
+	assert.False(t, JSONEqual(`{"a": 1}`, `{"a": 2}`))
+	assert.False(t, JSONEqual(`{"a": 1}`, `{"a": "1"}`))
+	assert.False(t, JSONEqual(`[1, 2]`, `[2, 1]`))
+	assert.False(t, JSONEqual(`{"a": null}`, `{}`))
+	assert.False(t, JSONEqual("foo", "bar"))
+	assert.False(t, JSONEqual(`{"a": 1} {"a": 1}`, `{"a": 1}`))
+}
+
+func TestJSONChanges(t *testing.T) {
+	decode := func(value string) any {
+		result, err := DecodeJSON(value)
+		assert.NoError(t, err)
+		return result
+	}
+
+	var cases = []struct {
+		name     string
+		old      string
+		new      string
+		expected []JSONChange
+	}{
+		{
+			name: "equal",
+			old:  `{"a": {"b": [1, 2.0]}}`,
+			new:  `{"a":{"b":[1.0,2]}}`,
+		},
+		{
+			name: "nested",
+			old:  `{"address": {"street": "foo", "number": 10}, "name": "John"}`,
+			new:  `{"address": {"street": "bar", "number": 10, "city": "Utrecht"}}`,
+			expected: []JSONChange{
+				{Path: "$.address.city", Action: "added"},
+				{Path: "$.address.street", Action: "changed"},
+				{Path: "$.name", Action: "removed"},
+			},
+		},
+		{
+			name: "arrays",
+			old:  `{"items": [1, {"a": 1}, 3]}`,
+			new:  `{"items": [1, {"a": 2}]}`,
+			expected: []JSONChange{
+				{Path: "$.items[1].a", Action: "changed"},
+				{Path: "$.items[2]", Action: "removed"},
+			},
+		},
+		{
+			name: "type change",
+			old:  `{"a": {"b": 1}}`,
+			new:  `{"a": [1]}`,
+			expected: []JSONChange{
+				{Path: "$.a", Action: "changed"},
+			},
+		},
+		{
+			name: "quoted keys",
+			old:  `{"my-key": 1}`,
+			new:  `{"my-key": 2}`,
+			expected: []JSONChange{
+				{Path: `$["my-key"]`, Action: "changed"},
+			},
+		},
+		{
+			name: "scalar",
+			old:  `10`,
+			new:  `"10"`,
+			expected: []JSONChange{
+				{Path: "$", Action: "changed"},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JSONChanges(decode(tt.old), decode(tt.new)))
+		})
+	}
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	decode := func(value string) any {
+		result, err := DecodeJSON(value)
+		assert.NoError(t, err)
+		return result
+	}
+
+	// Examples from RFC 7386
+	var cases = []struct {
+		target   string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.target+" "+tt.patch, func(t *testing.T) {
+			result := JSONMergePatch(decode(tt.target), decode(tt.patch))
+			assert.True(t, JSONValuesEqual(decode(tt.expected), result), "got %v", result)
+		})
+	}
+}
+
+func TestJSONMergePatchProjection(t *testing.T) {
+	decode := func(value string) any {
+		result, err := DecodeJSON(value)
+		assert.NoError(t, err)
+		return result
+	}
+
+	var cases = []struct {
+		name     string
+		target   string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "unmanaged keys are ignored",
+			target:   `{"a": 1, "b": {"c": 2, "d": 3}, "e": 4}`,
+			patch:    `{"a": 1, "b": {"c": 2}}`,
+			expected: `{"a": 1, "b": {"c": 2}}`,
+		},
+		{
+			name:     "changed values are kept",
+			target:   `{"a": 2, "b": [1, 2]}`,
+			patch:    `{"a": 1, "b": [1]}`,
+			expected: `{"a": 2, "b": [1, 2]}`,
+		},
+		{
+			name:     "missing keys are omitted",
+			target:   `{"b": 2}`,
+			patch:    `{"a": 1}`,
+			expected: `{}`,
+		},
+		{
+			name:     "removed keys",
+			target:   `{"b": 2}`,
+			patch:    `{"a": null, "b": null}`,
+			expected: `{"a": null, "b": 2}`,
+		},
+		{
+			name:     "non object target",
+			target:   `[1]`,
+			patch:    `{"a": 1}`,
+			expected: `[1]`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := JSONMergePatchProjection(decode(tt.target), decode(tt.patch))
+			assert.Equal(t, decode(tt.expected), result)
+		})
+	}
+}
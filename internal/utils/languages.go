@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+// LanguageValidator validates localized strings during the plan, against the
+// languages of the project and the languages every localized string needs a
+// value for. Both are configured in the provider.
+type LanguageValidator struct {
+	client   *platform.ByProjectKeyRequestBuilder
+	settings *ProjectSettings
+	fetch    ProjectFetcher
+
+	validateLanguages bool
+	requiredLanguages []string
+}
+
+func NewLanguageValidator(client *platform.ByProjectKeyRequestBuilder, settings *ProjectSettings, validateLanguages bool, requiredLanguages []string) *LanguageValidator {
+	return &LanguageValidator{
+		client:            client,
+		settings:          settings,
+		validateLanguages: validateLanguages,
+		requiredLanguages: requiredLanguages,
+	}
+}
+
+// LocalizedStringError is an error in a localized string. Key is the language
+// the error applies to, or empty when the error applies to the whole value.
+type LocalizedStringError struct {
+	Key string
+	Err error
+}
+
+// Enabled returns whether localized strings are validated at all, so callers
+// can skip collecting them.
+func (v *LanguageValidator) Enabled() bool {
+	return v != nil && (v.validateLanguages || len(v.requiredLanguages) > 0)
+}
+
+// Validate returns the errors in the localized string with the given
+// languages. The project is only fetched when validate_languages is enabled.
+func (v *LanguageValidator) Validate(ctx context.Context, keys []string) ([]LocalizedStringError, error) {
+	if !v.Enabled() || len(keys) == 0 {
+		return nil, nil
+	}
+
+	keys = slices.Sorted(slices.Values(keys))
+
+	var result []LocalizedStringError
+	if v.validateLanguages {
+		project, err := v.project(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if !slices.Contains(project.Languages, key) {
+				result = append(result, LocalizedStringError{
+					Key: key,
+					Err: fmt.Errorf("language %s is not enabled in the project, the languages of the project are %s",
+						key, strings.Join(project.Languages, ", ")),
+				})
+			}
+		}
+	}
+
+	var missing []string
+	for _, language := range v.requiredLanguages {
+		if !slices.Contains(keys, language) {
+			missing = append(missing, language)
+		}
+	}
+	if len(missing) > 0 {
+		result = append(result, LocalizedStringError{
+			Err: fmt.Errorf("missing a value for the required languages %s", strings.Join(missing, ", ")),
+		})
+	}
+
+	return result, nil
+}
+
+func (v *LanguageValidator) project(ctx context.Context) (*platform.Project, error) {
+	if v.fetch != nil {
+		return v.settings.GetWithFetcher(ctx, v.fetch)
+	}
+	return v.settings.Get(ctx, v.client)
+}
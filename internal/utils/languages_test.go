@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageValidator(t *testing.T) {
+	calls := 0
+	newValidator := func(validateLanguages bool, requiredLanguages []string) *LanguageValidator {
+		v := NewLanguageValidator(nil, &ProjectSettings{}, validateLanguages, requiredLanguages)
+		v.fetch = func(_ context.Context) (*platform.Project, error) {
+			calls++
+			return &platform.Project{Languages: []string{"de", "en", "es-419"}}, nil
+		}
+		return v
+	}
+	ctx := context.Background()
+
+	var v *LanguageValidator
+	assert.False(t, v.Enabled())
+	assert.False(t, newValidator(false, nil).Enabled())
+
+	t.Run("project languages", func(t *testing.T) {
+		v := newValidator(true, nil)
+		result, err := v.Validate(ctx, []string{"nl", "en", "es-419", "fr"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"fr", "nl"}, []string{result[0].Key, result[1].Key})
+		assert.EqualError(t, result[0].Err, "language fr is not enabled in the project, the languages of the project are de, en, es-419")
+
+		_, err = v.Validate(ctx, []string{"en"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls, "the project should be fetched once")
+	})
+
+	t.Run("required languages", func(t *testing.T) {
+		v := newValidator(false, []string{"en", "de", "nl"})
+		result, err := v.Validate(ctx, []string{"en"})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Empty(t, result[0].Key)
+		assert.EqualError(t, result[0].Err, "missing a value for the required languages de, nl")
+
+		result, err = v.Validate(ctx, []string{"de", "en", "nl"})
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.Equal(t, 1, calls, "the project should not be fetched")
+	})
+
+	t.Run("empty values are not validated", func(t *testing.T) {
+		result, err := newValidator(true, []string{"en"}).Validate(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+type ProjectFetcher func(ctx context.Context) (*platform.Project, error)
+
+// ProjectSettings caches the settings of the project, like the enabled
+// languages, which are used to validate values during the plan. The project is
+// fetched once, instead of once for every resource.
+type ProjectSettings struct {
+	mu      sync.Mutex
+	project *platform.Project
+}
+
+var (
+	projectSettings     = map[string]*ProjectSettings{}
+	projectSettingsLock sync.Mutex
+)
+
+// ProjectSettingsFor returns the project settings for the given project,
+// creating them when needed, so the SDK and the framework provider share the
+// settings. See TypeCacheFor.
+func ProjectSettingsFor(apiURL, projectKey string) *ProjectSettings {
+	key := fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), projectKey)
+
+	projectSettingsLock.Lock()
+	defer projectSettingsLock.Unlock()
+
+	if s, ok := projectSettings[key]; ok {
+		return s
+	}
+	s := &ProjectSettings{}
+	projectSettings[key] = s
+	return s
+}
+
+// Get returns the project, fetching it from commercetools when it is not
+// cached yet.
+func (s *ProjectSettings) Get(ctx context.Context, client *platform.ByProjectKeyRequestBuilder) (*platform.Project, error) {
+	return s.GetWithFetcher(ctx, func(ctx context.Context) (*platform.Project, error) {
+		return client.Get().Execute(ctx)
+	})
+}
+
+// GetWithFetcher returns the project, using fetch to retrieve it when it is
+// not cached yet. Failures are never cached.
+func (s *ProjectSettings) GetWithFetcher(ctx context.Context, fetch ProjectFetcher) (*platform.Project, error) {
+	// The lock is kept while fetching, so resources which are planned
+	// concurrently wait for the first fetch instead of fetching again
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.project != nil {
+		return s.project, nil
+	}
+
+	project, err := fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the project settings: %w", err)
+	}
+	if project == nil {
+		return nil, fmt.Errorf("failed to fetch the project settings: empty response")
+	}
+	s.project = project
+	return project, nil
+}
+
+// Set stores the project, so values are validated against these settings
+// without fetching the project.
+func (s *ProjectSettings) Set(project *platform.Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.project = project
+}
+
+// ProjectValidator validates currencies, countries and shipping rate tiers
+// during the plan against the settings of the project, based on the
+// validate_project_settings setting of the provider.
+type ProjectValidator struct {
+	client   *platform.ByProjectKeyRequestBuilder
+	settings *ProjectSettings
+	fetch    ProjectFetcher
+	enabled  bool
+}
+
+func NewProjectValidator(client *platform.ByProjectKeyRequestBuilder, settings *ProjectSettings, enabled bool) *ProjectValidator {
+	return &ProjectValidator{
+		client:   client,
+		settings: settings,
+		enabled:  enabled,
+	}
+}
+
+// Enabled returns whether values are validated against the project settings
+func (v *ProjectValidator) Enabled() bool {
+	return v != nil && v.enabled
+}
+
+// ValidateCurrency returns an error when the currency is not one of the
+// currencies of the project
+func (v *ProjectValidator) ValidateCurrency(ctx context.Context, currency string) error {
+	project, err := v.project(ctx)
+	if project == nil || err != nil {
+		return err
+	}
+	if !slices.Contains(project.Currencies, currency) {
+		return fmt.Errorf("currency %s is not enabled in the project, the currencies of the project are %s",
+			currency, strings.Join(project.Currencies, ", "))
+	}
+	return nil
+}
+
+// ValidateCountry returns an error when the country is not one of the
+// countries of the project
+func (v *ProjectValidator) ValidateCountry(ctx context.Context, country string) error {
+	project, err := v.project(ctx)
+	if project == nil || err != nil {
+		return err
+	}
+	if !slices.Contains(project.Countries, country) {
+		return fmt.Errorf("country %s is not enabled in the project, the countries of the project are %s",
+			country, strings.Join(project.Countries, ", "))
+	}
+	return nil
+}
+
+// ValidateShippingRateTier returns an error when the type of the shipping rate
+// price tier doesn't match the shipping rate input type of the project, or when
+// the value of a CartClassification tier is not one of the classification
+// values of the project
+func (v *ProjectValidator) ValidateShippingRateTier(ctx context.Context, tierType, value string) error {
+	project, err := v.project(ctx)
+	if project == nil || err != nil {
+		return err
+	}
+
+	var inputType string
+	var keys []string
+	switch t := project.ShippingRateInputType.(type) {
+	case platform.CartValueType:
+		inputType = string(platform.ShippingRateTierTypeCartValue)
+	case platform.CartScoreType:
+		inputType = string(platform.ShippingRateTierTypeCartScore)
+	case platform.CartClassificationType:
+		inputType = string(platform.ShippingRateTierTypeCartClassification)
+		for _, value := range t.Values {
+			keys = append(keys, value.Key)
+		}
+	default:
+		return fmt.Errorf("tier type %s can not be used, the project has no shipping rate input type. "+
+			"Set shipping_rate_input_type of the project settings", tierType)
+	}
+
+	if tierType != inputType {
+		return fmt.Errorf("tier type %s does not match the shipping rate input type of the project, which is %s",
+			tierType, inputType)
+	}
+	if inputType == string(platform.ShippingRateTierTypeCartClassification) && !slices.Contains(keys, value) {
+		return fmt.Errorf("value %s is not a cart classification value of the project, the values of the project are %s",
+			value, strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+// project returns the project, or nil when validate_project_settings is
+// disabled. A client which isn't allowed to view the project settings is
+// reported as an error, since the validation was enabled explicitly.
+func (v *ProjectValidator) project(ctx context.Context) (*platform.Project, error) {
+	if !v.Enabled() {
+		return nil, nil
+	}
+
+	var project *platform.Project
+	var err error
+	if v.fetch != nil {
+		project, err = v.settings.GetWithFetcher(ctx, v.fetch)
+	} else {
+		project, err = v.settings.Get(ctx, v.client)
+	}
+	if isForbiddenError(err) {
+		return nil, fmt.Errorf("validate_project_settings is enabled, but the API client isn't allowed to view "+
+			"the project settings. Add the view_project_settings scope or disable validate_project_settings: %w", err)
+	}
+	return project, err
+}
+
+func isForbiddenError(err error) bool {
+	var errorResponse platform.ErrorResponse
+	if errors.As(err, &errorResponse) {
+		return errorResponse.StatusCode == 401 || errorResponse.StatusCode == 403
+	}
+
+	var requestError platform.GenericRequestError
+	if errors.As(err, &requestError) {
+		return requestError.StatusCode == 401 || requestError.StatusCode == 403
+	}
+	return false
+}
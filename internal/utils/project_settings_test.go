@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectSettingsGet(t *testing.T) {
+	s := &ProjectSettings{}
+
+	calls := 0
+	fetch := func(_ context.Context) (*platform.Project, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("unavailable")
+		}
+		return &platform.Project{Languages: []string{"en"}}, nil
+	}
+
+	_, err := s.GetWithFetcher(context.Background(), fetch)
+	assert.ErrorContains(t, err, "failed to fetch the project settings: unavailable")
+
+	for i := 0; i < 3; i++ {
+		project, err := s.GetWithFetcher(context.Background(), fetch)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"en"}, project.Languages)
+	}
+	assert.Equal(t, 2, calls, "failures should not be cached")
+}
+
+func TestProjectValidator(t *testing.T) {
+	ctx := context.Background()
+	settings := &ProjectSettings{}
+	settings.Set(&platform.Project{Currencies: []string{"EUR", "USD"}, Countries: []string{"DE", "NL"}})
+
+	v := NewProjectValidator(nil, settings, true)
+	assert.NoError(t, v.ValidateCurrency(ctx, "EUR"))
+	assert.EqualError(t, v.ValidateCurrency(ctx, "GBP"),
+		"currency GBP is not enabled in the project, the currencies of the project are EUR, USD")
+	assert.NoError(t, v.ValidateCountry(ctx, "NL"))
+	assert.EqualError(t, v.ValidateCountry(ctx, "BE"),
+		"country BE is not enabled in the project, the countries of the project are DE, NL")
+
+	// Nothing is validated when the validation is disabled
+	v = NewProjectValidator(nil, settings, false)
+	assert.False(t, v.Enabled())
+	assert.NoError(t, v.ValidateCurrency(ctx, "GBP"))
+
+	// A client which can't view the project settings is reported, since the
+	// validation is enabled explicitly
+	v = NewProjectValidator(nil, &ProjectSettings{}, true)
+	v.fetch = func(_ context.Context) (*platform.Project, error) {
+		return nil, platform.ErrorResponse{StatusCode: 403, Message: "insufficient_scope"}
+	}
+	assert.ErrorContains(t, v.ValidateCurrency(ctx, "GBP"), "validate_project_settings is enabled, but the "+
+		"API client isn't allowed to view the project settings. Add the view_project_settings scope or "+
+		"disable validate_project_settings")
+
+	v.fetch = func(_ context.Context) (*platform.Project, error) {
+		return nil, platform.ErrorResponse{StatusCode: 500, Message: "failure"}
+	}
+	assert.ErrorContains(t, v.ValidateCountry(ctx, "BE"), "failed to fetch the project settings")
+}
+
+func TestProjectValidatorShippingRateTier(t *testing.T) {
+	ctx := context.Background()
+	settings := &ProjectSettings{}
+	settings.Set(&platform.Project{
+		ShippingRateInputType: platform.CartClassificationType{
+			Values: []platform.CustomFieldLocalizedEnumValue{{Key: "Light"}, {Key: "Heavy"}},
+		},
+	})
+
+	v := NewProjectValidator(nil, settings, true)
+	assert.NoError(t, v.ValidateShippingRateTier(ctx, "CartClassification", "Light"))
+	assert.EqualError(t, v.ValidateShippingRateTier(ctx, "CartClassification", "Medium"),
+		"value Medium is not a cart classification value of the project, the values of the project are Light, Heavy")
+	assert.EqualError(t, v.ValidateShippingRateTier(ctx, "CartScore", ""),
+		"tier type CartScore does not match the shipping rate input type of the project, which is CartClassification")
+
+	settings.Set(&platform.Project{ShippingRateInputType: platform.CartScoreType{}})
+	assert.NoError(t, v.ValidateShippingRateTier(ctx, "CartScore", ""))
+
+	settings.Set(&platform.Project{})
+	assert.EqualError(t, v.ValidateShippingRateTier(ctx, "CartValue", ""),
+		"tier type CartValue can not be used, the project has no shipping rate input type. "+
+			"Set shipping_rate_input_type of the project settings")
+}
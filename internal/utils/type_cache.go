@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labd/commercetools-go-sdk/platform"
+)
+
+type TypeFetcher func(ctx context.Context, id string) (*platform.Type, error)
+
+// TypeCache caches custom types by ID to minimize API calls when multiple
+// resources use the same type. Types managed by the commercetools_type
+// resource are updated in the cache when they are created, read or updated so
+// resources using a type during the same apply always see the latest
+// definition.
+type TypeCache struct {
+	mu    sync.Mutex
+	types map[string]*platform.Type
+
+	attempts   int
+	retryDelay time.Duration
+}
+
+func NewTypeCache() *TypeCache {
+	return &TypeCache{
+		types:      make(map[string]*platform.Type),
+		attempts:   3,
+		retryDelay: 500 * time.Millisecond,
+	}
+}
+
+var (
+	typeCaches     = map[string]*TypeCache{}
+	typeCachesLock sync.Mutex
+)
+
+// TypeCacheFor returns the type cache for the given project, creating it when
+// needed. Both the SDK and the framework provider are configured separately,
+// this allows them to share a single cache when they are configured for the
+// same project.
+func TypeCacheFor(apiURL, projectKey string) *TypeCache {
+	key := fmt.Sprintf("%s/%s", strings.TrimRight(apiURL, "/"), projectKey)
+
+	typeCachesLock.Lock()
+	defer typeCachesLock.Unlock()
+
+	if c, ok := typeCaches[key]; ok {
+		return c
+	}
+	c := NewTypeCache()
+	typeCaches[key] = c
+	return c
+}
+
+// Get returns the type with the given ID, fetching it from commercetools when
+// it is not cached yet.
+func (c *TypeCache) Get(ctx context.Context, client *platform.ByProjectKeyRequestBuilder, id string) (*platform.Type, error) {
+	return c.GetWithFetcher(ctx, id, func(ctx context.Context, id string) (*platform.Type, error) {
+		return client.Types().WithId(id).Get().Execute(ctx)
+	})
+}
+
+// GetWithFetcher returns the type with the given ID, using fetch to retrieve
+// it when it is not cached yet. Transient errors are retried, failures are
+// never cached.
+func (c *TypeCache) GetWithFetcher(ctx context.Context, id string, fetch TypeFetcher) (*platform.Type, error) {
+	c.mu.Lock()
+	t, ok := c.types[id]
+	c.mu.Unlock()
+	if ok {
+		return t, nil
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		t, err = fetch(ctx, id)
+		if err == nil || attempt >= c.attempts || !isTransientError(err) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt) * c.retryDelay):
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("failed to fetch type %s: empty response", id)
+	}
+
+	c.Set(t)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.types[id], nil
+}
+
+// Set stores the given type in the cache, unless a newer version of the type
+// is already cached.
+func (c *TypeCache) Set(t *platform.Type) {
+	if t == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current, ok := c.types[t.ID]; ok && current.Version > t.Version {
+		return
+	}
+	c.types[t.ID] = t
+}
+
+// Invalidate removes the type with the given ID from the cache.
+func (c *TypeCache) Invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.types, id)
+}
+
+// isTransientError returns true for errors which might succeed when retried:
+// network errors, rate limiting and server errors. Other errors, like invalid
+// requests, missing permissions or responses which can't be decoded, are
+// returned immediately.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var errorResponse platform.ErrorResponse
+	if errors.As(err, &errorResponse) {
+		return isTransientStatusCode(errorResponse.StatusCode)
+	}
+	var requestError platform.GenericRequestError
+	if errors.As(err, &requestError) {
+		return isTransientStatusCode(requestError.StatusCode)
+	}
+
+	// Connections which are reset or closed while reading the response
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func isTransientStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
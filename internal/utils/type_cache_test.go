@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labd/commercetools-go-sdk/platform"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTypeCache() *TypeCache {
+	c := NewTypeCache()
+	c.retryDelay = 0
+	return c
+}
+
+func TestTypeCacheGet(t *testing.T) {
+	c := newTestTypeCache()
+
+	calls := 0
+	fetch := func(_ context.Context, id string) (*platform.Type, error) {
+		calls++
+		return &platform.Type{ID: id, Version: 1}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := c.GetWithFetcher(context.Background(), "type-1", fetch)
+		assert.NoError(t, err)
+		assert.Equal(t, "type-1", result.ID)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestTypeCacheGetFailureNotCached(t *testing.T) {
+	c := newTestTypeCache()
+
+	calls := 0
+	fetch := func(_ context.Context, id string) (*platform.Type, error) {
+		calls++
+		if calls == 1 {
+			return nil, platform.ErrNotFound
+		}
+		return &platform.Type{ID: id}, nil
+	}
+
+	_, err := c.GetWithFetcher(context.Background(), "type-1", fetch)
+	assert.True(t, IsResourceNotFoundError(err))
+	assert.Equal(t, 1, calls, "not found errors should not be retried")
+
+	result, err := c.GetWithFetcher(context.Background(), "type-1", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, "type-1", result.ID)
+	assert.Equal(t, 2, calls)
+}
+
+func TestTypeCacheGetRetriesTransientErrors(t *testing.T) {
+	var cases = []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"server error", platform.ErrorResponse{StatusCode: 503}, 3},
+		{"rate limited", platform.GenericRequestError{StatusCode: 429}, 3},
+		{"bad request", platform.ErrorResponse{StatusCode: 400}, 1},
+		{"not found", platform.ResourceNotFoundError{}, 1},
+		{"network error", &url.Error{Op: "Get", URL: "https://api", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, 3},
+		{"connection closed", fmt.Errorf("reading response: %w", io.ErrUnexpectedEOF), 3},
+		{"unknown error", errors.New("invalid character '<' looking for beginning of value"), 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestTypeCache()
+
+			calls := 0
+			_, err := c.GetWithFetcher(context.Background(), "type-1", func(_ context.Context, id string) (*platform.Type, error) {
+				calls++
+				return nil, tc.err
+			})
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, calls)
+		})
+	}
+}
+
+func TestTypeCacheGetRecoversFromTransientError(t *testing.T) {
+	c := newTestTypeCache()
+
+	calls := 0
+	result, err := c.GetWithFetcher(context.Background(), "type-1", func(_ context.Context, id string) (*platform.Type, error) {
+		calls++
+		if calls < 3 {
+			return nil, platform.ErrorResponse{StatusCode: 502}
+		}
+		return &platform.Type{ID: id}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "type-1", result.ID)
+	assert.Equal(t, 3, calls)
+}
+
+func TestTypeCacheSet(t *testing.T) {
+	c := newTestTypeCache()
+	fetch := func(_ context.Context, id string) (*platform.Type, error) {
+		t.Fatalf("unexpected fetch of type %s", id)
+		return nil, nil
+	}
+
+	c.Set(&platform.Type{ID: "type-1", Version: 2, Key: "v2"})
+
+	result, err := c.GetWithFetcher(context.Background(), "type-1", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", result.Key)
+
+	// An older version should never replace a newer one
+	c.Set(&platform.Type{ID: "type-1", Version: 1, Key: "v1"})
+	result, _ = c.GetWithFetcher(context.Background(), "type-1", fetch)
+	assert.Equal(t, "v2", result.Key)
+
+	c.Set(&platform.Type{ID: "type-1", Version: 3, Key: "v3"})
+	result, _ = c.GetWithFetcher(context.Background(), "type-1", fetch)
+	assert.Equal(t, "v3", result.Key)
+}
+
+func TestTypeCacheInvalidate(t *testing.T) {
+	c := newTestTypeCache()
+
+	version := 0
+	fetch := func(_ context.Context, id string) (*platform.Type, error) {
+		version++
+		return &platform.Type{ID: id, Version: version}, nil
+	}
+
+	result, _ := c.GetWithFetcher(context.Background(), "type-1", fetch)
+	assert.Equal(t, 1, result.Version)
+
+	c.Invalidate("type-1")
+
+	result, _ = c.GetWithFetcher(context.Background(), "type-1", fetch)
+	assert.Equal(t, 2, result.Version)
+}
+
+func TestTypeCacheFor(t *testing.T) {
+	a := TypeCacheFor("https://api.europe-west1.gcp.commercetools.com/", "project-a")
+	b := TypeCacheFor("https://api.europe-west1.gcp.commercetools.com", "project-a")
+	c := TypeCacheFor("https://api.europe-west1.gcp.commercetools.com", "project-b")
+
+	assert.Same(t, a, b)
+	assert.NotSame(t, a, c)
+}
+
+func TestTypeCacheConcurrent(t *testing.T) {
+	c := newTestTypeCache()
+	var ids = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+
+	var calls atomic.Int32
+	wg := &sync.WaitGroup{}
+	for pid := 0; pid < 1000; pid++ {
+		wg.Add(1)
+		go func(pid int) {
+			defer wg.Done()
+			id := ids[pid%len(ids)]
+			result, err := c.GetWithFetcher(context.Background(), id, func(_ context.Context, id string) (*platform.Type, error) {
+				calls.Add(1)
+				time.Sleep(time.Duration(rand.Intn(10)) * time.Millisecond)
+				return &platform.Type{ID: id}, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, id, result.ID)
+			if pid%7 == 0 {
+				c.Invalidate(id)
+			}
+		}(pid)
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, int(calls.Load()), len(ids))
+}